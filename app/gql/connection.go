@@ -0,0 +1,107 @@
+package gql
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/graphql-go/graphql"
+)
+
+const defaultPageSize = 20
+
+type edge struct {
+	Cursor string      `json:"cursor"`
+	Node   interface{} `json:"node"`
+}
+
+type pageInfo struct {
+	HasNextPage bool   `json:"hasNextPage"`
+	EndCursor   string `json:"endCursor"`
+}
+
+type connection struct {
+	Edges    []edge   `json:"edges"`
+	PageInfo pageInfo `json:"pageInfo"`
+}
+
+var pageInfoType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "PageInfo",
+	Fields: graphql.Fields{
+		"hasNextPage": &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+		"endCursor":   &graphql.Field{Type: graphql.String},
+	},
+})
+
+func newConnectionType(name string, node graphql.Output) *graphql.Object {
+	edgeType := graphql.NewObject(graphql.ObjectConfig{
+		Name: name + "Edge",
+		Fields: graphql.Fields{
+			"cursor": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"node":   &graphql.Field{Type: node},
+		},
+	})
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: name + "Connection",
+		Fields: graphql.Fields{
+			"edges":    &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(edgeType)))},
+			"pageInfo": &graphql.Field{Type: graphql.NewNonNull(pageInfoType)},
+		},
+	})
+}
+
+// connectionArgs are shared by all connections, extra arguments are appended per field
+func connectionArgs(extra graphql.FieldConfigArgument) graphql.FieldConfigArgument {
+	args := graphql.FieldConfigArgument{
+		"first": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: defaultPageSize},
+		"after": &graphql.ArgumentConfig{Type: graphql.String},
+	}
+	for name, arg := range extra {
+		args[name] = arg
+	}
+	return args
+}
+
+var errFirstNotPositive = fmt.Errorf("first must be at least 1")
+
+// pageArgs reads first and after, first below 1 is rejected and first above maxLimit is cut down to it
+func pageArgs(p graphql.ResolveParams, maxLimit int) (first int, after string, err error) {
+	first, _ = p.Args["first"].(int)
+	if first < 1 {
+		return 0, "", errFirstNotPositive
+	}
+	if first > maxLimit {
+		first = maxLimit
+	}
+	if cursor, isSet := p.Args["after"].(string); isSet && cursor != "" {
+		after, err = decodeCursor(cursor)
+	}
+	return
+}
+
+// newConnection builds a page out of first+1 fetched nodes, the extra node only tells there is a next page
+func newConnection(nodes []interface{}, first int, cursorOf func(node interface{}) string) *connection {
+	result := &connection{Edges: []edge{}}
+	if len(nodes) > first {
+		nodes = nodes[:first]
+		result.PageInfo.HasNextPage = true
+	}
+	for _, node := range nodes {
+		result.Edges = append(result.Edges, edge{Cursor: encodeCursor(cursorOf(node)), Node: node})
+	}
+	if len(result.Edges) > 0 {
+		result.PageInfo.EndCursor = result.Edges[len(result.Edges)-1].Cursor
+	}
+	return result
+}
+
+func encodeCursor(value string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(value))
+}
+
+func decodeCursor(cursor string) (string, error) {
+	value, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return "", fmt.Errorf("invalid cursor %q", cursor)
+	}
+	return string(value), nil
+}
@@ -0,0 +1,152 @@
+package gql
+
+import (
+	"Technopark_DB_Project/app/usecases"
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+)
+
+type Request struct {
+	Query         string                 `json:"query"`
+	Variables     map[string]interface{} `json:"variables"`
+	OperationName string                 `json:"operationName"`
+}
+
+type Executor struct {
+	schema        graphql.Schema
+	maxDepth      int
+	maxComplexity int
+	maxLimit      int
+
+	userUseCase   usecases.UserUseCase
+	forumUseCase  usecases.ForumUseCase
+	threadUseCase usecases.ThreadUseCase
+}
+
+func CreateExecutor(maxDepth, maxComplexity, maxLimit int, userUseCase usecases.UserUseCase, forumUseCase usecases.ForumUseCase,
+	threadUseCase usecases.ThreadUseCase, postUseCase usecases.PostUseCase, serviceUseCase usecases.ServiceUseCase) (*Executor, error) {
+	resolver := &resolver{
+		maxLimit:       maxLimit,
+		userUseCase:    userUseCase,
+		forumUseCase:   forumUseCase,
+		threadUseCase:  threadUseCase,
+		postUseCase:    postUseCase,
+		serviceUseCase: serviceUseCase,
+	}
+	schema, err := newSchema(resolver)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Executor{
+		schema:        schema,
+		maxDepth:      maxDepth,
+		maxComplexity: maxComplexity,
+		maxLimit:      maxLimit,
+		userUseCase:   userUseCase,
+		forumUseCase:  forumUseCase,
+		threadUseCase: threadUseCase,
+	}, nil
+}
+
+// Execute runs a query with fresh loaders, so lookups are batched and cached only within the request
+func (executor *Executor) Execute(ctx context.Context, request Request) *graphql.Result {
+	document, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{Body: []byte(request.Query)})})
+	if err != nil {
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(err)}
+	}
+
+	validation := graphql.ValidateDocument(&executor.schema, document, nil)
+	if !validation.IsValid {
+		return &graphql.Result{Errors: validation.Errors}
+	}
+
+	err = checkLimits(document, request.OperationName, request.Variables, executor.maxDepth, executor.maxComplexity, executor.maxLimit)
+	if err != nil {
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(err)}
+	}
+
+	return graphql.Execute(graphql.ExecuteParams{
+		Schema:        executor.schema,
+		AST:           document,
+		OperationName: request.OperationName,
+		Args:          request.Variables,
+		Context:       context.WithValue(ctx, loadersKey{}, executor.newLoaders()),
+	})
+}
+
+type loadersKey struct{}
+
+type loaders struct {
+	users   *loader
+	forums  *loader
+	threads *loader
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
+
+// Nicknames and slugs are case insensitive, so they are keyed in lower case
+func (executor *Executor) newLoaders() *loaders {
+	return &loaders{
+		users: newLoader(func(keys []string) (map[string]interface{}, error) {
+			users, err := executor.userUseCase.GetByNicknames(keys)
+			if err != nil {
+				return nil, err
+			}
+			values := make(map[string]interface{}, len(*users))
+			for i := range *users {
+				values[strings.ToLower((*users)[i].Nickname)] = &(*users)[i]
+			}
+			return values, nil
+		}),
+		forums: newLoader(func(keys []string) (map[string]interface{}, error) {
+			forums, err := executor.forumUseCase.GetBySlugs(keys)
+			if err != nil {
+				return nil, err
+			}
+			values := make(map[string]interface{}, len(*forums))
+			for i := range *forums {
+				values[strings.ToLower((*forums)[i].Slug)] = &(*forums)[i]
+			}
+			return values, nil
+		}),
+		threads: newLoader(func(keys []string) (map[string]interface{}, error) {
+			ids := make([]int64, 0, len(keys))
+			for _, key := range keys {
+				id, err := strconv.ParseInt(key, 10, 64)
+				if err == nil {
+					ids = append(ids, id)
+				}
+			}
+			threads, err := executor.threadUseCase.GetByIDs(ids)
+			if err != nil {
+				return nil, err
+			}
+			values := make(map[string]interface{}, len(*threads))
+			for i := range *threads {
+				values[strconv.FormatInt((*threads)[i].ID, 10)] = &(*threads)[i]
+			}
+			return values, nil
+		}),
+	}
+}
+
+func (loaders *loaders) user(nickname string) func() (interface{}, error) {
+	return loaders.users.Load(strings.ToLower(nickname))
+}
+
+func (loaders *loaders) forum(slug string) func() (interface{}, error) {
+	return loaders.forums.Load(strings.ToLower(slug))
+}
+
+func (loaders *loaders) thread(id int64) func() (interface{}, error) {
+	return loaders.threads.Load(strconv.FormatInt(id, 10))
+}
@@ -0,0 +1,128 @@
+package gql
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// connectionFields are list fields paginated with first/after
+var connectionFields = map[string]bool{
+	"threads": true,
+	"posts":   true,
+	"users":   true,
+	"voters":  true,
+}
+
+type limitsChecker struct {
+	fragments     map[string]*ast.FragmentDefinition
+	variables     map[string]interface{}
+	maxComplexity int
+	maxLimit      int
+	err           error
+}
+
+// checkLimits rejects operations nested deeper than maxDepth or costing more than maxComplexity.
+// Every field costs one, and the cost of a connection's selection is multiplied by its page size,
+// which is first kept within 1..maxLimit the same way the resolvers keep it.
+func checkLimits(document *ast.Document, operationName string, variables map[string]interface{}, maxDepth, maxComplexity, maxLimit int) error {
+	checker := &limitsChecker{
+		fragments:     make(map[string]*ast.FragmentDefinition),
+		variables:     variables,
+		maxComplexity: maxComplexity,
+		maxLimit:      maxLimit,
+	}
+	var operations []*ast.OperationDefinition
+	for _, definition := range document.Definitions {
+		switch definition := definition.(type) {
+		case *ast.FragmentDefinition:
+			checker.fragments[definition.Name.Value] = definition
+		case *ast.OperationDefinition:
+			if operationName == "" || (definition.Name != nil && definition.Name.Value == operationName) {
+				operations = append(operations, definition)
+			}
+		}
+	}
+
+	for _, operation := range operations {
+		depth, complexity := checker.measure(operation.SelectionSet, 0, make(map[string]bool))
+		if checker.err != nil {
+			return checker.err
+		}
+		if depth > maxDepth {
+			return fmt.Errorf("query depth %d exceeds the limit of %d", depth, maxDepth)
+		}
+		if complexity > maxComplexity {
+			return fmt.Errorf("query complexity %d exceeds the limit of %d", complexity, maxComplexity)
+		}
+	}
+	return nil
+}
+
+func (checker *limitsChecker) measure(selectionSet *ast.SelectionSet, depth int, visitedFragments map[string]bool) (maxDepth, complexity int) {
+	maxDepth = depth
+	if selectionSet == nil {
+		return
+	}
+
+	for _, selection := range selectionSet.Selections {
+		var selectionDepth, selectionComplexity int
+		switch selection := selection.(type) {
+		case *ast.Field:
+			selectionDepth, selectionComplexity = checker.measure(selection.SelectionSet, depth+1, visitedFragments)
+			selectionComplexity = 1 + checker.pageSize(selection)*selectionComplexity
+			if selectionComplexity > checker.maxComplexity {
+				selectionComplexity = checker.maxComplexity + 1
+			}
+		case *ast.InlineFragment:
+			selectionDepth, selectionComplexity = checker.measure(selection.SelectionSet, depth, visitedFragments)
+		case *ast.FragmentSpread:
+			fragment, isFound := checker.fragments[selection.Name.Value]
+			if !isFound || visitedFragments[selection.Name.Value] {
+				continue
+			}
+			visitedFragments[selection.Name.Value] = true
+			selectionDepth, selectionComplexity = checker.measure(fragment.SelectionSet, depth, visitedFragments)
+			delete(visitedFragments, selection.Name.Value)
+		}
+
+		if selectionDepth > maxDepth {
+			maxDepth = selectionDepth
+		}
+		complexity += selectionComplexity
+		if complexity > checker.maxComplexity {
+			complexity = checker.maxComplexity + 1
+		}
+	}
+	return
+}
+
+func (checker *limitsChecker) pageSize(field *ast.Field) int {
+	if !connectionFields[field.Name.Value] || field.SelectionSet == nil {
+		return 1
+	}
+	for _, argument := range field.Arguments {
+		if argument.Name.Value != "first" {
+			continue
+		}
+		first := float64(defaultPageSize)
+		switch value := argument.Value.(type) {
+		case *ast.IntValue:
+			first, _ = strconv.ParseFloat(value.Value, 64)
+		case *ast.Variable:
+			if variable, isSet := checker.variables[value.Name.Value].(float64); isSet {
+				first = variable
+			}
+		}
+		if first < 1 {
+			checker.err = errFirstNotPositive
+			return 1
+		}
+		if first > float64(checker.maxLimit) {
+			return checker.maxLimit
+		}
+		return int(first)
+	}
+	return defaultPageSize
+}
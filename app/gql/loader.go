@@ -0,0 +1,61 @@
+package gql
+
+import "sync"
+
+// batchFunc fetches all keys at once, keys that are not found are left out of values
+type batchFunc func(keys []string) (values map[string]interface{}, err error)
+
+// loader batches lookups made by sibling resolvers. The executor resolves a level of the query
+// before calling the thunks it got back, so every key of the level is registered by the time
+// the first thunk runs and triggers a single batchFunc call.
+type loader struct {
+	mutex   sync.Mutex
+	fetch   batchFunc
+	pending []string
+	values  map[string]interface{}
+	errors  map[string]error
+}
+
+func newLoader(fetch batchFunc) *loader {
+	return &loader{
+		fetch:  fetch,
+		values: make(map[string]interface{}),
+		errors: make(map[string]error),
+	}
+}
+
+// Load registers key for the next batch, the returned thunk yields nil for keys that were not found
+func (loader *loader) Load(key string) func() (interface{}, error) {
+	loader.mutex.Lock()
+	if _, isLoaded := loader.values[key]; !isLoaded {
+		loader.pending = append(loader.pending, key)
+		loader.values[key] = nil
+	}
+	loader.mutex.Unlock()
+
+	return func() (interface{}, error) {
+		loader.mutex.Lock()
+		defer loader.mutex.Unlock()
+
+		if len(loader.pending) > 0 {
+			loader.flush()
+		}
+		return loader.values[key], loader.errors[key]
+	}
+}
+
+func (loader *loader) flush() {
+	keys := loader.pending
+	loader.pending = nil
+
+	values, err := loader.fetch(keys)
+	for _, key := range keys {
+		if err != nil {
+			loader.errors[key] = err
+			continue
+		}
+		if value, isFound := values[key]; isFound {
+			loader.values[key] = value
+		}
+	}
+}
@@ -0,0 +1,400 @@
+package gql
+
+import (
+	"Technopark_DB_Project/app/models"
+	"Technopark_DB_Project/app/usecases"
+	"Technopark_DB_Project/pkg/errors"
+	"Technopark_DB_Project/pkg/expand"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/graphql-go/graphql"
+)
+
+type resolver struct {
+	maxLimit int
+
+	userUseCase    usecases.UserUseCase
+	forumUseCase   usecases.ForumUseCase
+	threadUseCase  usecases.ThreadUseCase
+	postUseCase    usecases.PostUseCase
+	serviceUseCase usecases.ServiceUseCase
+}
+
+var postSortEnum = graphql.NewEnum(graphql.EnumConfig{
+	Name: "PostSort",
+	Values: graphql.EnumValueConfigMap{
+		"FLAT": &graphql.EnumValueConfig{Value: "flat"},
+		"TREE": &graphql.EnumValueConfig{Value: "tree"},
+		"TOP":  &graphql.EnumValueConfig{Value: "top"},
+	},
+})
+
+var userSortEnum = graphql.NewEnum(graphql.EnumConfig{
+	Name: "UserSort",
+	Values: graphql.EnumValueConfigMap{
+		"NICKNAME":   &graphql.EnumValueConfig{Value: "nickname"},
+		"REPUTATION": &graphql.EnumValueConfig{Value: "reputation"},
+	},
+})
+
+func newSchema(resolver *resolver) (graphql.Schema, error) {
+	userType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "User",
+		Fields: graphql.Fields{
+			"nickname":   &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"fullname":   &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"about":      &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"email":      &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"reputation": &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+		},
+	})
+
+	forumType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Forum",
+		Fields: graphql.Fields{
+			"slug":  &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"title": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"postCount": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Int),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*models.Forum).Posts, nil
+				},
+			},
+			"threadCount": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Int),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*models.Forum).Threads, nil
+				},
+			},
+		},
+	})
+
+	threadType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Thread",
+		Fields: graphql.Fields{
+			"id":      &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"slug":    &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"title":   &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"message": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"votes":   &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"created": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.String),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*models.Thread).Created.Format(time.RFC3339Nano), nil
+				},
+			},
+		},
+	})
+
+	postType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Post",
+		Fields: graphql.Fields{
+			"id":       &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"parent":   &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"message":  &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"isEdited": &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+			"created":  &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"votes":    &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+		},
+	})
+
+	voteType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Vote",
+		Fields: graphql.Fields{
+			"nickname": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"voice":    &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+		},
+	})
+
+	// Relations are added afterwards, as the types refer to each other
+	forumType.AddFieldConfig("user", &graphql.Field{
+		Type: userType,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return loadersFrom(p.Context).user(p.Source.(*models.Forum).User), nil
+		},
+	})
+	forumType.AddFieldConfig("threads", &graphql.Field{
+		Type: graphql.NewNonNull(newConnectionType("Thread", threadType)),
+		Args: connectionArgs(graphql.FieldConfigArgument{
+			"desc": &graphql.ArgumentConfig{Type: graphql.Boolean, DefaultValue: false},
+		}),
+		Resolve: resolver.forumThreads,
+	})
+	forumType.AddFieldConfig("users", &graphql.Field{
+		Type: graphql.NewNonNull(newConnectionType("User", userType)),
+		Args: connectionArgs(graphql.FieldConfigArgument{
+			"sort": &graphql.ArgumentConfig{Type: userSortEnum, DefaultValue: "nickname"},
+			"desc": &graphql.ArgumentConfig{Type: graphql.Boolean, DefaultValue: false},
+		}),
+		Resolve: resolver.forumUsers,
+	})
+
+	threadType.AddFieldConfig("author", &graphql.Field{
+		Type: userType,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return loadersFrom(p.Context).user(p.Source.(*models.Thread).Author), nil
+		},
+	})
+	threadType.AddFieldConfig("forum", &graphql.Field{
+		Type: forumType,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return loadersFrom(p.Context).forum(p.Source.(*models.Thread).Forum), nil
+		},
+	})
+	threadType.AddFieldConfig("posts", &graphql.Field{
+		Type: graphql.NewNonNull(newConnectionType("Post", postType)),
+		Args: connectionArgs(graphql.FieldConfigArgument{
+			"sort": &graphql.ArgumentConfig{Type: postSortEnum, DefaultValue: "flat"},
+			"desc": &graphql.ArgumentConfig{Type: graphql.Boolean, DefaultValue: false},
+		}),
+		Resolve: resolver.threadPosts,
+	})
+	threadType.AddFieldConfig("voters", &graphql.Field{
+		Type: graphql.NewNonNull(newConnectionType("Vote", voteType)),
+		Args: connectionArgs(graphql.FieldConfigArgument{
+			"desc": &graphql.ArgumentConfig{Type: graphql.Boolean, DefaultValue: false},
+		}),
+		Resolve: resolver.threadVoters,
+	})
+
+	postType.AddFieldConfig("author", &graphql.Field{
+		Type: userType,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return loadersFrom(p.Context).user(p.Source.(*models.Post).Author), nil
+		},
+	})
+	postType.AddFieldConfig("thread", &graphql.Field{
+		Type: threadType,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return loadersFrom(p.Context).thread(p.Source.(*models.Post).Thread), nil
+		},
+	})
+	postType.AddFieldConfig("forum", &graphql.Field{
+		Type: forumType,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return loadersFrom(p.Context).forum(p.Source.(*models.Post).Forum), nil
+		},
+	})
+
+	voteType.AddFieldConfig("user", &graphql.Field{
+		Type: userType,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return loadersFrom(p.Context).user(p.Source.(*models.Vote).Nickname), nil
+		},
+	})
+
+	statusType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Status",
+		Fields: graphql.Fields{
+			"user":   &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"forum":  &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"thread": &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"post":   &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+		},
+	})
+
+	queryType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"user": &graphql.Field{
+				Type: userType,
+				Args: graphql.FieldConfigArgument{
+					"nickname": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return loadersFrom(p.Context).user(p.Args["nickname"].(string)), nil
+				},
+			},
+			"forum": &graphql.Field{
+				Type: forumType,
+				Args: graphql.FieldConfigArgument{
+					"slug": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return loadersFrom(p.Context).forum(p.Args["slug"].(string)), nil
+				},
+			},
+			"thread": &graphql.Field{
+				Type: threadType,
+				Args: graphql.FieldConfigArgument{
+					"slugOrId": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: resolver.thread,
+			},
+			"post": &graphql.Field{
+				Type: postType,
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+				},
+				Resolve: resolver.post,
+			},
+			"status": &graphql.Field{
+				Type: graphql.NewNonNull(statusType),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return resolver.serviceUseCase.GetStatus()
+				},
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: queryType})
+}
+
+func (resolver *resolver) thread(p graphql.ResolveParams) (interface{}, error) {
+	slugOrID := p.Args["slugOrId"].(string)
+	if id, err := strconv.ParseInt(slugOrID, 10, 64); err == nil {
+		return loadersFrom(p.Context).thread(id), nil
+	}
+
+	thread, err := resolver.threadUseCase.Get(slugOrID)
+	if err == errors.ErrThreadNotFound {
+		return nil, nil
+	}
+	return thread, err
+}
+
+func (resolver *resolver) post(p graphql.ResolveParams) (interface{}, error) {
+	postFull, err := resolver.postUseCase.Get(int64(p.Args["id"].(int)), expand.Tree{})
+	if err == errors.ErrPostNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return postFull.Post, nil
+}
+
+func (resolver *resolver) threadPosts(p graphql.ResolveParams) (interface{}, error) {
+	thread := p.Source.(*models.Thread)
+	first, after, err := pageArgs(p, resolver.maxLimit)
+	if err != nil {
+		return nil, err
+	}
+	since := -1
+	if after != "" {
+		if since, err = strconv.Atoi(after); err != nil {
+			return nil, fmt.Errorf("invalid cursor for posts")
+		}
+	}
+	sort, _ := p.Args["sort"].(string)
+	desc, _ := p.Args["desc"].(bool)
+
+	posts, err := resolver.threadUseCase.GetPosts(strconv.FormatInt(thread.ID, 10), first+1, since, sort, desc)
+	if err != nil {
+		return nil, err
+	}
+	nodes := make([]interface{}, 0, len(*posts))
+	for i := range *posts {
+		nodes = append(nodes, &(*posts)[i])
+	}
+	return newConnection(nodes, first, func(node interface{}) string {
+		return strconv.FormatInt(node.(*models.Post).ID, 10)
+	}), nil
+}
+
+func (resolver *resolver) threadVoters(p graphql.ResolveParams) (interface{}, error) {
+	thread := p.Source.(*models.Thread)
+	first, after, err := pageArgs(p, resolver.maxLimit)
+	if err != nil {
+		return nil, err
+	}
+	desc, _ := p.Args["desc"].(bool)
+
+	votes, err := resolver.threadUseCase.GetVotes(strconv.FormatInt(thread.ID, 10), first+1, after, desc)
+	if err != nil {
+		return nil, err
+	}
+	nodes := make([]interface{}, 0, len(*votes))
+	for i := range *votes {
+		nodes = append(nodes, &(*votes)[i])
+	}
+	return newConnection(nodes, first, func(node interface{}) string {
+		return node.(*models.Vote).Nickname
+	}), nil
+}
+
+func (resolver *resolver) forumUsers(p graphql.ResolveParams) (interface{}, error) {
+	forum := p.Source.(*models.Forum)
+	first, after, err := pageArgs(p, resolver.maxLimit)
+	if err != nil {
+		return nil, err
+	}
+	sort, _ := p.Args["sort"].(string)
+	desc, _ := p.Args["desc"].(bool)
+
+	users, err := resolver.forumUseCase.GetUsers(forum.Slug, first+1, after, sort, desc)
+	if err != nil {
+		return nil, err
+	}
+	nodes := make([]interface{}, 0, len(*users))
+	for i := range *users {
+		nodes = append(nodes, &(*users)[i])
+	}
+	return newConnection(nodes, first, func(node interface{}) string {
+		return node.(*models.User).Nickname
+	}), nil
+}
+
+// forumThreads pages by (created, id). The store only filters on created inclusively,
+// so threads sharing the cursor's timestamp are skipped here, fetching more while ties fill the page.
+func (resolver *resolver) forumThreads(p graphql.ResolveParams) (interface{}, error) {
+	forum := p.Source.(*models.Forum)
+	first, after, err := pageArgs(p, resolver.maxLimit)
+	if err != nil {
+		return nil, err
+	}
+	desc, _ := p.Args["desc"].(bool)
+
+	var since string
+	var sinceCreated time.Time
+	var sinceID int64
+	if after != "" {
+		parts := strings.SplitN(after, "|", 2)
+		if len(parts) == 2 {
+			sinceCreated, err = time.Parse(time.RFC3339Nano, parts[0])
+			if err == nil {
+				sinceID, err = strconv.ParseInt(parts[1], 10, 64)
+			}
+		}
+		if len(parts) != 2 || err != nil {
+			return nil, fmt.Errorf("invalid cursor for threads")
+		}
+		since = parts[0]
+	}
+	isPastCursor := func(thread *models.Thread) bool {
+		if since == "" || !thread.Created.Equal(sinceCreated) {
+			return true
+		}
+		if desc {
+			return thread.ID < sinceID
+		}
+		return thread.ID > sinceID
+	}
+
+	var nodes []interface{}
+	for limit := first + 1; ; limit *= 2 {
+		nodes = nodes[:0]
+		fetched := 0
+		err = resolver.forumUseCase.IterateThreads(forum.Slug, limit, since, desc, func(thread *models.Thread) error {
+			fetched++
+			if isPastCursor(thread) {
+				threadCopy := *thread
+				nodes = append(nodes, &threadCopy)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(nodes) > first || fetched < limit || limit >= resolver.maxLimit {
+			break
+		}
+	}
+	return newConnection(nodes, first, func(node interface{}) string {
+		thread := node.(*models.Thread)
+		return thread.Created.Format(time.RFC3339Nano) + "|" + strconv.FormatInt(thread.ID, 10)
+	}), nil
+}
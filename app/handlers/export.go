@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"Technopark_DB_Project/app/usecases"
+	"Technopark_DB_Project/pkg/archive"
+	"Technopark_DB_Project/pkg/errors"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ExportHandler struct {
+	ForumURL      string
+	ExportUseCase usecases.ExportUseCase
+}
+
+func CreateExportHandler(router *gin.RouterGroup, forumURL string, exportUseCase usecases.ExportUseCase) {
+	handler := &ExportHandler{
+		ForumURL:      forumURL,
+		ExportUseCase: exportUseCase,
+	}
+
+	exports := router.Group(handler.ForumURL)
+	{
+		exports.GET("/:slug/export", handler.ExportForum)
+	}
+}
+
+func (exportHandler *ExportHandler) ExportForum(c *gin.Context) {
+	slug := c.Param("slug")
+
+	format := c.Query("format")
+	if format == "" {
+		format = archive.FormatNDJSON
+	}
+	contentType := archive.ContentType(format)
+	if contentType == "" {
+		respondError(c, errors.ErrBadRequest)
+		return
+	}
+
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", slug+"."+format))
+
+	err := exportHandler.ExportUseCase.ExportForum(slug, format, c.Writer)
+	if err != nil {
+		// Once the archive has started, the status is already sent and the response is just cut off
+		if c.Writer.Written() {
+			fmt.Println(err)
+			return
+		}
+		c.Writer.Header().Del("Content-Type")
+		c.Writer.Header().Del("Content-Disposition")
+		respondError(c, err)
+	}
+}
@@ -4,23 +4,50 @@ import (
 	"Technopark_DB_Project/app/models"
 	"Technopark_DB_Project/app/usecases"
 	"Technopark_DB_Project/pkg/errors"
+	"Technopark_DB_Project/pkg/expand"
+	"Technopark_DB_Project/pkg/validator"
+	"context"
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
-	"github.com/mailru/easyjson"
+	"github.com/gorilla/websocket"
 
 	"github.com/gin-gonic/gin"
 )
 
+const (
+	liveWriteTimeout = 10 * time.Second
+	livePingInterval = 30 * time.Second
+	// A pong is expected within the read timeout, it is longer than the ping interval to leave time for the answer
+	liveReadTimeout    = 2 * livePingInterval
+	liveMaxMessageSize = 512
+)
+
 type ForumHandler struct {
 	ForumURL     string
+	MaxLimit     int
 	ForumUseCase usecases.ForumUseCase
+	LiveUseCase  usecases.LiveUseCase
+
+	liveUpgrader websocket.Upgrader
 }
 
-func CreateForumHandler(router *gin.RouterGroup, forumURL string, forumUseCase usecases.ForumUseCase) {
+// CreateForumHandler registers the forum routes, live connections are accepted from liveOrigins only,
+// the same list CORS allows
+func CreateForumHandler(router *gin.RouterGroup, forumURL string, maxLimit int, liveOrigins []string, forumUseCase usecases.ForumUseCase, liveUseCase usecases.LiveUseCase) {
 	handler := &ForumHandler{
 		ForumURL:     forumURL,
+		MaxLimit:     maxLimit,
 		ForumUseCase: forumUseCase,
+		LiveUseCase:  liveUseCase,
+		liveUpgrader: websocket.Upgrader{
+			ReadBufferSize:  1024,
+			WriteBufferSize: 1024,
+			CheckOrigin:     isAllowedOrigin(liveOrigins),
+		},
 	}
 
 	forums := router.Group(handler.ForumURL)
@@ -30,90 +57,79 @@ func CreateForumHandler(router *gin.RouterGroup, forumURL string, forumUseCase u
 		forums.POST("/:slug/create", handler.CreateThread)
 		forums.GET("/:slug/users", handler.GetForumUsers)
 		forums.GET("/:slug/threads", handler.GetForumThreads)
+		forums.POST("/:slug/subscription", handler.Subscribe)
+		forums.DELETE("/:slug/subscription", handler.Unsubscribe)
+		forums.GET("/:slug/live", handler.Live)
 	}
 }
 
 func (forumHandler *ForumHandler) CreateForum(c *gin.Context) {
 	forum := new(models.Forum)
-	if err := easyjson.UnmarshalFromReader(c.Request.Body, forum); err != nil {
-		c.Data(errors.PrepareErrorResponse(errors.ErrBadRequest))
+	if err := decodeBody(c, forum); err != nil {
+		respondError(c, err)
+		return
+	}
+	if err := validator.ValidateForumData(forum); err != nil {
+		respondError(c, err)
 		return
 	}
 
 	err := forumHandler.ForumUseCase.CreateForum(forum)
 	if err != nil {
 		if errors.ResolveErrorToCode(err) == http.StatusConflict {
-			forumJSON, errInt := forum.MarshalJSON()
-			if errInt != nil {
-				c.Data(errors.PrepareErrorResponse(err))
-				return
-			}
-			c.Data(errors.ResolveErrorToCode(err), "application/json; charset=utf-8", forumJSON)
+			respond(c, errors.ResolveErrorToCode(err), forum)
 		} else {
-			c.Data(errors.PrepareErrorResponse(err))
+			respondError(c, err)
 		}
 		return
 	}
 
-	forumJSON, err := forum.MarshalJSON()
-	if err != nil {
-		c.Data(errors.PrepareErrorResponse(err))
-		return
-	}
-
-	c.Data(http.StatusCreated, "application/json; charset=utf-8", forumJSON)
+	respond(c, http.StatusCreated, forum)
 }
 
 func (forumHandler *ForumHandler) GetDetails(c *gin.Context) {
 	slug := c.Param("slug")
 
-	forum, err := forumHandler.ForumUseCase.Get(slug)
+	expansion, err := expand.Parse("expand", c.QueryArray("expand"), models.ForumExpansions)
 	if err != nil {
-		c.Data(errors.PrepareErrorResponse(err))
+		respondError(c, err)
 		return
 	}
 
-	forumJSON, err := forum.MarshalJSON()
+	forumFull, err := forumHandler.ForumUseCase.GetFull(slug, expansion)
 	if err != nil {
-		c.Data(errors.PrepareErrorResponse(err))
+		respondError(c, err)
 		return
 	}
 
-	c.Data(http.StatusOK, "application/json; charset=utf-8", forumJSON)
+	respond(c, http.StatusOK, forumFull)
 }
 
 func (forumHandler *ForumHandler) CreateThread(c *gin.Context) {
 	slug := c.Param("slug")
 
 	thread := new(models.Thread)
-	if err := easyjson.UnmarshalFromReader(c.Request.Body, thread); err != nil {
-		c.Data(errors.PrepareErrorResponse(errors.ErrBadRequest))
+	if err := decodeBody(c, thread); err != nil {
+		respondError(c, err)
 		return
 	}
 	thread.Forum = slug
+	if err := validator.ValidateThreadData(thread, false); err != nil {
+		respondError(c, err)
+		return
+	}
 
 	err := forumHandler.ForumUseCase.CreateThread(thread)
 	if err != nil {
 		if errors.ResolveErrorToCode(err) == http.StatusConflict {
-			threadJSON, errInt := thread.MarshalJSON()
-			if errInt != nil {
-				c.Data(errors.PrepareErrorResponse(err))
-				return
-			}
-			c.Data(errors.ResolveErrorToCode(err), "application/json; charset=utf-8", threadJSON)
+			respond(c, errors.ResolveErrorToCode(err), thread)
 		} else {
-			c.Data(errors.PrepareErrorResponse(err))
+			respondError(c, err)
 		}
 		return
 	}
 
-	threadJSON, err := thread.MarshalJSON()
-	if err != nil {
-		c.Data(errors.PrepareErrorResponse(err))
-		return
-	}
-
-	c.Data(http.StatusCreated, "application/json; charset=utf-8", threadJSON)
+	respond(c, http.StatusCreated, thread)
 }
 
 func (forumHandler *ForumHandler) GetForumUsers(c *gin.Context) {
@@ -125,35 +141,33 @@ func (forumHandler *ForumHandler) GetForumUsers(c *gin.Context) {
 		var err error
 		limit, err = strconv.Atoi(limitStr)
 		if err != nil {
-			c.Data(errors.PrepareErrorResponse(errors.ErrBadRequest))
+			respondError(c, errors.ErrBadRequest)
 			return
 		}
 	}
 	since := c.Query("since")
+	sort := c.Query("sort")
+	if sort == "" {
+		sort = "nickname"
+	}
 	descStr := c.Query("desc")
 	desc := false
 	if descStr != "" {
 		var err error
 		desc, err = strconv.ParseBool(descStr)
 		if err != nil {
-			c.Data(errors.PrepareErrorResponse(errors.ErrBadRequest))
+			respondError(c, errors.ErrBadRequest)
 			return
 		}
 	}
 
-	users, err := forumHandler.ForumUseCase.GetUsers(slug, limit, since, desc)
+	users, err := forumHandler.ForumUseCase.GetUsers(slug, limit, since, sort, desc)
 	if err != nil {
-		c.Data(errors.PrepareErrorResponse(err))
+		respondError(c, err)
 		return
 	}
 
-	usersJSON, err := users.MarshalJSON()
-	if err != nil {
-		c.Data(errors.PrepareErrorResponse(err))
-		return
-	}
-
-	c.Data(http.StatusOK, "application/json; charset=utf-8", usersJSON)
+	respond(c, http.StatusOK, users)
 }
 
 func (forumHandler *ForumHandler) GetForumThreads(c *gin.Context) {
@@ -165,7 +179,7 @@ func (forumHandler *ForumHandler) GetForumThreads(c *gin.Context) {
 		var err error
 		limit, err = strconv.Atoi(limitStr)
 		if err != nil {
-			c.Data(errors.PrepareErrorResponse(errors.ErrBadRequest))
+			respondError(c, errors.ErrBadRequest)
 			return
 		}
 	}
@@ -176,22 +190,148 @@ func (forumHandler *ForumHandler) GetForumThreads(c *gin.Context) {
 		var err error
 		desc, err = strconv.ParseBool(descStr)
 		if err != nil {
-			c.Data(errors.PrepareErrorResponse(errors.ErrBadRequest))
+			respondError(c, errors.ErrBadRequest)
+			return
+		}
+	}
+
+	limit = clampLimit(limit, forumHandler.MaxLimit)
+
+	stream := newListStream(c)
+	err := forumHandler.ForumUseCase.IterateThreads(slug, limit, since, desc, func(thread *models.Thread) error {
+		return stream.Write(thread)
+	})
+	if err == nil {
+		err = stream.Close()
+	}
+	if err != nil {
+		if stream.IsStarted() {
+			// Status is already sent, the broken array tells the client the list is incomplete
+			fmt.Println(err)
 			return
 		}
+		respondError(c, err)
+	}
+}
+
+func (forumHandler *ForumHandler) Subscribe(c *gin.Context) {
+	slug := c.Param("slug")
+
+	subscription := new(models.Subscription)
+	if err := decodeBody(c, subscription); err != nil {
+		respondError(c, err)
+		return
+	}
+	if err := validator.ValidateSubscriptionData(subscription); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	subscription, err := forumHandler.ForumUseCase.Subscribe(slug, subscription.Nickname)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respond(c, http.StatusOK, subscription)
+}
+
+func (forumHandler *ForumHandler) Unsubscribe(c *gin.Context) {
+	slug := c.Param("slug")
+
+	nickname := c.Query("nickname")
+	if nickname == "" {
+		respondError(c, errors.ErrBadRequest)
+		return
 	}
 
-	threads, err := forumHandler.ForumUseCase.GetThreads(slug, limit, since, desc)
+	err := forumHandler.ForumUseCase.Unsubscribe(slug, nickname)
 	if err != nil {
-		c.Data(errors.PrepareErrorResponse(err))
+		respondError(c, err)
 		return
 	}
 
-	threadsJSON, err := threads.MarshalJSON()
+	c.Status(http.StatusOK)
+}
+
+func (forumHandler *ForumHandler) Live(c *gin.Context) {
+	slug := c.Param("slug")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Subscribe before upgrading so that a missing forum is reported as a regular error response
+	events, err := forumHandler.LiveUseCase.Subscribe(ctx, slug)
 	if err != nil {
-		c.Data(errors.PrepareErrorResponse(err))
+		respondError(c, err)
 		return
 	}
 
-	c.Data(http.StatusOK, "application/json; charset=utf-8", threadsJSON)
+	conn, err := forumHandler.liveUpgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	// Incoming messages are ignored, reading is needed to process control frames and detect disconnect.
+	// A client that stops answering pings is dropped once the read deadline passes.
+	conn.SetReadLimit(liveMaxMessageSize)
+	_ = conn.SetReadDeadline(time.Now().Add(liveReadTimeout))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(liveReadTimeout))
+	})
+	go func() {
+		defer cancel()
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	ping := time.NewTicker(livePingInterval)
+	defer ping.Stop()
+
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				if ctx.Err() == nil {
+					_ = conn.WriteControl(websocket.CloseMessage,
+						websocket.FormatCloseMessage(websocket.ClosePolicyViolation, "slow consumer"),
+						time.Now().Add(liveWriteTimeout))
+				}
+				return
+			}
+			eventJSON, err := event.MarshalJSON()
+			if err != nil {
+				return
+			}
+			_ = conn.SetWriteDeadline(time.Now().Add(liveWriteTimeout))
+			if err = conn.WriteMessage(websocket.TextMessage, eventJSON); err != nil {
+				return
+			}
+		case <-ping.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(liveWriteTimeout)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// isAllowedOrigin accepts requests without Origin, which don't come from browsers, and the ones from origins.
+// "*" allows any origin like it does for CORS.
+func isAllowedOrigin(origins []string) func(r *http.Request) bool {
+	return func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			return true
+		}
+		for _, allowed := range origins {
+			if allowed == "*" || strings.EqualFold(allowed, origin) {
+				return true
+			}
+		}
+		return false
+	}
 }
@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"Technopark_DB_Project/app/models"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+type liveUseCaseMock struct {
+	events chan models.Event
+}
+
+func (mock *liveUseCaseMock) Publish(event models.Event) {
+	mock.events <- event
+}
+
+func (mock *liveUseCaseMock) Subscribe(ctx context.Context, slug string) (<-chan models.Event, error) {
+	return mock.events, nil
+}
+
+func TestIsAllowedOrigin(t *testing.T) {
+	tests := []struct {
+		name    string
+		origins []string
+		origin  string
+		want    bool
+	}{
+		{name: "no origin header", origins: []string{"http://localhost:5000"}, want: true},
+		{name: "listed", origins: []string{"http://localhost:5000"}, origin: "http://localhost:5000", want: true},
+		{name: "listed in another case", origins: []string{"http://localhost:5000"}, origin: "http://LOCALHOST:5000", want: true},
+		{name: "not listed", origins: []string{"http://localhost:5000"}, origin: "https://evil.example", want: false},
+		{name: "other port", origins: []string{"http://localhost:5000"}, origin: "http://localhost:5001", want: false},
+		{name: "empty list", origin: "http://localhost:5000", want: false},
+		{name: "any", origins: []string{"*"}, origin: "https://evil.example", want: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "/api/forum/slug/live", nil)
+			if test.origin != "" {
+				request.Header.Set("Origin", test.origin)
+			}
+			if got := isAllowedOrigin(test.origins)(request); got != test.want {
+				t.Errorf("isAllowedOrigin(%v) for %q = %v, want %v", test.origins, test.origin, got, test.want)
+			}
+		})
+	}
+}
+
+func TestLiveChecksOrigin(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	liveUseCase := &liveUseCaseMock{events: make(chan models.Event, 1)}
+	CreateForumHandler(router.Group("/api"), "/forum", 100, []string{"http://localhost:5000"}, nil, liveUseCase)
+	server := httptest.NewServer(router)
+	defer server.Close()
+	liveURL := "ws" + strings.TrimPrefix(server.URL, "http") + "/api/forum/slug/live"
+
+	_, response, err := websocket.DefaultDialer.Dial(liveURL, http.Header{"Origin": {"https://evil.example"}})
+	if err == nil {
+		t.Fatal("connection from a foreign origin was accepted")
+	}
+	if response == nil || response.StatusCode != http.StatusForbidden {
+		t.Fatalf("response = %v, want %d", response, http.StatusForbidden)
+	}
+
+	conn, _, err := websocket.DefaultDialer.Dial(liveURL, http.Header{"Origin": {"http://localhost:5000"}})
+	if err != nil {
+		t.Fatalf("connection from an allowed origin failed: %v", err)
+	}
+	defer conn.Close()
+
+	liveUseCase.Publish(models.Event{Type: models.EventPostCreated, Forum: "slug"})
+	_, message, err := conn.ReadMessage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(message), models.EventPostCreated) {
+		t.Fatalf("message = %s, want a %s event", message, models.EventPostCreated)
+	}
+}
@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"Technopark_DB_Project/app/gql"
+	"Technopark_DB_Project/pkg/errors"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type GraphQLHandler struct {
+	GraphQLURL string
+	Executor   *gql.Executor
+}
+
+func CreateGraphQLHandler(router *gin.RouterGroup, graphQLURL string, executor *gql.Executor) {
+	handler := &GraphQLHandler{
+		GraphQLURL: graphQLURL,
+		Executor:   executor,
+	}
+
+	router.GET(handler.GraphQLURL, handler.Query)
+	router.POST(handler.GraphQLURL, handler.Query)
+}
+
+// Query accepts a JSON body {query, variables, operationName} or the same as query parameters for GET.
+// Errors of the query itself are reported in the result with 200 as GraphQL clients expect.
+func (graphQLHandler *GraphQLHandler) Query(c *gin.Context) {
+	request := gql.Request{}
+	if c.Request.Method == http.MethodGet {
+		request.Query = c.Query("query")
+		request.OperationName = c.Query("operationName")
+		if variables := c.Query("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &request.Variables); err != nil {
+				respondError(c, errors.ErrBadRequest)
+				return
+			}
+		}
+	} else if err := json.NewDecoder(c.Request.Body).Decode(&request); err != nil {
+		respondError(c, errors.ErrBadRequest)
+		return
+	}
+
+	result := graphQLHandler.Executor.Execute(c.Request.Context(), request)
+	data, err := json.Marshal(result)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.Data(http.StatusOK, "application/json; charset=utf-8", data)
+}
@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mailru/easyjson"
+	"github.com/mailru/easyjson/jwriter"
+)
+
+const jsonStreamFlushSize = 32 << 10
+
+// jsonArrayStream writes a list response item by item with chunked transfer encoding.
+// Items are buffered up to jsonStreamFlushSize, so an error found before the first flush still gets a regular error response.
+type jsonArrayStream struct {
+	c      *gin.Context
+	writer jwriter.Writer
+	count  int
+}
+
+func newJSONArrayStream(c *gin.Context) *jsonArrayStream {
+	return &jsonArrayStream{c: c}
+}
+
+func (stream *jsonArrayStream) Write(item easyjson.Marshaler) (err error) {
+	if stream.count == 0 {
+		stream.start()
+	} else {
+		stream.writer.RawByte(',')
+	}
+	item.MarshalEasyJSON(&stream.writer)
+	stream.count++
+
+	if stream.writer.Size() >= jsonStreamFlushSize {
+		return stream.flush()
+	}
+	return stream.writer.Error
+}
+
+func (stream *jsonArrayStream) Close() (err error) {
+	if stream.count == 0 {
+		stream.start()
+	}
+	stream.writer.RawByte(']')
+	return stream.flush()
+}
+
+// IsStarted reports whether the response status has been already sent. Buffered items don't count,
+// the status goes out with the first flush.
+func (stream *jsonArrayStream) IsStarted() bool {
+	return stream.c.Writer.Written()
+}
+
+func (stream *jsonArrayStream) start() {
+	stream.c.Header("Content-Type", "application/json; charset=utf-8")
+	stream.c.Status(http.StatusOK)
+	stream.writer.RawByte('[')
+}
+
+func (stream *jsonArrayStream) flush() (err error) {
+	if stream.writer.Error != nil {
+		return stream.writer.Error
+	}
+	if _, err = stream.writer.DumpTo(stream.c.Writer); err != nil {
+		return
+	}
+	stream.c.Writer.Flush()
+	return
+}
+
+func clampLimit(limit, maxLimit int) int {
+	if limit <= 0 || limit > maxLimit {
+		return maxLimit
+	}
+	return limit
+}
@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"Technopark_DB_Project/app/models/pb"
+	"Technopark_DB_Project/pkg/codec"
+	"Technopark_DB_Project/pkg/errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mailru/easyjson"
+	"github.com/vmihailenco/msgpack/v5"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+)
+
+// listStream writes a list response item by item in the media type negotiated from the Accept header
+type listStream interface {
+	Write(item easyjson.Marshaler) (err error)
+	Close() (err error)
+	// IsStarted reports whether the response status has been already sent
+	IsStarted() bool
+}
+
+func newListStream(c *gin.Context) listStream {
+	switch codec.Negotiate(c.GetHeader("Accept")) {
+	case codec.MIMEProtobuf:
+		return &protobufListStream{c: c}
+	case codec.MIMEMsgPack:
+		return &msgPackListStream{c: c}
+	}
+	return newJSONArrayStream(c)
+}
+
+// protobufListStream relies on a repeated field being just a sequence of its elements,
+// so the list message (Posts, Threads) is written one element at a time.
+type protobufListStream struct {
+	c      *gin.Context
+	buffer []byte
+	count  int
+}
+
+func (stream *protobufListStream) Write(item easyjson.Marshaler) (err error) {
+	message := pb.ToMessage(item)
+	if message == nil {
+		return errors.ErrInternal
+	}
+	itemData, err := proto.Marshal(message)
+	if err != nil {
+		return
+	}
+
+	if stream.count == 0 {
+		stream.start()
+	}
+	stream.buffer = protowire.AppendTag(stream.buffer, 1, protowire.BytesType)
+	stream.buffer = protowire.AppendBytes(stream.buffer, itemData)
+	stream.count++
+
+	if len(stream.buffer) >= jsonStreamFlushSize {
+		return stream.flush()
+	}
+	return
+}
+
+func (stream *protobufListStream) Close() (err error) {
+	if stream.count == 0 {
+		stream.start()
+	}
+	return stream.flush()
+}
+
+func (stream *protobufListStream) IsStarted() bool {
+	return stream.c.Writer.Written()
+}
+
+func (stream *protobufListStream) start() {
+	stream.c.Header("Content-Type", codec.MIMEProtobuf)
+	stream.c.Status(http.StatusOK)
+}
+
+func (stream *protobufListStream) flush() (err error) {
+	if _, err = stream.c.Writer.Write(stream.buffer); err != nil {
+		return
+	}
+	stream.buffer = stream.buffer[:0]
+	stream.c.Writer.Flush()
+	return
+}
+
+// msgPackListStream is not streamed. The array length goes before the first element,
+// so encoded items are kept until Close and only then sent.
+type msgPackListStream struct {
+	c     *gin.Context
+	items []byte
+	count int
+}
+
+func (stream *msgPackListStream) Write(item easyjson.Marshaler) (err error) {
+	itemData, _, err := codec.Marshal(codec.MIMEMsgPack, item)
+	if err != nil {
+		return
+	}
+	stream.items = append(stream.items, itemData...)
+	stream.count++
+	return
+}
+
+func (stream *msgPackListStream) Close() (err error) {
+	stream.c.Header("Content-Type", codec.MIMEMsgPack)
+	stream.c.Status(http.StatusOK)
+	if err = msgpack.NewEncoder(stream.c.Writer).EncodeArrayLen(stream.count); err != nil {
+		return
+	}
+	_, err = stream.c.Writer.Write(stream.items)
+	return
+}
+
+func (stream *msgPackListStream) IsStarted() bool {
+	return stream.c.Writer.Written()
+}
@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"Technopark_DB_Project/app/models"
+	"Technopark_DB_Project/pkg/codec"
+	"Technopark_DB_Project/pkg/errors"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+func newStreamContext(accept string) (*gin.Context, *httptest.ResponseRecorder) {
+	gin.SetMode(gin.TestMode)
+	recorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(recorder)
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/thread/1/posts", nil)
+	c.Request.Header.Set("Accept", accept)
+	return c, recorder
+}
+
+func TestListStreamErrorBeforeFlush(t *testing.T) {
+	for _, accept := range []string{codec.MIMEJSON, codec.MIMEProtobuf, codec.MIMEMsgPack} {
+		t.Run(accept, func(t *testing.T) {
+			c, recorder := newStreamContext(accept)
+			stream := newListStream(c)
+			if err := stream.Write(&models.Post{ID: 1, Author: "author", Message: "buffered item"}); err != nil {
+				t.Fatal(err)
+			}
+
+			// The item is only buffered, so the error can still be the whole response
+			if stream.IsStarted() {
+				t.Fatal("stream is started before anything was sent")
+			}
+			respondError(c, errors.ErrThreadNotFound)
+
+			if recorder.Code != http.StatusNotFound {
+				t.Fatalf("status = %d, want %d", recorder.Code, http.StatusNotFound)
+			}
+			if strings.Contains(recorder.Body.String(), "buffered item") {
+				t.Fatalf("buffered item leaked into the error response: %q", recorder.Body.String())
+			}
+		})
+	}
+}
+
+func TestJSONArrayStreamStartsOnFlush(t *testing.T) {
+	c, recorder := newStreamContext(codec.MIMEJSON)
+	stream := newListStream(c)
+
+	message := strings.Repeat("x", 1024)
+	written := 0
+	for !stream.IsStarted() {
+		if written > jsonStreamFlushSize {
+			t.Fatal("nothing is flushed after jsonStreamFlushSize bytes")
+		}
+		if err := stream.Write(&models.Post{ID: int64(written), Message: message}); err != nil {
+			t.Fatal(err)
+		}
+		written++
+	}
+	if recorder.Code != http.StatusOK || !strings.HasPrefix(recorder.Body.String(), "[") {
+		t.Fatalf("status = %d, body starts with %.10q", recorder.Code, recorder.Body.String())
+	}
+
+	if err := stream.Close(); err != nil {
+		t.Fatal(err)
+	}
+	var posts []models.Post
+	if err := json.Unmarshal(recorder.Body.Bytes(), &posts); err != nil {
+		t.Fatal(err)
+	}
+	if len(posts) != written {
+		t.Fatalf("got %d posts, want %d", len(posts), written)
+	}
+}
+
+func TestListStreamEmpty(t *testing.T) {
+	tests := []struct {
+		accept string
+		want   string
+	}{
+		{accept: codec.MIMEJSON, want: "[]"},
+		{accept: codec.MIMEProtobuf, want: ""},
+		{accept: codec.MIMEMsgPack, want: "\x90"},
+	}
+	for _, test := range tests {
+		t.Run(test.accept, func(t *testing.T) {
+			c, recorder := newStreamContext(test.accept)
+			if err := newListStream(c).Close(); err != nil {
+				t.Fatal(err)
+			}
+			if recorder.Code != http.StatusOK || recorder.Body.String() != test.want {
+				t.Fatalf("status = %d, body = %q, want %d and %q", recorder.Code, recorder.Body.String(), http.StatusOK, test.want)
+			}
+		})
+	}
+}
+
+func TestMsgPackListStream(t *testing.T) {
+	c, recorder := newStreamContext(codec.MIMEMsgPack)
+	stream := newListStream(c)
+	for i := int64(1); i <= 3; i++ {
+		if err := stream.Write(&models.Post{ID: i, Author: "author"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if stream.IsStarted() {
+		t.Fatal("msgpack list is sent before Close")
+	}
+	if err := stream.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	var posts []map[string]interface{}
+	if err := msgpack.Unmarshal(recorder.Body.Bytes(), &posts); err != nil {
+		t.Fatal(err)
+	}
+	if len(posts) != 3 || recorder.Header().Get("Content-Type") != codec.MIMEMsgPack {
+		t.Fatalf("got %d posts with %q, want 3 with %q", len(posts), recorder.Header().Get("Content-Type"), codec.MIMEMsgPack)
+	}
+}
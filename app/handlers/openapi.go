@@ -0,0 +1,692 @@
+package handlers
+
+import (
+	"Technopark_DB_Project/app/gql"
+	"Technopark_DB_Project/app/models"
+	"Technopark_DB_Project/pkg/archive"
+	"Technopark_DB_Project/pkg/codec"
+	"Technopark_DB_Project/pkg/openapi"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/graphql-go/graphql"
+)
+
+// DocumentedHandlers are the handlers whose every route must have an OpenAPI entry,
+// the package is part of the names so that API v2 handlers are not taken for them
+var DocumentedHandlers = []string{
+	"handlers.(*UserHandler)",
+	"handlers.(*ForumHandler)",
+	"handlers.(*PostHandler)",
+	"handlers.(*ThreadHandler)",
+	"handlers.(*ServiceHandler)",
+	"handlers.(*WebhookHandler)",
+	"handlers.(*ExportHandler)",
+	"handlers.(*GraphQLHandler)",
+}
+
+type OpenAPIHandler struct {
+	OpenAPIURL string
+	document   []byte
+}
+
+func CreateOpenAPIHandler(router *gin.RouterGroup, openAPIURL string, document *openapi.Document) (err error) {
+	handler := &OpenAPIHandler{OpenAPIURL: openAPIURL}
+	if handler.document, err = json.Marshal(document); err != nil {
+		return
+	}
+
+	router.GET(handler.OpenAPIURL, handler.GetDocument)
+	return
+}
+
+func (openAPIHandler *OpenAPIHandler) GetDocument(c *gin.Context) {
+	c.Data(http.StatusOK, "application/json; charset=utf-8", openAPIHandler.document)
+}
+
+// CheckOpenAPIRoutes fails when a route of handlerNames has no operation in the document,
+// so the server refuses to start until a new route is described. rootURLs are the groups the handlers are registered in.
+func CheckOpenAPIRoutes(routes gin.RoutesInfo, rootURLs []string, handlerNames []string, document *openapi.Document) error {
+	// The longest root goes first, so /api/v1/user isn't taken for /v1/user under /api
+	rootURLs = append([]string(nil), rootURLs...)
+	sort.Slice(rootURLs, func(i, j int) bool {
+		return len(rootURLs[i]) > len(rootURLs[j])
+	})
+
+	var missing []string
+	for _, route := range routes {
+		if !isDocumentedHandler(route.Handler, handlerNames) {
+			continue
+		}
+		path := route.Path
+		for _, rootURL := range rootURLs {
+			if strings.HasPrefix(path, rootURL+"/") {
+				path = strings.TrimPrefix(path, rootURL)
+				break
+			}
+		}
+		path = openapi.PathFromGin(path)
+		if _, isFound := document.Operation(route.Method, path); !isFound {
+			missing = append(missing, route.Method+" "+path)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("routes without OpenAPI operations: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+func isDocumentedHandler(handlerName string, handlerNames []string) bool {
+	for _, handler := range handlerNames {
+		if strings.Contains(handlerName, handler) {
+			return true
+		}
+	}
+	return false
+}
+
+const streamedListDescription = "JSON and protobuf responses are sent in chunks while the list is read, an error after " +
+	"the first chunk cuts the response short. " + codec.MIMEMsgPack + " responses are not streamed, the array length " +
+	"comes first, so the whole list is read before anything is sent."
+
+// NewOpenAPIDocument describes the routes of the user, forum, post, thread, service, webhook, export and GraphQL handlers.
+// Paths are relative to each of serverURLs, the root groups the handlers are registered in.
+func NewOpenAPIDocument(serverURLs []string, userURL, forumURL, postURL, threadURL, serviceURL, graphQLURL string) *openapi.Document {
+	document := openapi.NewDocument(openapi.Info{
+		Title: "Technopark DB forum",
+		Description: "Responses are JSON unless the Accept header prefers " + codec.MIMEMsgPack + " or " + codec.MIMEProtobuf +
+			", request bodies are read according to Content-Type the same way.",
+		Version: "1.0",
+	}, serverURLs...)
+
+	// Models, nested ones go first to be referenced by the rest
+	document.AddModel("FieldError", models.FieldError{})
+	errorSchema := document.AddModel("Error", models.Error{})
+	userSchema := document.AddModel("User", models.User{})
+	usersSchema := document.AddModel("Users", models.Users{})
+	forumSchema := document.AddModel("Forum", models.Forum{})
+	threadSchema := document.AddModel("Thread", models.Thread{})
+	threadsSchema := document.AddModel("Threads", models.Threads{})
+	postSchema := document.AddModel("Post", models.Post{})
+	postsSchema := document.AddModel("Posts", models.Posts{})
+	document.AddModel("ForumExpanded", models.ForumExpanded{})
+	forumFullSchema := document.AddModel("ForumFull", models.ForumFull{})
+	document.AddModel("ThreadExpanded", models.ThreadExpanded{})
+	threadFullSchema := document.AddModel("ThreadFull", models.ThreadFull{})
+	postFullSchema := document.AddModel("PostFull", models.PostFull{})
+	document.AddModel("Vote", models.Vote{})
+	votesSchema := document.AddModel("Votes", models.Votes{})
+	document.AddModel("Reaction", models.Reaction{})
+	reactionsSchema := document.AddModel("Reactions", models.Reactions{})
+	subscriptionSchema := document.AddModel("Subscription", models.Subscription{})
+	document.AddModel("UserForum", models.UserForum{})
+	userForumsSchema := document.AddModel("UserForums", models.UserForums{})
+	document.AddModel("Notification", models.Notification{})
+	notificationsSchema := document.AddModel("Notifications", models.Notifications{})
+	statusSchema := document.AddModel("Status", models.Status{})
+	document.AddModel("Change", models.Change{})
+	changesSchema := document.AddModel("Changes", models.Changes{})
+	importRecordSchema := document.AddModel("ImportRecord", models.ImportRecord{})
+	document.AddModel("ImportError", models.ImportError{})
+	importResultSchema := document.AddModel("ImportResult", models.ImportResult{})
+	usersBatchSchema := document.AddModel("UsersBatch", models.UsersBatch{})
+	threadsBatchSchema := document.AddModel("ThreadsBatch", models.ThreadsBatch{})
+	postsBatchSchema := document.AddModel("PostsBatch", models.PostsBatch{})
+	webhookSchema := document.AddModel("Webhook", models.Webhook{})
+	webhooksSchema := document.AddModel("Webhooks", models.Webhooks{})
+	document.AddModel("WebhookDelivery", models.WebhookDelivery{})
+	webhookDeliveriesSchema := document.AddModel("WebhookDeliveries", models.WebhookDeliveries{})
+	graphQLResultSchema := document.AddModel("GraphQLResult", graphql.Result{})
+
+	// Request bodies, limited to the fields handlers read
+	newUserSchema := document.AddRequestModel("NewUser", models.UserUpdate{}, []string{"fullname", "about", "email"}, "fullname", "email")
+	userUpdateSchema := document.AddRequestModel("UserUpdate", models.UserUpdate{}, []string{"fullname", "about", "email"})
+	newForumSchema := document.AddRequestModel("NewForum", models.Forum{}, []string{"title", "user", "slug"}, "title", "user", "slug")
+	newThreadSchema := document.AddRequestModel("NewThread", models.Thread{},
+		[]string{"title", "author", "message", "slug", "created"}, "title", "author", "message")
+	threadUpdateSchema := document.AddRequestModel("ThreadUpdate", models.ThreadUpdate{}, []string{"title", "message"})
+	newPostSchema := document.AddRequestModel("NewPost", models.Post{}, []string{"parent", "author", "message"}, "author", "message")
+	postUpdateSchema := document.AddRequestModel("PostUpdate", models.PostUpdate{}, []string{"message"})
+	newVoteSchema := document.AddRequestModel("NewVote", models.Vote{}, []string{"nickname", "voice"}, "nickname", "voice")
+	document.Schema(newVoteSchema).Properties["voice"].WithEnum(models.Dislike, models.Like)
+	newReactionSchema := document.AddRequestModel("NewReaction", models.Reaction{}, []string{"nickname", "reaction"}, "nickname", "reaction")
+	newSubscriptionSchema := document.AddRequestModel("NewSubscription", models.Subscription{}, []string{"nickname"}, "nickname")
+	notificationsReadSchema := document.AddRequestModel("NotificationsRead", models.NotificationsRead{}, []string{"ids"}, "ids")
+	batchNicknamesSchema := document.AddRequestModel("BatchNicknames", models.BatchNicknames{}, []string{"nicknames"}, "nicknames")
+	batchIDsSchema := document.AddRequestModel("BatchIDs", models.BatchIDs{}, []string{"ids"}, "ids")
+	newWebhookSchema := document.AddRequestModel("NewWebhook", models.Webhook{}, []string{"nickname", "url", "events"}, "nickname", "url", "events")
+	document.Schema(newWebhookSchema).Properties["events"].Items.WithEnum(
+		models.EventThreadCreated, models.EventPostCreated, models.EventPostUpdated, models.EventVoteCast)
+	graphQLRequestSchema := document.AddRequestModel("GraphQLRequest", gql.Request{}, []string{"query", "variables", "operationName"}, "query")
+	// GraphQL clients send null for missing variables and operation name
+	document.Schema(graphQLRequestSchema).Properties["variables"].AsNullable()
+	document.Schema(graphQLRequestSchema).Properties["operationName"].AsNullable()
+
+	// Parameters
+	nickname := openapi.PathParam("nickname", "User nickname, case insensitive", openapi.String())
+	slug := openapi.PathParam("slug", "Forum slug, case insensitive", openapi.String())
+	slugOrID := openapi.PathParam("slug_or_id", "Thread slug or numeric id", openapi.String())
+	postID := openapi.PathParam("id", "Post id", openapi.Integer())
+	webhookID := openapi.PathParam("id", "Webhook id", openapi.Integer())
+	limit := openapi.QueryParam("limit", "Maximum number of items returned", openapi.Integer().WithMinimum(1).WithDefault(100))
+	desc := openapi.QueryParam("desc", "Sort in descending order", openapi.Boolean().WithDefault(false))
+	sinceID := openapi.QueryParam("since", "Return items after the one with this id", openapi.Integer())
+	sinceCreated := openapi.QueryParam("since", "Return items created at or after this time", openapi.String().WithFormat("date-time"))
+	sinceNickname := openapi.QueryParam("since", "Return items after this nickname", openapi.String())
+	sinceSlug := openapi.QueryParam("since", "Return items after this forum slug", openapi.String())
+	forumFilter := openapi.QueryParam("forum", "Only items of this forum", openapi.String())
+	nicknameQuery := openapi.QueryParam("nickname", "User nickname", openapi.String())
+	nicknameQuery.Required = true
+	expand := func(allowed []string) *openapi.Parameter {
+		return openapi.QueryParam("expand", "Comma separated list of related data paths to include, one of "+strings.Join(allowed, ", "), openapi.String())
+	}
+
+	// Responses
+	badRequest := openapi.Content("Malformed request or invalid fields", codec.MIMEJSON, errorSchema)
+	notFound := openapi.Content("Not found", codec.MIMEJSON, errorSchema)
+	forbidden := openapi.Content("Caller is not the forum owner", codec.MIMEJSON, errorSchema)
+	ok := func(schema *openapi.Schema) map[string]*openapi.Response {
+		return map[string]*openapi.Response{
+			"200": openapi.Content("Success", codec.MIMEJSON, schema),
+			"400": badRequest,
+			"404": notFound,
+		}
+	}
+	withResponse := func(responses map[string]*openapi.Response, code string, response *openapi.Response) map[string]*openapi.Response {
+		responses[code] = response
+		return responses
+	}
+
+	batch := func(schema *openapi.Schema) map[string]*openapi.Response {
+		return map[string]*openapi.Response{
+			"200": openapi.Content("Items found and identifiers not found", codec.MIMEJSON, schema),
+			"400": badRequest,
+		}
+	}
+
+	// User
+	document.Add(http.MethodPost, userURL+"/batch", &openapi.Operation{
+		OperationID: "userGetBatch",
+		Summary:     "Get several user profiles at once",
+		Tags:        []string{"user"},
+		RequestBody: openapi.Body(codec.MIMEJSON, batchNicknamesSchema),
+		Responses:   batch(usersBatchSchema),
+	})
+	document.Add(http.MethodPost, userURL+"/{nickname}/create", &openapi.Operation{
+		OperationID: "userCreate",
+		Summary:     "Create a user",
+		Tags:        []string{"user"},
+		Parameters:  []*openapi.Parameter{nickname},
+		RequestBody: openapi.Body(codec.MIMEJSON, newUserSchema),
+		Responses: map[string]*openapi.Response{
+			"201": openapi.Content("User created", codec.MIMEJSON, userSchema),
+			"400": badRequest,
+			"409": openapi.Content("Users with the same nickname or email", codec.MIMEJSON, usersSchema),
+		},
+	})
+	document.Add(http.MethodGet, userURL+"/{nickname}/profile", &openapi.Operation{
+		OperationID: "userGetOne",
+		Summary:     "Get a user profile",
+		Tags:        []string{"user"},
+		Parameters:  []*openapi.Parameter{nickname},
+		Responses:   ok(userSchema),
+	})
+	document.Add(http.MethodPost, userURL+"/{nickname}/profile", &openapi.Operation{
+		OperationID: "userUpdate",
+		Summary:     "Update a user profile, empty fields are left as they are",
+		Tags:        []string{"user"},
+		Parameters:  []*openapi.Parameter{nickname},
+		RequestBody: openapi.Body(codec.MIMEJSON, userUpdateSchema),
+		Responses:   withResponse(ok(userSchema), "409", openapi.Content("Email is taken by another user", codec.MIMEJSON, errorSchema)),
+	})
+	document.Add(http.MethodGet, userURL+"/{nickname}/votes", &openapi.Operation{
+		OperationID: "userGetVotes",
+		Summary:     "List votes cast by a user",
+		Tags:        []string{"user"},
+		Parameters:  []*openapi.Parameter{nickname, limit, sinceID, desc},
+		Responses:   ok(votesSchema),
+	})
+	document.Add(http.MethodGet, userURL+"/{nickname}/posts", &openapi.Operation{
+		OperationID: "userGetPosts",
+		Summary:     "List posts written by a user",
+		Tags:        []string{"user"},
+		Parameters:  []*openapi.Parameter{nickname, forumFilter, limit, sinceID, desc},
+		Responses:   ok(postsSchema),
+	})
+	document.Add(http.MethodGet, userURL+"/{nickname}/threads", &openapi.Operation{
+		OperationID: "userGetThreads",
+		Summary:     "List threads started by a user",
+		Tags:        []string{"user"},
+		Parameters:  []*openapi.Parameter{nickname, forumFilter, limit, sinceID, desc},
+		Responses:   ok(threadsSchema),
+	})
+	document.Add(http.MethodGet, userURL+"/{nickname}/forums", &openapi.Operation{
+		OperationID: "userGetForums",
+		Summary:     "List forums a user took part in",
+		Tags:        []string{"user"},
+		Parameters:  []*openapi.Parameter{nickname, limit, sinceSlug, desc},
+		Responses:   ok(userForumsSchema),
+	})
+	notificationParams := []*openapi.Parameter{
+		nickname, limit, sinceID,
+		openapi.QueryParam("unread", "Only unread notifications", openapi.Boolean().WithDefault(false)),
+		desc,
+	}
+	document.Add(http.MethodGet, userURL+"/{nickname}/notifications", &openapi.Operation{
+		OperationID: "userGetNotifications",
+		Summary:     "List notifications of a user",
+		Tags:        []string{"user"},
+		Parameters: append([]*openapi.Parameter{
+			openapi.QueryParam("kind", "Only notifications of this kind", openapi.String().WithEnum(
+				models.NotificationThreadCreated, models.NotificationPostCreated, models.NotificationReply)),
+		}, notificationParams...),
+		Responses: ok(notificationsSchema),
+	})
+	document.Add(http.MethodGet, userURL+"/{nickname}/inbox", &openapi.Operation{
+		OperationID: "userGetInbox",
+		Summary:     "List replies to posts of a user",
+		Tags:        []string{"user"},
+		Parameters:  notificationParams,
+		Responses:   ok(notificationsSchema),
+	})
+	document.Add(http.MethodGet, userURL+"/{nickname}/mentions", &openapi.Operation{
+		OperationID: "userGetMentions",
+		Summary:     "List posts mentioning a user",
+		Tags:        []string{"user"},
+		Parameters:  []*openapi.Parameter{nickname, limit, sinceID, desc},
+		Responses:   ok(postsSchema),
+	})
+	document.Add(http.MethodPost, userURL+"/{nickname}/notifications/read", &openapi.Operation{
+		OperationID: "userReadNotifications",
+		Summary:     "Mark notifications of a user as read",
+		Tags:        []string{"user"},
+		Parameters:  []*openapi.Parameter{nickname},
+		RequestBody: openapi.Body(codec.MIMEJSON, notificationsReadSchema),
+		Responses: map[string]*openapi.Response{
+			"200": openapi.NoContent("Notifications marked as read"),
+			"400": badRequest,
+			"404": notFound,
+		},
+	})
+
+	// Forum
+	document.Add(http.MethodPost, forumURL+"/create", &openapi.Operation{
+		OperationID: "forumCreate",
+		Summary:     "Create a forum",
+		Tags:        []string{"forum"},
+		RequestBody: openapi.Body(codec.MIMEJSON, newForumSchema),
+		Responses: map[string]*openapi.Response{
+			"201": openapi.Content("Forum created", codec.MIMEJSON, forumSchema),
+			"400": badRequest,
+			"404": notFound,
+			"409": openapi.Content("Forum with the same slug", codec.MIMEJSON, forumSchema),
+		},
+	})
+	document.Add(http.MethodGet, forumURL+"/{slug}/details", &openapi.Operation{
+		OperationID: "forumGetOne",
+		Summary:     "Get forum details",
+		Tags:        []string{"forum"},
+		Parameters:  []*openapi.Parameter{slug, expand(models.ForumExpansions)},
+		Responses:   ok(forumFullSchema),
+	})
+	document.Add(http.MethodPost, forumURL+"/{slug}/create", &openapi.Operation{
+		OperationID: "threadCreate",
+		Summary:     "Create a thread in a forum",
+		Tags:        []string{"forum"},
+		Parameters:  []*openapi.Parameter{slug},
+		RequestBody: openapi.Body(codec.MIMEJSON, newThreadSchema),
+		Responses: map[string]*openapi.Response{
+			"201": openapi.Content("Thread created", codec.MIMEJSON, threadSchema),
+			"400": badRequest,
+			"404": notFound,
+			"409": openapi.Content("Thread with the same slug", codec.MIMEJSON, threadSchema),
+		},
+	})
+	document.Add(http.MethodGet, forumURL+"/{slug}/users", &openapi.Operation{
+		OperationID: "forumGetUsers",
+		Summary:     "List users who posted in a forum",
+		Tags:        []string{"forum"},
+		Parameters: []*openapi.Parameter{
+			slug, limit, sinceNickname,
+			openapi.QueryParam("sort", "Order of users", openapi.String().WithEnum("nickname", "reputation").WithDefault("nickname")),
+			desc,
+		},
+		Responses: ok(usersSchema),
+	})
+	document.Add(http.MethodGet, forumURL+"/{slug}/threads", &openapi.Operation{
+		OperationID: "forumGetThreads",
+		Summary:     "List threads of a forum, streamed as they are read",
+		Description: streamedListDescription,
+		Tags:        []string{"forum"},
+		Parameters:  []*openapi.Parameter{slug, limit, sinceCreated, desc},
+		Responses:   ok(threadsSchema),
+	})
+	document.Add(http.MethodPost, forumURL+"/{slug}/subscription", &openapi.Operation{
+		OperationID: "forumSubscribe",
+		Summary:     "Subscribe a user to new threads of a forum",
+		Tags:        []string{"forum"},
+		Parameters:  []*openapi.Parameter{slug},
+		RequestBody: openapi.Body(codec.MIMEJSON, newSubscriptionSchema),
+		Responses:   ok(subscriptionSchema),
+	})
+	document.Add(http.MethodDelete, forumURL+"/{slug}/subscription", &openapi.Operation{
+		OperationID: "forumUnsubscribe",
+		Summary:     "Unsubscribe a user from a forum",
+		Tags:        []string{"forum"},
+		Parameters:  []*openapi.Parameter{slug, nicknameQuery},
+		Responses: map[string]*openapi.Response{
+			"200": openapi.NoContent("Subscription removed"),
+			"400": badRequest,
+			"404": notFound,
+		},
+	})
+	document.Add(http.MethodGet, forumURL+"/{slug}/live", &openapi.Operation{
+		OperationID: "forumLive",
+		Summary:     "WebSocket with events of a forum",
+		Tags:        []string{"forum"},
+		Parameters:  []*openapi.Parameter{slug},
+		Responses: map[string]*openapi.Response{
+			"101": openapi.NoContent("Switched to WebSocket, every message is an event as JSON"),
+			"404": notFound,
+		},
+	})
+	document.Add(http.MethodGet, forumURL+"/{slug}/export", &openapi.Operation{
+		OperationID: "forumExport",
+		Summary:     "Download a forum with its threads and posts",
+		Description: "The archive is written while it is read, an error after the first bytes cuts the download short.",
+		Tags:        []string{"forum"},
+		Parameters: []*openapi.Parameter{slug, openapi.QueryParam("format", "Archive format",
+			openapi.String().WithEnum(archive.FormatNDJSON, archive.FormatCSV, archive.FormatHTML).WithDefault(archive.FormatNDJSON))},
+		Responses: map[string]*openapi.Response{
+			"200": {
+				Description: "Archive as an attachment named after the forum",
+				Content: map[string]*openapi.MediaType{
+					"application/x-ndjson": {Schema: openapi.String().WithFormat("binary")},
+					"text/csv":             {Schema: openapi.String().WithFormat("binary")},
+					"text/html":            {Schema: openapi.String().WithFormat("binary")},
+				},
+			},
+			"400": badRequest,
+			"404": notFound,
+		},
+	})
+
+	// Webhooks, managed by the forum owner
+	document.Add(http.MethodPost, forumURL+"/{slug}/webhooks", &openapi.Operation{
+		OperationID: "webhookCreate",
+		Summary:     "Create a webhook of a forum, the secret signing deliveries is returned only here",
+		Tags:        []string{"webhook"},
+		Parameters:  []*openapi.Parameter{slug},
+		RequestBody: openapi.Body(codec.MIMEJSON, newWebhookSchema),
+		Responses: map[string]*openapi.Response{
+			"201": openapi.Content("Webhook created", codec.MIMEJSON, webhookSchema),
+			"400": badRequest,
+			"403": forbidden,
+			"404": notFound,
+		},
+	})
+	document.Add(http.MethodGet, forumURL+"/{slug}/webhooks", &openapi.Operation{
+		OperationID: "webhookGetAll",
+		Summary:     "List webhooks of a forum",
+		Tags:        []string{"webhook"},
+		Parameters:  []*openapi.Parameter{slug, nicknameQuery},
+		Responses:   withResponse(ok(webhooksSchema), "403", forbidden),
+	})
+	document.Add(http.MethodDelete, forumURL+"/{slug}/webhooks/{id}", &openapi.Operation{
+		OperationID: "webhookDelete",
+		Summary:     "Delete a webhook of a forum",
+		Tags:        []string{"webhook"},
+		Parameters:  []*openapi.Parameter{slug, webhookID, nicknameQuery},
+		Responses: map[string]*openapi.Response{
+			"200": openapi.NoContent("Webhook deleted"),
+			"400": badRequest,
+			"403": forbidden,
+			"404": notFound,
+		},
+	})
+	document.Add(http.MethodGet, forumURL+"/{slug}/webhooks/{id}/deliveries", &openapi.Operation{
+		OperationID: "webhookGetDeliveries",
+		Summary:     "List delivery attempts of a webhook",
+		Tags:        []string{"webhook"},
+		Parameters:  []*openapi.Parameter{slug, webhookID, nicknameQuery, limit, sinceID, desc},
+		Responses:   withResponse(ok(webhookDeliveriesSchema), "403", forbidden),
+	})
+
+	// Post
+	document.Add(http.MethodPost, postURL+"/batch", &openapi.Operation{
+		OperationID: "postGetBatch",
+		Summary:     "Get several posts at once",
+		Tags:        []string{"post"},
+		RequestBody: openapi.Body(codec.MIMEJSON, batchIDsSchema),
+		Responses:   batch(postsBatchSchema),
+	})
+	document.Add(http.MethodGet, postURL+"/{id}/details", &openapi.Operation{
+		OperationID: "postGetOne",
+		Summary:     "Get a post with related data",
+		Tags:        []string{"post"},
+		Parameters: []*openapi.Parameter{
+			postID,
+			openapi.QueryParam("related", "Comma separated list of user, forum and thread to include, older form of expand", openapi.String()),
+			expand(models.PostExpansions),
+		},
+		Responses: ok(postFullSchema),
+	})
+	document.Add(http.MethodPost, postURL+"/{id}/details", &openapi.Operation{
+		OperationID: "postUpdate",
+		Summary:     "Edit a post message",
+		Tags:        []string{"post"},
+		Parameters:  []*openapi.Parameter{postID},
+		RequestBody: openapi.Body(codec.MIMEJSON, postUpdateSchema),
+		Responses:   ok(postSchema),
+	})
+	document.Add(http.MethodPost, postURL+"/{id}/vote", &openapi.Operation{
+		OperationID: "postVote",
+		Summary:     "Vote for a post",
+		Tags:        []string{"post"},
+		Parameters:  []*openapi.Parameter{postID},
+		RequestBody: openapi.Body(codec.MIMEJSON, newVoteSchema),
+		Responses:   ok(postSchema),
+	})
+	document.Add(http.MethodGet, postURL+"/{id}/reactions", &openapi.Operation{
+		OperationID: "postGetReactions",
+		Summary:     "List reactions to a post",
+		Tags:        []string{"post"},
+		Parameters: []*openapi.Parameter{postID, limit, sinceNickname,
+			openapi.QueryParam("since_reaction", "With since, return items after this reaction of that nickname", openapi.String()), desc},
+		Responses: ok(reactionsSchema),
+	})
+	document.Add(http.MethodPost, postURL+"/{id}/reactions", &openapi.Operation{
+		OperationID: "postAddReaction",
+		Summary:     "React to a post",
+		Tags:        []string{"post"},
+		Parameters:  []*openapi.Parameter{postID},
+		RequestBody: openapi.Body(codec.MIMEJSON, newReactionSchema),
+		Responses:   ok(postSchema),
+	})
+	document.Add(http.MethodDelete, postURL+"/{id}/reactions", &openapi.Operation{
+		OperationID: "postRemoveReaction",
+		Summary:     "Remove a reaction from a post",
+		Tags:        []string{"post"},
+		Parameters: []*openapi.Parameter{
+			postID, nicknameQuery,
+			{Name: "reaction", In: "query", Description: "Reaction to remove", Required: true, Schema: openapi.String()},
+		},
+		Responses: ok(postSchema),
+	})
+
+	// Thread
+	document.Add(http.MethodPost, threadURL+"/batch", &openapi.Operation{
+		OperationID: "threadGetBatch",
+		Summary:     "Get several threads at once",
+		Tags:        []string{"thread"},
+		RequestBody: openapi.Body(codec.MIMEJSON, batchIDsSchema),
+		Responses:   batch(threadsBatchSchema),
+	})
+	document.Add(http.MethodPost, threadURL+"/{slug_or_id}/create", &openapi.Operation{
+		OperationID: "postsCreate",
+		Summary:     "Create posts in a thread",
+		Tags:        []string{"thread"},
+		Parameters:  []*openapi.Parameter{slugOrID},
+		RequestBody: openapi.Body(codec.MIMEJSON, openapi.ArrayOf(newPostSchema)),
+		Responses: map[string]*openapi.Response{
+			"201": openapi.Content("Posts created", codec.MIMEJSON, postsSchema),
+			"400": badRequest,
+			"404": notFound,
+			"409": openapi.Content("Parent post is in another thread", codec.MIMEJSON, errorSchema),
+		},
+	})
+	document.Add(http.MethodGet, threadURL+"/{slug_or_id}/details", &openapi.Operation{
+		OperationID: "threadGetOne",
+		Summary:     "Get thread details",
+		Tags:        []string{"thread"},
+		Parameters:  []*openapi.Parameter{slugOrID, expand(models.ThreadExpansions)},
+		Responses:   ok(threadFullSchema),
+	})
+	document.Add(http.MethodPost, threadURL+"/{slug_or_id}/details", &openapi.Operation{
+		OperationID: "threadUpdate",
+		Summary:     "Update a thread, empty fields are left as they are",
+		Tags:        []string{"thread"},
+		Parameters:  []*openapi.Parameter{slugOrID},
+		RequestBody: openapi.Body(codec.MIMEJSON, threadUpdateSchema),
+		Responses:   ok(threadSchema),
+	})
+	document.Add(http.MethodGet, threadURL+"/{slug_or_id}/posts", &openapi.Operation{
+		OperationID: "threadGetPosts",
+		Summary:     "List posts of a thread, streamed as they are read",
+		Description: streamedListDescription,
+		Tags:        []string{"thread"},
+		Parameters: []*openapi.Parameter{
+			slugOrID, limit, sinceID,
+			openapi.QueryParam("sort", "Order of posts", openapi.String().WithEnum("flat", "tree", "parent_tree", "top").WithDefault("flat")),
+			desc,
+		},
+		Responses: ok(postsSchema),
+	})
+	document.Add(http.MethodGet, threadURL+"/{slug_or_id}/stream", &openapi.Operation{
+		OperationID: "threadStreamPosts",
+		Summary:     "Server-sent events with new posts of a thread",
+		Tags:        []string{"thread"},
+		Parameters: []*openapi.Parameter{
+			slugOrID,
+			openapi.HeaderParam("Last-Event-ID", "Resume after the post with this id", openapi.Integer()),
+			openapi.QueryParam("lastEventId", "Same as Last-Event-ID for clients unable to set headers", openapi.Integer()),
+		},
+		Responses: map[string]*openapi.Response{
+			"200": openapi.Content("Stream of post events, data of each is a post as JSON", "text/event-stream", openapi.String()),
+			"400": badRequest,
+			"404": notFound,
+		},
+	})
+	document.Add(http.MethodPost, threadURL+"/{slug_or_id}/vote", &openapi.Operation{
+		OperationID: "threadVote",
+		Summary:     "Vote for a thread",
+		Tags:        []string{"thread"},
+		Parameters:  []*openapi.Parameter{slugOrID},
+		RequestBody: openapi.Body(codec.MIMEJSON, newVoteSchema),
+		Responses:   ok(threadSchema),
+	})
+	document.Add(http.MethodDelete, threadURL+"/{slug_or_id}/vote", &openapi.Operation{
+		OperationID: "threadUnvote",
+		Summary:     "Withdraw a vote for a thread",
+		Tags:        []string{"thread"},
+		Parameters:  []*openapi.Parameter{slugOrID, nicknameQuery},
+		Responses:   ok(threadSchema),
+	})
+	document.Add(http.MethodGet, threadURL+"/{slug_or_id}/votes", &openapi.Operation{
+		OperationID: "threadGetVotes",
+		Summary:     "List votes for a thread",
+		Tags:        []string{"thread"},
+		Parameters:  []*openapi.Parameter{slugOrID, limit, sinceNickname, desc},
+		Responses:   ok(votesSchema),
+	})
+	document.Add(http.MethodPost, threadURL+"/{slug_or_id}/subscription", &openapi.Operation{
+		OperationID: "threadSubscribe",
+		Summary:     "Subscribe a user to new posts of a thread",
+		Tags:        []string{"thread"},
+		Parameters:  []*openapi.Parameter{slugOrID},
+		RequestBody: openapi.Body(codec.MIMEJSON, newSubscriptionSchema),
+		Responses:   ok(subscriptionSchema),
+	})
+	document.Add(http.MethodDelete, threadURL+"/{slug_or_id}/subscription", &openapi.Operation{
+		OperationID: "threadUnsubscribe",
+		Summary:     "Unsubscribe a user from a thread",
+		Tags:        []string{"thread"},
+		Parameters:  []*openapi.Parameter{slugOrID, nicknameQuery},
+		Responses: map[string]*openapi.Response{
+			"200": openapi.NoContent("Subscription removed"),
+			"400": badRequest,
+			"404": notFound,
+		},
+	})
+
+	// Service
+	document.Add(http.MethodPost, serviceURL+"/clear", &openapi.Operation{
+		OperationID: "clear",
+		Summary:     "Delete all data",
+		Tags:        []string{"service"},
+		Responses:   map[string]*openapi.Response{"200": openapi.NoContent("Database cleared")},
+	})
+	document.Add(http.MethodGet, serviceURL+"/status", &openapi.Operation{
+		OperationID: "status",
+		Summary:     "Count users, forums, threads and posts",
+		Tags:        []string{"service"},
+		Responses:   map[string]*openapi.Response{"200": openapi.Content("Counters", codec.MIMEJSON, statusSchema)},
+	})
+	document.Add(http.MethodGet, serviceURL+"/changes", &openapi.Operation{
+		OperationID: "changes",
+		Summary:     "List changes after a position of the change feed",
+		Tags:        []string{"service"},
+		Parameters: []*openapi.Parameter{
+			openapi.QueryParam("after", "Return changes after this id", openapi.Integer().WithDefault(0)),
+			limit,
+		},
+		Responses: map[string]*openapi.Response{
+			"200": openapi.Content("Changes", codec.MIMEJSON, changesSchema),
+			"400": badRequest,
+		},
+	})
+	document.Add(http.MethodPost, serviceURL+"/import", &openapi.Operation{
+		OperationID: "import",
+		Summary:     "Bulk import users, forums, threads and posts",
+		Tags:        []string{"service"},
+		RequestBody: openapi.Body("application/x-ndjson", importRecordSchema),
+		Responses: map[string]*openapi.Response{
+			"200": openapi.Content("Import counters and rejected lines", codec.MIMEJSON, importResultSchema),
+			"400": badRequest,
+		},
+	})
+
+	// GraphQL
+	graphQLDescription := "Errors of the query itself are returned in the errors field with status 200."
+	graphQLResponses := map[string]*openapi.Response{
+		"200": openapi.Content("Query result", codec.MIMEJSON, graphQLResultSchema),
+		"400": badRequest,
+	}
+	document.Add(http.MethodGet, graphQLURL, &openapi.Operation{
+		OperationID: "graphQLQuery",
+		Summary:     "Run a GraphQL query given in query parameters",
+		Description: graphQLDescription,
+		Tags:        []string{"graphql"},
+		Parameters: []*openapi.Parameter{
+			openapi.QueryParam("query", "GraphQL document", openapi.String()),
+			openapi.QueryParam("variables", "Variables as a JSON object", openapi.String()),
+			openapi.QueryParam("operationName", "Operation of the document to run", openapi.String()),
+		},
+		Responses: graphQLResponses,
+	})
+	document.Add(http.MethodPost, graphQLURL, &openapi.Operation{
+		OperationID: "graphQLExecute",
+		Summary:     "Run a GraphQL query given in the body",
+		Description: graphQLDescription,
+		Tags:        []string{"graphql"},
+		RequestBody: openapi.Body(codec.MIMEJSON, graphQLRequestSchema),
+		Responses:   graphQLResponses,
+	})
+
+	return document
+}
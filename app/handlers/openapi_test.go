@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+var testRootURLs = []string{"/api", "/api/v1"}
+
+// newTestRouter registers the documented handlers the way the server does, usecases aren't called while routing
+func newTestRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	for _, rootURL := range testRootURLs {
+		rootGroup := router.Group(rootURL)
+		CreateUserHandler(rootGroup, "/user", 100, nil)
+		CreateForumHandler(rootGroup, "/forum", 10000, nil, nil, nil)
+		CreatePostHandler(rootGroup, "/post", 100, nil)
+		CreateServiceHandler(rootGroup, "/service", 10000, nil)
+		CreateThreadHandler(rootGroup, "/thread", 10000, 100, nil, nil)
+		CreateWebhookHandler(rootGroup, "/forum", nil)
+		CreateExportHandler(rootGroup, "/forum", nil)
+		CreateGraphQLHandler(rootGroup, "/graphql", nil)
+	}
+	return router
+}
+
+func TestOpenAPIDocumentCoversRoutes(t *testing.T) {
+	router := newTestRouter()
+	document := NewOpenAPIDocument(testRootURLs, "/user", "/forum", "/post", "/thread", "/service", "/graphql")
+
+	if err := CheckOpenAPIRoutes(router.Routes(), testRootURLs, DocumentedHandlers, document); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCheckOpenAPIRoutesReportsUndocumented(t *testing.T) {
+	router := newTestRouter()
+	userHandler := &UserHandler{UserURL: "/user"}
+	router.Group("/api/v1").GET("/user/:nickname/undocumented", userHandler.GetUser)
+	document := NewOpenAPIDocument(testRootURLs, "/user", "/forum", "/post", "/thread", "/service", "/graphql")
+
+	err := CheckOpenAPIRoutes(router.Routes(), testRootURLs, DocumentedHandlers, document)
+	if err == nil {
+		t.Fatal("undocumented route isn't reported")
+	}
+	if !strings.Contains(err.Error(), "GET /user/{nickname}/undocumented") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -4,69 +4,99 @@ import (
 	"Technopark_DB_Project/app/models"
 	"Technopark_DB_Project/app/usecases"
 	"Technopark_DB_Project/pkg/errors"
+	"Technopark_DB_Project/pkg/expand"
+	"Technopark_DB_Project/pkg/validator"
 	"net/http"
 	"strconv"
-	"strings"
-
-	"github.com/mailru/easyjson"
 
 	"github.com/gin-gonic/gin"
 )
 
 type PostHandler struct {
-	PostURL     string
-	PostUseCase usecases.PostUseCase
+	PostURL      string
+	MaxBatchSize int
+	PostUseCase  usecases.PostUseCase
 }
 
-func CreatePostHandler(router *gin.RouterGroup, postURL string, postUseCase usecases.PostUseCase) {
+func CreatePostHandler(router *gin.RouterGroup, postURL string, maxBatchSize int, postUseCase usecases.PostUseCase) {
 	handler := &PostHandler{
-		PostURL:     postURL,
-		PostUseCase: postUseCase,
+		PostURL:      postURL,
+		MaxBatchSize: maxBatchSize,
+		PostUseCase:  postUseCase,
 	}
 
 	posts := router.Group(handler.PostURL)
 	{
+		posts.POST("/batch", handler.GetPostsBatch)
 		posts.GET("/:id/details", handler.GetPost)
 		posts.POST("/:id/details", handler.UpdatePost)
+		posts.POST("/:id/vote", handler.Vote)
+		posts.GET("/:id/reactions", handler.GetReactions)
+		posts.POST("/:id/reactions", handler.AddReaction)
+		posts.DELETE("/:id/reactions", handler.RemoveReaction)
 	}
 }
 
+func (postHandler *PostHandler) GetPostsBatch(c *gin.Context) {
+	batchIDs := new(models.BatchIDs)
+	if err := decodeBody(c, batchIDs); err != nil {
+		respondError(c, err)
+		return
+	}
+	if err := validator.ValidateBatchIDs(batchIDs, postHandler.MaxBatchSize); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	batch, err := postHandler.PostUseCase.GetBatch(batchIDs.IDs)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respond(c, http.StatusOK, batch)
+}
+
 func (postHandler *PostHandler) GetPost(c *gin.Context) {
 	postIDstr := c.Param("id")
 	postID, err := strconv.Atoi(postIDstr)
-
-	relatedData := c.Query("related")
-	var relatedDataArr []string
-	if relatedData != "" {
-		relatedDataArr = strings.Split(relatedData, ",")
+	if err != nil {
+		respondError(c, errors.ErrBadRequest)
+		return
 	}
 
-	postFull, err := postHandler.PostUseCase.Get(int64(postID), &relatedDataArr)
+	// related is the older form of expand, its values are single level paths
+	paths, err := expand.FromAliases("related", c.QueryArray("related"), models.PostRelated)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	expansion, err := expand.Parse("expand", append(paths, c.QueryArray("expand")...), models.PostExpansions)
 	if err != nil {
-		c.Data(errors.PrepareErrorResponse(err))
+		respondError(c, err)
 		return
 	}
 
-	postFullJSON, err := postFull.MarshalJSON()
+	postFull, err := postHandler.PostUseCase.Get(int64(postID), expansion)
 	if err != nil {
-		c.Data(errors.PrepareErrorResponse(err))
+		respondError(c, err)
 		return
 	}
 
-	c.Data(http.StatusOK, "application/json; charset=utf-8", postFullJSON)
+	respond(c, http.StatusOK, postFull)
 }
 
 func (postHandler *PostHandler) UpdatePost(c *gin.Context) {
 	postIDstr := c.Param("id")
 	postID, err := strconv.Atoi(postIDstr)
 	if err != nil {
-		c.Data(errors.PrepareErrorResponse(err))
+		respondError(c, err)
 		return
 	}
 
 	postUpdate := new(models.PostUpdate)
-	if err := easyjson.UnmarshalFromReader(c.Request.Body, postUpdate); err != nil {
-		c.Data(errors.PrepareErrorResponse(errors.ErrBadRequest))
+	if err := decodeBody(c, postUpdate); err != nil {
+		respondError(c, err)
 		return
 	}
 
@@ -74,17 +104,134 @@ func (postHandler *PostHandler) UpdatePost(c *gin.Context) {
 		ID:      int64(postID),
 		Message: postUpdate.Message,
 	}
+	if err := validator.ValidatePostData(post, true); err != nil {
+		respondError(c, err)
+		return
+	}
+
 	err = postHandler.PostUseCase.Update(post)
 	if err != nil {
-		c.Data(errors.PrepareErrorResponse(err))
+		respondError(c, err)
+		return
+	}
+
+	respond(c, http.StatusOK, post)
+}
+
+func (postHandler *PostHandler) Vote(c *gin.Context) {
+	postIDstr := c.Param("id")
+	postID, err := strconv.Atoi(postIDstr)
+	if err != nil {
+		respondError(c, errors.ErrBadRequest)
+		return
+	}
+
+	vote := new(models.Vote)
+	if err := decodeBody(c, vote); err != nil {
+		respondError(c, err)
+		return
+	}
+	if err := validator.ValidateVoteData(vote); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	post, err := postHandler.PostUseCase.Vote(int64(postID), vote)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respond(c, http.StatusOK, post)
+}
+
+func (postHandler *PostHandler) GetReactions(c *gin.Context) {
+	postIDstr := c.Param("id")
+	postID, err := strconv.Atoi(postIDstr)
+	if err != nil {
+		respondError(c, errors.ErrBadRequest)
+		return
+	}
+
+	limitStr := c.Query("limit")
+	limit := 100
+	if limitStr != "" {
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil {
+			respondError(c, errors.ErrBadRequest)
+			return
+		}
+	}
+	since := c.Query("since")
+	sinceReaction := c.Query("since_reaction")
+	descStr := c.Query("desc")
+	desc := false
+	if descStr != "" {
+		desc, err = strconv.ParseBool(descStr)
+		if err != nil {
+			respondError(c, errors.ErrBadRequest)
+			return
+		}
+	}
+
+	reactions, err := postHandler.PostUseCase.GetReactions(int64(postID), limit, since, sinceReaction, desc)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respond(c, http.StatusOK, reactions)
+}
+
+func (postHandler *PostHandler) AddReaction(c *gin.Context) {
+	postIDstr := c.Param("id")
+	postID, err := strconv.Atoi(postIDstr)
+	if err != nil {
+		respondError(c, errors.ErrBadRequest)
+		return
+	}
+
+	reaction := new(models.Reaction)
+	if err := decodeBody(c, reaction); err != nil {
+		respondError(c, err)
+		return
+	}
+	if err := validator.ValidateReactionData(reaction); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	post, err := postHandler.PostUseCase.AddReaction(int64(postID), reaction)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respond(c, http.StatusOK, post)
+}
+
+func (postHandler *PostHandler) RemoveReaction(c *gin.Context) {
+	postIDstr := c.Param("id")
+	postID, err := strconv.Atoi(postIDstr)
+	if err != nil {
+		respondError(c, errors.ErrBadRequest)
+		return
+	}
+
+	reaction := &models.Reaction{
+		Nickname: c.Query("nickname"),
+		Reaction: c.Query("reaction"),
+	}
+	if err := validator.ValidateReactionData(reaction); err != nil {
+		respondError(c, err)
 		return
 	}
 
-	postJSON, err := post.MarshalJSON()
+	post, err := postHandler.PostUseCase.RemoveReaction(int64(postID), reaction)
 	if err != nil {
-		c.Data(errors.PrepareErrorResponse(err))
+		respondError(c, err)
 		return
 	}
 
-	c.Data(http.StatusOK, "application/json; charset=utf-8", postJSON)
+	respond(c, http.StatusOK, post)
 }
@@ -0,0 +1,29 @@
+package handlers
+
+import (
+	"Technopark_DB_Project/pkg/codec"
+	"Technopark_DB_Project/pkg/errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mailru/easyjson"
+)
+
+// respond writes value in the media type negotiated from the Accept header
+func respond(c *gin.Context, code int, value easyjson.Marshaler) {
+	data, contentType, err := codec.Marshal(codec.Negotiate(c.GetHeader("Accept")), value)
+	if err != nil {
+		c.Data(errors.PrepareErrorResponse(err))
+		return
+	}
+	c.Data(code, contentType, data)
+}
+
+func respondError(c *gin.Context, err error) {
+	code, errorModel := errors.PrepareErrorModel(err)
+	respond(c, code, errorModel)
+}
+
+// decodeBody reads the request body in the media type given by its Content-Type
+func decodeBody(c *gin.Context, value easyjson.Unmarshaler) (err error) {
+	return codec.Decode(codec.MediaType(c.ContentType()), c.Request.Body, value)
+}
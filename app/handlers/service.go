@@ -4,18 +4,21 @@ import (
 	"Technopark_DB_Project/app/usecases"
 	"Technopark_DB_Project/pkg/errors"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
 
 type ServiceHandler struct {
 	ServiceURL     string
+	MaxLimit       int
 	ServiceUseCase usecases.ServiceUseCase
 }
 
-func CreateServiceHandler(router *gin.RouterGroup, serviceURL string, serviceUseCase usecases.ServiceUseCase) {
+func CreateServiceHandler(router *gin.RouterGroup, serviceURL string, maxLimit int, serviceUseCase usecases.ServiceUseCase) {
 	handler := &ServiceHandler{
 		ServiceURL:     serviceURL,
+		MaxLimit:       maxLimit,
 		ServiceUseCase: serviceUseCase,
 	}
 
@@ -23,13 +26,15 @@ func CreateServiceHandler(router *gin.RouterGroup, serviceURL string, serviceUse
 	{
 		service.POST("/clear", handler.Clear)
 		service.GET("/status", handler.GetStatus)
+		service.GET("/changes", handler.GetChanges)
+		service.POST("/import", handler.Import)
 	}
 }
 
 func (serviceHandler *ServiceHandler) Clear(c *gin.Context) {
 	err := serviceHandler.ServiceUseCase.Clear()
 	if err != nil {
-		c.Data(errors.PrepareErrorResponse(err))
+		respondError(c, err)
 		return
 	}
 
@@ -39,15 +44,52 @@ func (serviceHandler *ServiceHandler) Clear(c *gin.Context) {
 func (serviceHandler *ServiceHandler) GetStatus(c *gin.Context) {
 	status, err := serviceHandler.ServiceUseCase.GetStatus()
 	if err != nil {
-		c.Data(errors.PrepareErrorResponse(err))
+		respondError(c, err)
 		return
 	}
 
-	statusJSON, err := status.MarshalJSON()
+	respond(c, http.StatusOK, status)
+}
+
+func (serviceHandler *ServiceHandler) GetChanges(c *gin.Context) {
+	afterStr := c.Query("after")
+	var after int64
+	if afterStr != "" {
+		var err error
+		after, err = strconv.ParseInt(afterStr, 10, 64)
+		if err != nil {
+			respondError(c, errors.ErrBadRequest)
+			return
+		}
+	}
+	limitStr := c.Query("limit")
+	limit := 100
+	if limitStr != "" {
+		var err error
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil {
+			respondError(c, errors.ErrBadRequest)
+			return
+		}
+	}
+
+	limit = clampLimit(limit, serviceHandler.MaxLimit)
+
+	changes, err := serviceHandler.ServiceUseCase.GetChanges(after, limit)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respond(c, http.StatusOK, changes)
+}
+
+func (serviceHandler *ServiceHandler) Import(c *gin.Context) {
+	result, err := serviceHandler.ServiceUseCase.Import(c.Request.Body)
 	if err != nil {
-		c.Data(errors.PrepareErrorResponse(err))
+		respondError(c, err)
 		return
 	}
 
-	c.Data(http.StatusOK, "application/json; charset=utf-8", statusJSON)
+	respond(c, http.StatusOK, result)
 }
@@ -4,32 +4,51 @@ import (
 	"Technopark_DB_Project/app/models"
 	"Technopark_DB_Project/app/usecases"
 	"Technopark_DB_Project/pkg/errors"
+	"Technopark_DB_Project/pkg/expand"
+	"Technopark_DB_Project/pkg/validator"
+	"fmt"
+	"io"
 	"net/http"
 	"strconv"
+	"time"
 
-	"github.com/mailru/easyjson"
+	"github.com/gin-contrib/sse"
 
 	"github.com/gin-gonic/gin"
 )
 
+const streamHeartbeatInterval = 15 * time.Second
+
 type ThreadHandler struct {
 	ThreadURL     string
+	MaxLimit      int
+	MaxBatchSize  int
 	ThreadUseCase usecases.ThreadUseCase
+	StreamUseCase usecases.StreamUseCase
 }
 
-func CreateThreadHandler(router *gin.RouterGroup, threadURL string, threadUseCase usecases.ThreadUseCase) {
+func CreateThreadHandler(router *gin.RouterGroup, threadURL string, maxLimit, maxBatchSize int, threadUseCase usecases.ThreadUseCase, streamUseCase usecases.StreamUseCase) {
 	handler := &ThreadHandler{
 		ThreadURL:     threadURL,
+		MaxLimit:      maxLimit,
+		MaxBatchSize:  maxBatchSize,
 		ThreadUseCase: threadUseCase,
+		StreamUseCase: streamUseCase,
 	}
 
 	threads := router.Group(handler.ThreadURL)
 	{
+		threads.POST("/batch", handler.GetThreadsBatch)
 		threads.POST("/:slug_or_id/create", handler.CreatePosts)
 		threads.GET("/:slug_or_id/details", handler.GetDetails)
 		threads.POST("/:slug_or_id/details", handler.UpdateDetails)
 		threads.GET("/:slug_or_id/posts", handler.GetThreadPosts)
+		threads.GET("/:slug_or_id/stream", handler.StreamPosts)
 		threads.POST("/:slug_or_id/vote", handler.Vote)
+		threads.DELETE("/:slug_or_id/vote", handler.Unvote)
+		threads.GET("/:slug_or_id/votes", handler.GetThreadVotes)
+		threads.POST("/:slug_or_id/subscription", handler.Subscribe)
+		threads.DELETE("/:slug_or_id/subscription", handler.Unsubscribe)
 	}
 }
 
@@ -37,50 +56,68 @@ func (threadHandler *ThreadHandler) CreatePosts(c *gin.Context) {
 	slugOrID := c.Param("slug_or_id")
 
 	posts := new(models.Posts)
-	if err := easyjson.UnmarshalFromReader(c.Request.Body, posts); err != nil {
-		c.Data(errors.PrepareErrorResponse(errors.ErrBadRequest))
+	if err := decodeBody(c, posts); err != nil {
+		respondError(c, err)
+		return
+	}
+	if err := validator.ValidatePostsData(posts); err != nil {
+		respondError(c, err)
 		return
 	}
 
 	err := threadHandler.ThreadUseCase.CreatePosts(slugOrID, posts)
 	if err != nil {
-		c.Data(errors.PrepareErrorResponse(err))
+		respondError(c, err)
+		return
+	}
+
+	respond(c, http.StatusCreated, posts)
+}
+
+func (threadHandler *ThreadHandler) GetThreadsBatch(c *gin.Context) {
+	batchIDs := new(models.BatchIDs)
+	if err := decodeBody(c, batchIDs); err != nil {
+		respondError(c, err)
+		return
+	}
+	if err := validator.ValidateBatchIDs(batchIDs, threadHandler.MaxBatchSize); err != nil {
+		respondError(c, err)
 		return
 	}
 
-	postsJSON, err := posts.MarshalJSON()
+	batch, err := threadHandler.ThreadUseCase.GetBatch(batchIDs.IDs)
 	if err != nil {
-		c.Data(errors.PrepareErrorResponse(err))
+		respondError(c, err)
 		return
 	}
 
-	c.Data(http.StatusCreated, "application/json; charset=utf-8", postsJSON)
+	respond(c, http.StatusOK, batch)
 }
 
 func (threadHandler *ThreadHandler) GetDetails(c *gin.Context) {
 	slugOrID := c.Param("slug_or_id")
 
-	thread, err := threadHandler.ThreadUseCase.Get(slugOrID)
+	expansion, err := expand.Parse("expand", c.QueryArray("expand"), models.ThreadExpansions)
 	if err != nil {
-		c.Data(errors.PrepareErrorResponse(err))
+		respondError(c, err)
 		return
 	}
 
-	threadJSON, err := thread.MarshalJSON()
+	threadFull, err := threadHandler.ThreadUseCase.GetFull(slugOrID, expansion)
 	if err != nil {
-		c.Data(errors.PrepareErrorResponse(err))
+		respondError(c, err)
 		return
 	}
 
-	c.Data(http.StatusOK, "application/json; charset=utf-8", threadJSON)
+	respond(c, http.StatusOK, threadFull)
 }
 
 func (threadHandler *ThreadHandler) UpdateDetails(c *gin.Context) {
 	slugOrID := c.Param("slug_or_id")
 
 	threadUpdate := new(models.ThreadUpdate)
-	if err := easyjson.UnmarshalFromReader(c.Request.Body, threadUpdate); err != nil {
-		c.Data(errors.PrepareErrorResponse(errors.ErrBadRequest))
+	if err := decodeBody(c, threadUpdate); err != nil {
+		respondError(c, err)
 		return
 	}
 
@@ -88,19 +125,18 @@ func (threadHandler *ThreadHandler) UpdateDetails(c *gin.Context) {
 		Title:   threadUpdate.Title,
 		Message: threadUpdate.Message,
 	}
-	err := threadHandler.ThreadUseCase.Update(slugOrID, thread)
-	if err != nil {
-		c.Data(errors.PrepareErrorResponse(err))
+	if err := validator.ValidateThreadData(thread, true); err != nil {
+		respondError(c, err)
 		return
 	}
 
-	threadJSON, err := thread.MarshalJSON()
+	err := threadHandler.ThreadUseCase.Update(slugOrID, thread)
 	if err != nil {
-		c.Data(errors.PrepareErrorResponse(err))
+		respondError(c, err)
 		return
 	}
 
-	c.Data(http.StatusOK, "application/json; charset=utf-8", threadJSON)
+	respond(c, http.StatusOK, thread)
 }
 
 func (threadHandler *ThreadHandler) GetThreadPosts(c *gin.Context) {
@@ -112,7 +148,7 @@ func (threadHandler *ThreadHandler) GetThreadPosts(c *gin.Context) {
 		var err error
 		limit, err = strconv.Atoi(limitStr)
 		if err != nil {
-			c.Data(errors.PrepareErrorResponse(errors.ErrBadRequest))
+			respondError(c, errors.ErrBadRequest)
 			return
 		}
 	}
@@ -122,7 +158,7 @@ func (threadHandler *ThreadHandler) GetThreadPosts(c *gin.Context) {
 		var err error
 		since, err = strconv.Atoi(sinceStr)
 		if err != nil {
-			c.Data(errors.PrepareErrorResponse(errors.ErrBadRequest))
+			respondError(c, errors.ErrBadRequest)
 			return
 		}
 	}
@@ -136,46 +172,192 @@ func (threadHandler *ThreadHandler) GetThreadPosts(c *gin.Context) {
 		var err error
 		desc, err = strconv.ParseBool(descStr)
 		if err != nil {
-			c.Data(errors.PrepareErrorResponse(errors.ErrBadRequest))
+			respondError(c, errors.ErrBadRequest)
 			return
 		}
 	}
 
-	posts, err := threadHandler.ThreadUseCase.GetPosts(slugOrID, limit, since, sort, desc)
+	limit = clampLimit(limit, threadHandler.MaxLimit)
+
+	stream := newListStream(c)
+	err := threadHandler.ThreadUseCase.IteratePosts(slugOrID, limit, since, sort, desc, func(post *models.Post) error {
+		return stream.Write(post)
+	})
+	if err == nil {
+		err = stream.Close()
+	}
 	if err != nil {
-		c.Data(errors.PrepareErrorResponse(err))
+		if stream.IsStarted() {
+			// Status is already sent, the broken array tells the client the list is incomplete
+			fmt.Println(err)
+			return
+		}
+		respondError(c, err)
+	}
+}
+
+func (threadHandler *ThreadHandler) Vote(c *gin.Context) {
+	slugOrID := c.Param("slug_or_id")
+
+	vote := new(models.Vote)
+	if err := decodeBody(c, vote); err != nil {
+		respondError(c, err)
+		return
+	}
+	if err := validator.ValidateVoteData(vote); err != nil {
+		respondError(c, err)
 		return
 	}
 
-	postsJSON, err := posts.MarshalJSON()
+	thread, err := threadHandler.ThreadUseCase.Vote(slugOrID, vote)
 	if err != nil {
-		c.Data(errors.PrepareErrorResponse(err))
+		respondError(c, err)
 		return
 	}
 
-	c.Data(http.StatusOK, "application/json; charset=utf-8", postsJSON)
+	respond(c, http.StatusOK, thread)
 }
 
-func (threadHandler *ThreadHandler) Vote(c *gin.Context) {
+func (threadHandler *ThreadHandler) Unvote(c *gin.Context) {
 	slugOrID := c.Param("slug_or_id")
 
-	vote := new(models.Vote)
-	if err := easyjson.UnmarshalFromReader(c.Request.Body, vote); err != nil {
-		c.Data(errors.PrepareErrorResponse(errors.ErrBadRequest))
+	nickname := c.Query("nickname")
+	if nickname == "" {
+		respondError(c, errors.ErrBadRequest)
 		return
 	}
 
-	thread, err := threadHandler.ThreadUseCase.Vote(slugOrID, vote)
+	thread, err := threadHandler.ThreadUseCase.Unvote(slugOrID, nickname)
 	if err != nil {
-		c.Data(errors.PrepareErrorResponse(err))
+		respondError(c, err)
 		return
 	}
 
-	threadJSON, err := thread.MarshalJSON()
+	respond(c, http.StatusOK, thread)
+}
+
+func (threadHandler *ThreadHandler) GetThreadVotes(c *gin.Context) {
+	slugOrID := c.Param("slug_or_id")
+
+	limitStr := c.Query("limit")
+	limit := 100
+	if limitStr != "" {
+		var err error
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil {
+			respondError(c, errors.ErrBadRequest)
+			return
+		}
+	}
+	since := c.Query("since")
+	descStr := c.Query("desc")
+	desc := false
+	if descStr != "" {
+		var err error
+		desc, err = strconv.ParseBool(descStr)
+		if err != nil {
+			respondError(c, errors.ErrBadRequest)
+			return
+		}
+	}
+
+	votes, err := threadHandler.ThreadUseCase.GetVotes(slugOrID, limit, since, desc)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respond(c, http.StatusOK, votes)
+}
+
+func (threadHandler *ThreadHandler) Subscribe(c *gin.Context) {
+	slugOrID := c.Param("slug_or_id")
+
+	subscription := new(models.Subscription)
+	if err := decodeBody(c, subscription); err != nil {
+		respondError(c, err)
+		return
+	}
+	if err := validator.ValidateSubscriptionData(subscription); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	subscription, err := threadHandler.ThreadUseCase.Subscribe(slugOrID, subscription.Nickname)
 	if err != nil {
-		c.Data(errors.PrepareErrorResponse(err))
+		respondError(c, err)
 		return
 	}
 
-	c.Data(http.StatusOK, "application/json; charset=utf-8", threadJSON)
+	respond(c, http.StatusOK, subscription)
+}
+
+func (threadHandler *ThreadHandler) Unsubscribe(c *gin.Context) {
+	slugOrID := c.Param("slug_or_id")
+
+	nickname := c.Query("nickname")
+	if nickname == "" {
+		respondError(c, errors.ErrBadRequest)
+		return
+	}
+
+	err := threadHandler.ThreadUseCase.Unsubscribe(slugOrID, nickname)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (threadHandler *ThreadHandler) StreamPosts(c *gin.Context) {
+	slugOrID := c.Param("slug_or_id")
+
+	lastEventIDStr := c.GetHeader("Last-Event-ID")
+	if lastEventIDStr == "" {
+		lastEventIDStr = c.Query("lastEventId")
+	}
+	var lastEventID int64 = -1
+	if lastEventIDStr != "" {
+		var err error
+		lastEventID, err = strconv.ParseInt(lastEventIDStr, 10, 64)
+		if err != nil {
+			respondError(c, errors.ErrBadRequest)
+			return
+		}
+	}
+
+	posts, err := threadHandler.StreamUseCase.StreamPosts(c.Request.Context(), slugOrID, lastEventID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no")
+
+	heartbeat := time.NewTicker(streamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case post, ok := <-posts:
+			if !ok {
+				return false
+			}
+			postJSON, err := post.MarshalJSON()
+			if err != nil {
+				return false
+			}
+			return sse.Encode(w, sse.Event{
+				Id:    strconv.FormatInt(post.ID, 10),
+				Event: "post",
+				Data:  string(postJSON),
+			}) == nil
+		case <-heartbeat.C:
+			_, err := io.WriteString(w, ": heartbeat\n\n")
+			return err == nil
+		}
+	})
 }
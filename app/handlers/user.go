@@ -4,29 +4,40 @@ import (
 	"Technopark_DB_Project/app/models"
 	"Technopark_DB_Project/app/usecases"
 	"Technopark_DB_Project/pkg/errors"
+	"Technopark_DB_Project/pkg/validator"
 	"net/http"
-
-	"github.com/mailru/easyjson"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
 
 type UserHandler struct {
-	UserURL     string
-	UserUseCase usecases.UserUseCase
+	UserURL      string
+	MaxBatchSize int
+	UserUseCase  usecases.UserUseCase
 }
 
-func CreateUserHandler(router *gin.RouterGroup, userURL string, userUseCase usecases.UserUseCase) {
+func CreateUserHandler(router *gin.RouterGroup, userURL string, maxBatchSize int, userUseCase usecases.UserUseCase) {
 	handler := &UserHandler{
-		UserURL:     userURL,
-		UserUseCase: userUseCase,
+		UserURL:      userURL,
+		MaxBatchSize: maxBatchSize,
+		UserUseCase:  userUseCase,
 	}
 
 	users := router.Group(handler.UserURL)
 	{
+		users.POST("/batch", handler.GetUsersBatch)
 		users.POST("/:nickname/create", handler.CreateUser)
 		users.GET("/:nickname/profile", handler.GetUser)
 		users.POST("/:nickname/profile", handler.UpdateUser)
+		users.GET("/:nickname/votes", handler.GetUserVotes)
+		users.GET("/:nickname/posts", handler.GetUserPosts)
+		users.GET("/:nickname/threads", handler.GetUserThreads)
+		users.GET("/:nickname/forums", handler.GetUserForums)
+		users.GET("/:nickname/notifications", handler.GetNotifications)
+		users.GET("/:nickname/inbox", handler.GetInbox)
+		users.GET("/:nickname/mentions", handler.GetMentions)
+		users.POST("/:nickname/notifications/read", handler.ReadNotifications)
 	}
 }
 
@@ -34,8 +45,8 @@ func (userHandler *UserHandler) CreateUser(c *gin.Context) {
 	nickname := c.Param("nickname")
 
 	userUpdate := new(models.UserUpdate)
-	if err := easyjson.UnmarshalFromReader(c.Request.Body, userUpdate); err != nil {
-		c.Data(errors.PrepareErrorResponse(errors.ErrBadRequest))
+	if err := decodeBody(c, userUpdate); err != nil {
+		respondError(c, err)
 		return
 	}
 
@@ -45,29 +56,42 @@ func (userHandler *UserHandler) CreateUser(c *gin.Context) {
 		About:    userUpdate.About,
 		Email:    userUpdate.Email,
 	}
+	if err := validator.ValidateUserData(user, false); err != nil {
+		respondError(c, err)
+		return
+	}
 
 	users, err := userHandler.UserUseCase.Create(user)
 	if err != nil {
 		if errors.ResolveErrorToCode(err) == http.StatusConflict {
-			usersJSON, errInt := users.MarshalJSON()
-			if errInt != nil {
-				c.Data(errors.PrepareErrorResponse(err))
-				return
-			}
-			c.Data(errors.ResolveErrorToCode(err), "application/json; charset=utf-8", usersJSON)
+			respond(c, errors.ResolveErrorToCode(err), users)
 		} else {
-			c.Data(errors.PrepareErrorResponse(err))
+			respondError(c, err)
 		}
 		return
 	}
 
-	userJSON, err := user.MarshalJSON()
+	respond(c, http.StatusCreated, user)
+}
+
+func (userHandler *UserHandler) GetUsersBatch(c *gin.Context) {
+	batchNicknames := new(models.BatchNicknames)
+	if err := decodeBody(c, batchNicknames); err != nil {
+		respondError(c, err)
+		return
+	}
+	if err := validator.ValidateBatchNicknames(batchNicknames, userHandler.MaxBatchSize); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	batch, err := userHandler.UserUseCase.GetBatch(batchNicknames.Nicknames)
 	if err != nil {
-		c.Data(errors.PrepareErrorResponse(err))
+		respondError(c, err)
 		return
 	}
 
-	c.Data(http.StatusCreated, "application/json; charset=utf-8", userJSON)
+	respond(c, http.StatusOK, batch)
 }
 
 func (userHandler *UserHandler) GetUser(c *gin.Context) {
@@ -75,37 +99,25 @@ func (userHandler *UserHandler) GetUser(c *gin.Context) {
 
 	user, err := userHandler.UserUseCase.Get(nickname)
 	if err != nil {
-		c.Data(errors.PrepareErrorResponse(err))
+		respondError(c, err)
 		return
 	}
 
-	userJSON, err := user.MarshalJSON()
-	if err != nil {
-		c.Data(errors.PrepareErrorResponse(err))
-		return
-	}
-
-	c.Data(http.StatusOK, "application/json; charset=utf-8", userJSON)
+	respond(c, http.StatusOK, user)
 }
 
 func (userHandler *UserHandler) UpdateUser(c *gin.Context) {
 	nickname := c.Param("nickname")
 
 	userUpdate := new(models.UserUpdate)
-	if err := easyjson.UnmarshalFromReader(c.Request.Body, userUpdate); err != nil {
+	if err := decodeBody(c, userUpdate); err != nil {
 		user, err := userHandler.UserUseCase.Get(nickname)
 		if err != nil {
-			c.Data(errors.PrepareErrorResponse(err))
-			return
-		}
-
-		userJSON, err := user.MarshalJSON()
-		if err != nil {
-			c.Data(errors.PrepareErrorResponse(err))
+			respondError(c, err)
 			return
 		}
 
-		c.Data(http.StatusOK, "application/json; charset=utf-8", userJSON)
+		respond(c, http.StatusOK, user)
 		return
 	}
 
@@ -115,18 +127,303 @@ func (userHandler *UserHandler) UpdateUser(c *gin.Context) {
 		About:    userUpdate.About,
 		Email:    userUpdate.Email,
 	}
+	if err := validator.ValidateUserData(user, true); err != nil {
+		respondError(c, err)
+		return
+	}
 
 	err := userHandler.UserUseCase.Update(user)
 	if err != nil {
-		c.Data(errors.PrepareErrorResponse(err))
+		respondError(c, err)
 		return
 	}
 
-	userJSON, err := user.MarshalJSON()
+	respond(c, http.StatusOK, user)
+}
+
+func (userHandler *UserHandler) GetUserVotes(c *gin.Context) {
+	nickname := c.Param("nickname")
+
+	limitStr := c.Query("limit")
+	limit := 100
+	if limitStr != "" {
+		var err error
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil {
+			respondError(c, errors.ErrBadRequest)
+			return
+		}
+	}
+	sinceStr := c.Query("since")
+	var since int64 = -1
+	if sinceStr != "" {
+		var err error
+		since, err = strconv.ParseInt(sinceStr, 10, 64)
+		if err != nil {
+			respondError(c, errors.ErrBadRequest)
+			return
+		}
+	}
+	descStr := c.Query("desc")
+	desc := false
+	if descStr != "" {
+		var err error
+		desc, err = strconv.ParseBool(descStr)
+		if err != nil {
+			respondError(c, errors.ErrBadRequest)
+			return
+		}
+	}
+
+	votes, err := userHandler.UserUseCase.GetVotes(nickname, limit, since, desc)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respond(c, http.StatusOK, votes)
+}
+
+func (userHandler *UserHandler) GetUserPosts(c *gin.Context) {
+	nickname := c.Param("nickname")
+
+	forum := c.Query("forum")
+	limitStr := c.Query("limit")
+	limit := 100
+	if limitStr != "" {
+		var err error
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil {
+			respondError(c, errors.ErrBadRequest)
+			return
+		}
+	}
+	sinceStr := c.Query("since")
+	var since int64 = -1
+	if sinceStr != "" {
+		var err error
+		since, err = strconv.ParseInt(sinceStr, 10, 64)
+		if err != nil {
+			respondError(c, errors.ErrBadRequest)
+			return
+		}
+	}
+	descStr := c.Query("desc")
+	desc := false
+	if descStr != "" {
+		var err error
+		desc, err = strconv.ParseBool(descStr)
+		if err != nil {
+			respondError(c, errors.ErrBadRequest)
+			return
+		}
+	}
+
+	posts, err := userHandler.UserUseCase.GetPosts(nickname, forum, limit, since, desc)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respond(c, http.StatusOK, posts)
+}
+
+func (userHandler *UserHandler) GetUserThreads(c *gin.Context) {
+	nickname := c.Param("nickname")
+
+	forum := c.Query("forum")
+	limitStr := c.Query("limit")
+	limit := 100
+	if limitStr != "" {
+		var err error
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil {
+			respondError(c, errors.ErrBadRequest)
+			return
+		}
+	}
+	sinceStr := c.Query("since")
+	var since int64 = -1
+	if sinceStr != "" {
+		var err error
+		since, err = strconv.ParseInt(sinceStr, 10, 64)
+		if err != nil {
+			respondError(c, errors.ErrBadRequest)
+			return
+		}
+	}
+	descStr := c.Query("desc")
+	desc := false
+	if descStr != "" {
+		var err error
+		desc, err = strconv.ParseBool(descStr)
+		if err != nil {
+			respondError(c, errors.ErrBadRequest)
+			return
+		}
+	}
+
+	threads, err := userHandler.UserUseCase.GetThreads(nickname, forum, limit, since, desc)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respond(c, http.StatusOK, threads)
+}
+
+func (userHandler *UserHandler) GetUserForums(c *gin.Context) {
+	nickname := c.Param("nickname")
+
+	limitStr := c.Query("limit")
+	limit := 100
+	if limitStr != "" {
+		var err error
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil {
+			respondError(c, errors.ErrBadRequest)
+			return
+		}
+	}
+	since := c.Query("since")
+	descStr := c.Query("desc")
+	desc := false
+	if descStr != "" {
+		var err error
+		desc, err = strconv.ParseBool(descStr)
+		if err != nil {
+			respondError(c, errors.ErrBadRequest)
+			return
+		}
+	}
+
+	forums, err := userHandler.UserUseCase.GetForums(nickname, limit, since, desc)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respond(c, http.StatusOK, forums)
+}
+
+func (userHandler *UserHandler) GetNotifications(c *gin.Context) {
+	userHandler.getNotifications(c, c.Query("kind"))
+}
+
+func (userHandler *UserHandler) GetInbox(c *gin.Context) {
+	userHandler.getNotifications(c, models.NotificationReply)
+}
+
+func (userHandler *UserHandler) getNotifications(c *gin.Context, kind string) {
+	nickname := c.Param("nickname")
+
+	limitStr := c.Query("limit")
+	limit := 100
+	if limitStr != "" {
+		var err error
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil {
+			respondError(c, errors.ErrBadRequest)
+			return
+		}
+	}
+	sinceStr := c.Query("since")
+	var since int64 = -1
+	if sinceStr != "" {
+		var err error
+		since, err = strconv.ParseInt(sinceStr, 10, 64)
+		if err != nil {
+			respondError(c, errors.ErrBadRequest)
+			return
+		}
+	}
+	unreadStr := c.Query("unread")
+	unread := false
+	if unreadStr != "" {
+		var err error
+		unread, err = strconv.ParseBool(unreadStr)
+		if err != nil {
+			respondError(c, errors.ErrBadRequest)
+			return
+		}
+	}
+	descStr := c.Query("desc")
+	desc := false
+	if descStr != "" {
+		var err error
+		desc, err = strconv.ParseBool(descStr)
+		if err != nil {
+			respondError(c, errors.ErrBadRequest)
+			return
+		}
+	}
+
+	notifications, err := userHandler.UserUseCase.GetNotifications(nickname, kind, limit, since, unread, desc)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respond(c, http.StatusOK, notifications)
+}
+
+func (userHandler *UserHandler) ReadNotifications(c *gin.Context) {
+	nickname := c.Param("nickname")
+
+	notificationsRead := new(models.NotificationsRead)
+	if err := decodeBody(c, notificationsRead); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	err := userHandler.UserUseCase.ReadNotifications(nickname, notificationsRead.IDs)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (userHandler *UserHandler) GetMentions(c *gin.Context) {
+	nickname := c.Param("nickname")
+
+	limitStr := c.Query("limit")
+	limit := 100
+	if limitStr != "" {
+		var err error
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil {
+			respondError(c, errors.ErrBadRequest)
+			return
+		}
+	}
+	sinceStr := c.Query("since")
+	var since int64 = -1
+	if sinceStr != "" {
+		var err error
+		since, err = strconv.ParseInt(sinceStr, 10, 64)
+		if err != nil {
+			respondError(c, errors.ErrBadRequest)
+			return
+		}
+	}
+	descStr := c.Query("desc")
+	desc := false
+	if descStr != "" {
+		var err error
+		desc, err = strconv.ParseBool(descStr)
+		if err != nil {
+			respondError(c, errors.ErrBadRequest)
+			return
+		}
+	}
+
+	posts, err := userHandler.UserUseCase.GetMentions(nickname, limit, since, desc)
 	if err != nil {
-		c.Data(errors.PrepareErrorResponse(err))
+		respondError(c, err)
 		return
 	}
 
-	c.Data(http.StatusOK, "application/json; charset=utf-8", userJSON)
+	respond(c, http.StatusOK, posts)
 }
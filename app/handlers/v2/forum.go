@@ -0,0 +1,147 @@
+package v2
+
+import (
+	"Technopark_DB_Project/app/models"
+	"Technopark_DB_Project/app/usecases"
+	"Technopark_DB_Project/pkg/errors"
+	"Technopark_DB_Project/pkg/expand"
+	"Technopark_DB_Project/pkg/validator"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ForumHandler struct {
+	ForumURL     string
+	MaxLimit     int
+	ForumUseCase usecases.ForumUseCase
+}
+
+func CreateForumHandler(router *gin.RouterGroup, forumURL string, maxLimit int, forumUseCase usecases.ForumUseCase) {
+	handler := &ForumHandler{
+		ForumURL:     forumURL,
+		MaxLimit:     maxLimit,
+		ForumUseCase: forumUseCase,
+	}
+
+	forums := router.Group(handler.ForumURL)
+	{
+		forums.POST("/create", handler.CreateForum)
+		forums.GET("/:slug/details", handler.GetDetails)
+		forums.POST("/:slug/create", handler.CreateThread)
+		forums.GET("/:slug/users", handler.GetForumUsers)
+		forums.GET("/:slug/threads", handler.GetForumThreads)
+	}
+}
+
+func (forumHandler *ForumHandler) CreateForum(c *gin.Context) {
+	forum := new(models.Forum)
+	if err := decodeBody(c, forum); err != nil {
+		respondError(c, err)
+		return
+	}
+	if err := validator.ValidateForumData(forum); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	err := forumHandler.ForumUseCase.CreateForum(forum)
+	if err != nil {
+		if errors.ResolveErrorToCode(err) == http.StatusConflict {
+			respondConflict(c, err, forum)
+		} else {
+			respondError(c, err)
+		}
+		return
+	}
+
+	respond(c, http.StatusCreated, forum)
+}
+
+func (forumHandler *ForumHandler) GetDetails(c *gin.Context) {
+	expansion, err := expand.Parse("expand", c.QueryArray("expand"), models.ForumExpansions)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	forumFull, err := forumHandler.ForumUseCase.GetFull(c.Param("slug"), expansion)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respond(c, http.StatusOK, forumFull)
+}
+
+func (forumHandler *ForumHandler) CreateThread(c *gin.Context) {
+	thread := new(models.Thread)
+	if err := decodeBody(c, thread); err != nil {
+		respondError(c, err)
+		return
+	}
+	thread.Forum = c.Param("slug")
+	if err := validator.ValidateThreadData(thread, false); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	err := forumHandler.ForumUseCase.CreateThread(thread)
+	if err != nil {
+		if errors.ResolveErrorToCode(err) == http.StatusConflict {
+			respondConflict(c, err, thread)
+		} else {
+			respondError(c, err)
+		}
+		return
+	}
+
+	respond(c, http.StatusCreated, thread)
+}
+
+// GetForumUsers pages by nickname for both sorts, the reputation order resumes after the user of the cursor
+func (forumHandler *ForumHandler) GetForumUsers(c *gin.Context) {
+	limit, since, desc, err := pageParams(c, forumHandler.MaxLimit)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	sort := c.DefaultQuery("sort", "nickname")
+	if sort != "nickname" && sort != "reputation" {
+		validationError := new(errors.ValidationError)
+		validationError.Add("sort", "must be one of nickname, reputation")
+		respondError(c, validationError)
+		return
+	}
+
+	users, err := forumHandler.ForumUseCase.GetUsers(c.Param("slug"), limit+1, since, sort, desc)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	page, kept := paginate(len(*users), limit, func(i int) string {
+		return (*users)[i].Nickname
+	})
+	*users = (*users)[:kept]
+	respondPage(c, users, page)
+}
+
+func (forumHandler *ForumHandler) GetForumThreads(c *gin.Context) {
+	limit, since, desc, err := pageParams(c, forumHandler.MaxLimit)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	slug := c.Param("slug")
+	threads, page, err := pageThreads(limit, since, desc, func(limit int, since string) (*models.Threads, error) {
+		return forumHandler.ForumUseCase.GetThreads(slug, limit, since, desc)
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respondPage(c, &threads, page)
+}
@@ -0,0 +1,351 @@
+package v2
+
+import (
+	"Technopark_DB_Project/app/models"
+	"Technopark_DB_Project/pkg/codec"
+	"Technopark_DB_Project/pkg/openapi"
+	"net/http"
+	"strings"
+)
+
+// DocumentedHandlers are the v2 handlers whose every route must have an operation in the v2 document
+var DocumentedHandlers = []string{
+	"v2.(*UserHandler)",
+	"v2.(*ForumHandler)",
+	"v2.(*PostHandler)",
+	"v2.(*ThreadHandler)",
+	"v2.(*ServiceHandler)",
+}
+
+// NewOpenAPIDocument describes the v2 routes. Every response is an envelope, so response schemas
+// are built around the models rather than referring to them directly.
+func NewOpenAPIDocument(serverURL, userURL, forumURL, postURL, threadURL, serviceURL string) *openapi.Document {
+	document := openapi.NewDocument(openapi.Info{
+		Title: "Technopark DB forum, API v2",
+		Description: "Responses are envelopes holding data, page and error. Lists are paged by the opaque cursor " +
+			"taken from page.next of the previous page.",
+		Version: "2.0",
+	}, serverURL)
+
+	// Models, nested ones go first to be referenced by the rest
+	document.AddModel("FieldError", models.FieldError{})
+	apiErrorSchema := document.AddModel("APIError", models.APIError{})
+	pageSchema := document.AddModel("Page", models.Page{})
+	userSchema := document.AddModel("User", models.User{})
+	usersSchema := document.AddModel("Users", models.Users{})
+	forumSchema := document.AddModel("Forum", models.Forum{})
+	threadSchema := document.AddModel("Thread", models.Thread{})
+	threadsSchema := document.AddModel("Threads", models.Threads{})
+	postSchema := document.AddModel("Post", models.Post{})
+	postsSchema := document.AddModel("Posts", models.Posts{})
+	document.AddModel("ForumExpanded", models.ForumExpanded{})
+	forumFullSchema := document.AddModel("ForumFull", models.ForumFull{})
+	document.AddModel("ThreadExpanded", models.ThreadExpanded{})
+	threadFullSchema := document.AddModel("ThreadFull", models.ThreadFull{})
+	postFullSchema := document.AddModel("PostFull", models.PostFull{})
+	document.AddModel("Vote", models.Vote{})
+	votesSchema := document.AddModel("Votes", models.Votes{})
+	document.AddModel("Reaction", models.Reaction{})
+	reactionsSchema := document.AddModel("Reactions", models.Reactions{})
+	document.AddModel("UserForum", models.UserForum{})
+	userForumsSchema := document.AddModel("UserForums", models.UserForums{})
+	statusSchema := document.AddModel("Status", models.Status{})
+
+	// Request bodies
+	newUserSchema := document.AddRequestModel("NewUser", models.UserUpdate{}, []string{"fullname", "about", "email"}, "fullname", "email")
+	userUpdateSchema := document.AddRequestModel("UserUpdate", models.UserUpdate{}, []string{"fullname", "about", "email"})
+	newForumSchema := document.AddRequestModel("NewForum", models.Forum{}, []string{"title", "user", "slug"}, "title", "user", "slug")
+	newThreadSchema := document.AddRequestModel("NewThread", models.Thread{},
+		[]string{"title", "author", "message", "slug", "created"}, "title", "author", "message")
+	threadUpdateSchema := document.AddRequestModel("ThreadUpdate", models.ThreadUpdate{}, []string{"title", "message"})
+	newPostSchema := document.AddRequestModel("NewPost", models.Post{}, []string{"parent", "author", "message"}, "author", "message")
+	postUpdateSchema := document.AddRequestModel("PostUpdate", models.PostUpdate{}, []string{"message"})
+	newVoteSchema := document.AddRequestModel("NewVote", models.Vote{}, []string{"nickname", "voice"}, "nickname", "voice")
+	document.Schema(newVoteSchema).Properties["voice"] = openapi.Integer().WithEnum(models.Dislike, models.Like)
+
+	// Envelopes
+	document.Components.Schemas["ErrorEnvelope"] = &openapi.Schema{
+		Type:       "object",
+		Properties: map[string]*openapi.Schema{"error": apiErrorSchema},
+		Required:   []string{"error"},
+	}
+	errorEnvelopeSchema := openapi.Ref("ErrorEnvelope")
+	envelope := func(data *openapi.Schema) *openapi.Schema {
+		return &openapi.Schema{
+			Type:       "object",
+			Properties: map[string]*openapi.Schema{"data": data},
+			Required:   []string{"data"},
+		}
+	}
+	pageEnvelope := func(items *openapi.Schema) *openapi.Schema {
+		return &openapi.Schema{
+			Type:       "object",
+			Properties: map[string]*openapi.Schema{"data": items, "page": pageSchema},
+			Required:   []string{"data", "page"},
+		}
+	}
+	conflictEnvelope := func(existing *openapi.Schema) *openapi.Schema {
+		return &openapi.Schema{
+			Type:       "object",
+			Properties: map[string]*openapi.Schema{"data": existing, "error": apiErrorSchema},
+			Required:   []string{"error"},
+		}
+	}
+
+	// Parameters
+	nickname := openapi.PathParam("nickname", "User nickname, case insensitive", openapi.String())
+	slug := openapi.PathParam("slug", "Forum slug, case insensitive", openapi.String())
+	slugOrID := openapi.PathParam("slug_or_id", "Thread slug or numeric id", openapi.String())
+	postID := openapi.PathParam("id", "Post id", openapi.Integer())
+	limit := openapi.QueryParam("limit", "Maximum number of items returned, larger values are rejected",
+		openapi.Integer().WithMinimum(1).WithDefault(defaultLimit))
+	cursor := openapi.QueryParam("cursor", "page.next of the previous page", openapi.String())
+	desc := openapi.QueryParam("desc", "Sort in descending order", openapi.Boolean().WithDefault(false))
+	forumFilter := openapi.QueryParam("forum", "Only items of this forum", openapi.String())
+	expand := func(allowed []string) *openapi.Parameter {
+		return openapi.QueryParam("expand", "Comma separated list of related data paths to include, one of "+strings.Join(allowed, ", "), openapi.String())
+	}
+	page := []*openapi.Parameter{limit, cursor, desc}
+	withPage := func(parameters ...*openapi.Parameter) []*openapi.Parameter {
+		return append(parameters, page...)
+	}
+
+	// Responses
+	badRequest := openapi.Content("Malformed request or invalid fields", codec.MIMEJSON, errorEnvelopeSchema)
+	notFound := openapi.Content("Not found", codec.MIMEJSON, errorEnvelopeSchema)
+	ok := func(data *openapi.Schema) map[string]*openapi.Response {
+		return map[string]*openapi.Response{
+			"200": openapi.Content("Success", codec.MIMEJSON, envelope(data)),
+			"400": badRequest,
+			"404": notFound,
+		}
+	}
+	okPage := func(items *openapi.Schema) map[string]*openapi.Response {
+		return map[string]*openapi.Response{
+			"200": openapi.Content("Success", codec.MIMEJSON, pageEnvelope(items)),
+			"400": badRequest,
+			"404": notFound,
+		}
+	}
+	created := func(data *openapi.Schema, conflict string, existing *openapi.Schema) map[string]*openapi.Response {
+		return map[string]*openapi.Response{
+			"201": openapi.Content("Created", codec.MIMEJSON, envelope(data)),
+			"400": badRequest,
+			"404": notFound,
+			"409": openapi.Content(conflict, codec.MIMEJSON, conflictEnvelope(existing)),
+		}
+	}
+
+	// User
+	document.Add(http.MethodPost, userURL+"/{nickname}/create", &openapi.Operation{
+		OperationID: "userCreate",
+		Summary:     "Create a user",
+		Tags:        []string{"user"},
+		Parameters:  []*openapi.Parameter{nickname},
+		RequestBody: openapi.Body(codec.MIMEJSON, newUserSchema),
+		Responses:   created(userSchema, "Users with the same nickname or email", usersSchema),
+	})
+	document.Add(http.MethodGet, userURL+"/{nickname}/profile", &openapi.Operation{
+		OperationID: "userGetOne",
+		Summary:     "Get a user profile",
+		Tags:        []string{"user"},
+		Parameters:  []*openapi.Parameter{nickname},
+		Responses:   ok(userSchema),
+	})
+	document.Add(http.MethodPost, userURL+"/{nickname}/profile", &openapi.Operation{
+		OperationID: "userUpdate",
+		Summary:     "Update a user profile, empty fields are left as they are",
+		Tags:        []string{"user"},
+		Parameters:  []*openapi.Parameter{nickname},
+		RequestBody: openapi.Body(codec.MIMEJSON, userUpdateSchema),
+		Responses:   ok(userSchema),
+	})
+	document.Add(http.MethodGet, userURL+"/{nickname}/votes", &openapi.Operation{
+		OperationID: "userGetVotes",
+		Summary:     "List votes cast by a user",
+		Tags:        []string{"user"},
+		Parameters:  withPage(nickname),
+		Responses:   okPage(votesSchema),
+	})
+	document.Add(http.MethodGet, userURL+"/{nickname}/posts", &openapi.Operation{
+		OperationID: "userGetPosts",
+		Summary:     "List posts of a user",
+		Tags:        []string{"user"},
+		Parameters:  withPage(nickname, forumFilter),
+		Responses:   okPage(postsSchema),
+	})
+	document.Add(http.MethodGet, userURL+"/{nickname}/threads", &openapi.Operation{
+		OperationID: "userGetThreads",
+		Summary:     "List threads of a user",
+		Tags:        []string{"user"},
+		Parameters:  withPage(nickname, forumFilter),
+		Responses:   okPage(threadsSchema),
+	})
+	document.Add(http.MethodGet, userURL+"/{nickname}/forums", &openapi.Operation{
+		OperationID: "userGetForums",
+		Summary:     "List forums a user took part in",
+		Tags:        []string{"user"},
+		Parameters:  withPage(nickname),
+		Responses:   okPage(userForumsSchema),
+	})
+	document.Add(http.MethodGet, userURL+"/{nickname}/mentions", &openapi.Operation{
+		OperationID: "userGetMentions",
+		Summary:     "List posts mentioning a user",
+		Tags:        []string{"user"},
+		Parameters:  withPage(nickname),
+		Responses:   okPage(postsSchema),
+	})
+
+	// Forum
+	document.Add(http.MethodPost, forumURL+"/create", &openapi.Operation{
+		OperationID: "forumCreate",
+		Summary:     "Create a forum",
+		Tags:        []string{"forum"},
+		RequestBody: openapi.Body(codec.MIMEJSON, newForumSchema),
+		Responses:   created(forumSchema, "Forum with the same slug", forumSchema),
+	})
+	document.Add(http.MethodGet, forumURL+"/{slug}/details", &openapi.Operation{
+		OperationID: "forumGetOne",
+		Summary:     "Get forum details",
+		Tags:        []string{"forum"},
+		Parameters:  []*openapi.Parameter{slug, expand(models.ForumExpansions)},
+		Responses:   ok(forumFullSchema),
+	})
+	document.Add(http.MethodPost, forumURL+"/{slug}/create", &openapi.Operation{
+		OperationID: "threadCreate",
+		Summary:     "Create a thread in a forum",
+		Tags:        []string{"forum"},
+		Parameters:  []*openapi.Parameter{slug},
+		RequestBody: openapi.Body(codec.MIMEJSON, newThreadSchema),
+		Responses:   created(threadSchema, "Thread with the same slug", threadSchema),
+	})
+	document.Add(http.MethodGet, forumURL+"/{slug}/users", &openapi.Operation{
+		OperationID: "forumGetUsers",
+		Summary:     "List users who took part in a forum",
+		Tags:        []string{"forum"},
+		Parameters: withPage(slug,
+			openapi.QueryParam("sort", "Order of users", openapi.String().WithEnum("nickname", "reputation").WithDefault("nickname"))),
+		Responses: okPage(usersSchema),
+	})
+	document.Add(http.MethodGet, forumURL+"/{slug}/threads", &openapi.Operation{
+		OperationID: "forumGetThreads",
+		Summary:     "List threads of a forum by creation time",
+		Tags:        []string{"forum"},
+		Parameters:  withPage(slug),
+		Responses:   okPage(threadsSchema),
+	})
+
+	// Post
+	document.Add(http.MethodGet, postURL+"/{id}/details", &openapi.Operation{
+		OperationID: "postGetOne",
+		Summary:     "Get a post with related data",
+		Tags:        []string{"post"},
+		Parameters: []*openapi.Parameter{
+			postID,
+			openapi.QueryParam("related", "Comma separated list of user, forum and thread to include, older form of expand", openapi.String()),
+			expand(models.PostExpansions),
+		},
+		Responses: ok(postFullSchema),
+	})
+	document.Add(http.MethodPost, postURL+"/{id}/details", &openapi.Operation{
+		OperationID: "postUpdate",
+		Summary:     "Edit a post message",
+		Tags:        []string{"post"},
+		Parameters:  []*openapi.Parameter{postID},
+		RequestBody: openapi.Body(codec.MIMEJSON, postUpdateSchema),
+		Responses:   ok(postSchema),
+	})
+	document.Add(http.MethodPost, postURL+"/{id}/vote", &openapi.Operation{
+		OperationID: "postVote",
+		Summary:     "Vote for a post",
+		Tags:        []string{"post"},
+		Parameters:  []*openapi.Parameter{postID},
+		RequestBody: openapi.Body(codec.MIMEJSON, newVoteSchema),
+		Responses:   ok(postSchema),
+	})
+	document.Add(http.MethodGet, postURL+"/{id}/reactions", &openapi.Operation{
+		OperationID: "postGetReactions",
+		Summary:     "List reactions to a post",
+		Tags:        []string{"post"},
+		Parameters:  withPage(postID),
+		Responses:   okPage(reactionsSchema),
+	})
+
+	// Thread
+	document.Add(http.MethodPost, threadURL+"/{slug_or_id}/create", &openapi.Operation{
+		OperationID: "postsCreate",
+		Summary:     "Create posts in a thread",
+		Tags:        []string{"thread"},
+		Parameters:  []*openapi.Parameter{slugOrID},
+		RequestBody: openapi.Body(codec.MIMEJSON, openapi.ArrayOf(newPostSchema)),
+		Responses: map[string]*openapi.Response{
+			"201": openapi.Content("Posts created", codec.MIMEJSON, envelope(postsSchema)),
+			"400": badRequest,
+			"404": notFound,
+			"409": openapi.Content("Parent post is in another thread", codec.MIMEJSON, errorEnvelopeSchema),
+		},
+	})
+	document.Add(http.MethodGet, threadURL+"/{slug_or_id}/details", &openapi.Operation{
+		OperationID: "threadGetOne",
+		Summary:     "Get thread details",
+		Tags:        []string{"thread"},
+		Parameters:  []*openapi.Parameter{slugOrID, expand(models.ThreadExpansions)},
+		Responses:   ok(threadFullSchema),
+	})
+	document.Add(http.MethodPost, threadURL+"/{slug_or_id}/details", &openapi.Operation{
+		OperationID: "threadUpdate",
+		Summary:     "Update a thread, empty fields are left as they are",
+		Tags:        []string{"thread"},
+		Parameters:  []*openapi.Parameter{slugOrID},
+		RequestBody: openapi.Body(codec.MIMEJSON, threadUpdateSchema),
+		Responses:   ok(threadSchema),
+	})
+	document.Add(http.MethodGet, threadURL+"/{slug_or_id}/posts", &openapi.Operation{
+		OperationID: "threadGetPosts",
+		Summary:     "List posts of a thread",
+		Tags:        []string{"thread"},
+		Parameters: withPage(slugOrID,
+			openapi.QueryParam("sort", "Order of posts", openapi.String().WithEnum("flat", "tree", "top").WithDefault("flat"))),
+		Responses: okPage(postsSchema),
+	})
+	document.Add(http.MethodPost, threadURL+"/{slug_or_id}/vote", &openapi.Operation{
+		OperationID: "threadVote",
+		Summary:     "Vote for a thread, a repeated vote replaces the previous one",
+		Tags:        []string{"thread"},
+		Parameters:  []*openapi.Parameter{slugOrID},
+		RequestBody: openapi.Body(codec.MIMEJSON, newVoteSchema),
+		Responses:   ok(threadSchema),
+	})
+	nicknameQuery := openapi.QueryParam("nickname", "User nickname", openapi.String())
+	nicknameQuery.Required = true
+	document.Add(http.MethodDelete, threadURL+"/{slug_or_id}/vote", &openapi.Operation{
+		OperationID: "threadUnvote",
+		Summary:     "Withdraw a vote for a thread",
+		Tags:        []string{"thread"},
+		Parameters:  []*openapi.Parameter{slugOrID, nicknameQuery},
+		Responses:   ok(threadSchema),
+	})
+	document.Add(http.MethodGet, threadURL+"/{slug_or_id}/votes", &openapi.Operation{
+		OperationID: "threadGetVotes",
+		Summary:     "List votes for a thread",
+		Tags:        []string{"thread"},
+		Parameters:  withPage(slugOrID),
+		Responses:   okPage(votesSchema),
+	})
+
+	// Service
+	document.Add(http.MethodPost, serviceURL+"/clear", &openapi.Operation{
+		OperationID: "clear",
+		Summary:     "Delete all data",
+		Tags:        []string{"service"},
+		Responses:   map[string]*openapi.Response{"204": openapi.NoContent("Data deleted")},
+	})
+	document.Add(http.MethodGet, serviceURL+"/status", &openapi.Operation{
+		OperationID: "status",
+		Summary:     "Count users, forums, threads and posts",
+		Tags:        []string{"service"},
+		Responses:   map[string]*openapi.Response{"200": openapi.Content("Success", codec.MIMEJSON, envelope(statusSchema))},
+	})
+
+	return document
+}
@@ -0,0 +1,24 @@
+package v2
+
+import (
+	"Technopark_DB_Project/app/handlers"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestOpenAPIDocumentCoversRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	group := router.Group("/api/v2")
+	CreateUserHandler(group, "/user", 10000, nil)
+	CreateForumHandler(group, "/forum", 10000, nil)
+	CreatePostHandler(group, "/post", 10000, nil)
+	CreateServiceHandler(group, "/service", nil)
+	CreateThreadHandler(group, "/thread", 10000, nil)
+	document := NewOpenAPIDocument("/api/v2", "/user", "/forum", "/post", "/thread", "/service")
+
+	if err := handlers.CheckOpenAPIRoutes(router.Routes(), []string{"/api/v2"}, DocumentedHandlers, document); err != nil {
+		t.Fatal(err)
+	}
+}
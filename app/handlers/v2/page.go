@@ -0,0 +1,128 @@
+package v2
+
+import (
+	"Technopark_DB_Project/app/models"
+	"Technopark_DB_Project/pkg/errors"
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const defaultLimit = 100
+
+// maxThreadsFetchFactor bounds the rows fetched for a page of threads to a multiple of limit+1
+const maxThreadsFetchFactor = 8
+
+// pageParams reads limit, cursor and desc of a list. The cursor is opaque to clients, it is the next
+// field of the previous page. Unlike v1, limits outside 1..maxLimit are rejected rather than clamped.
+func pageParams(c *gin.Context, maxLimit int) (limit int, since string, desc bool, err error) {
+	validationError := new(errors.ValidationError)
+
+	limit = defaultLimit
+	if limitStr := c.Query("limit"); limitStr != "" {
+		var errConv error
+		limit, errConv = strconv.Atoi(limitStr)
+		if errConv != nil || limit < 1 || limit > maxLimit {
+			validationError.Add("limit", "must be an integer from 1 to "+strconv.Itoa(maxLimit))
+		}
+	}
+	if cursor := c.Query("cursor"); cursor != "" {
+		sinceBytes, errDecode := base64.RawURLEncoding.DecodeString(cursor)
+		if errDecode != nil {
+			validationError.Add("cursor", "must be the next field of a previous page")
+		}
+		since = string(sinceBytes)
+	}
+	if descStr := c.Query("desc"); descStr != "" {
+		var errParse error
+		desc, errParse = strconv.ParseBool(descStr)
+		if errParse != nil {
+			validationError.Add("desc", "must be a boolean")
+		}
+	}
+
+	return limit, since, desc, validationError.OrNil()
+}
+
+// paginate is given a list fetched with limit+1, the extra item only tells there is a next page.
+// It returns how many items to keep and the page pointing past the last of them.
+func paginate(length, limit int, keyOf func(i int) string) (page *models.Page, kept int) {
+	page = &models.Page{Limit: limit}
+	if length <= limit {
+		return page, length
+	}
+	page.Next = base64.RawURLEncoding.EncodeToString([]byte(keyOf(limit - 1)))
+	return page, limit
+}
+
+// sinceID turns an id cursor into the since of usecases, where -1 means the start of the list
+func sinceID(since string) (int64, error) {
+	if since == "" {
+		return -1, nil
+	}
+	id, err := strconv.ParseInt(since, 10, 64)
+	if err != nil {
+		return 0, errors.ErrBadRequest
+	}
+	return id, nil
+}
+
+// pageThreads pages thread lists, which the store filters by an inclusive creation time.
+// The cursor keeps the id as well, so threads up to it are skipped, fetching more while ties fill the page.
+// Fetching stops at maxThreadsFetchFactor times the page, then the page may be short and its cursor
+// points at the last fetched thread.
+func pageThreads(limit int, since string, desc bool, fetch func(limit int, since string) (*models.Threads, error)) (threads models.Threads, page *models.Page, err error) {
+	var sinceCreated time.Time
+	var sinceThreadID int64
+	var sinceTime string
+	if since != "" {
+		parts := strings.SplitN(since, "|", 2)
+		if len(parts) != 2 {
+			return nil, nil, errors.ErrBadRequest
+		}
+		if sinceCreated, err = time.Parse(time.RFC3339Nano, parts[0]); err != nil {
+			return nil, nil, errors.ErrBadRequest
+		}
+		if sinceThreadID, err = strconv.ParseInt(parts[1], 10, 64); err != nil {
+			return nil, nil, errors.ErrBadRequest
+		}
+		sinceTime = parts[0]
+	}
+
+	keyOf := func(thread *models.Thread) string {
+		return thread.Created.Format(time.RFC3339Nano) + "|" + strconv.FormatInt(thread.ID, 10)
+	}
+
+	threads = models.Threads{}
+	maxFetchLimit := maxThreadsFetchFactor * (limit + 1)
+	for fetchLimit := limit + 1; ; fetchLimit *= 2 {
+		fetched, errFetch := fetch(fetchLimit, sinceTime)
+		if errFetch != nil {
+			return nil, nil, errFetch
+		}
+		threads = threads[:0]
+		for _, thread := range *fetched {
+			isPastCursor := sinceTime == "" || !thread.Created.Equal(sinceCreated) ||
+				(desc && thread.ID < sinceThreadID) || (!desc && thread.ID > sinceThreadID)
+			if isPastCursor {
+				threads = append(threads, thread)
+			}
+		}
+		if len(threads) > limit || len(*fetched) < fetchLimit {
+			break
+		}
+		if fetchLimit*2 > maxFetchLimit {
+			page = &models.Page{Limit: limit}
+			page.Next = base64.RawURLEncoding.EncodeToString([]byte(keyOf(&(*fetched)[len(*fetched)-1])))
+			return threads, page, nil
+		}
+	}
+
+	page, kept := paginate(len(threads), limit, func(i int) string {
+		return keyOf(&threads[i])
+	})
+	return threads[:kept], page, nil
+}
@@ -0,0 +1,274 @@
+package v2
+
+import (
+	"Technopark_DB_Project/app/models"
+	"Technopark_DB_Project/pkg/errors"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func cursorOf(key string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(key))
+}
+
+func TestPageParams(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name       string
+		query      string
+		wantLimit  int
+		wantSince  string
+		wantDesc   bool
+		wantFields []string
+	}{
+		{name: "defaults", wantLimit: defaultLimit},
+		{name: "all set", query: "limit=5&desc=true&cursor=" + cursorOf("42"), wantLimit: 5, wantSince: "42", wantDesc: true},
+		{name: "limit at the maximum", query: "limit=50", wantLimit: 50},
+		{name: "zero limit", query: "limit=0", wantLimit: 0, wantFields: []string{"limit"}},
+		{name: "limit over the maximum", query: "limit=51", wantLimit: 51, wantFields: []string{"limit"}},
+		{name: "limit not a number", query: "limit=ten", wantLimit: 0, wantFields: []string{"limit"}},
+		{name: "cursor not base64", query: "cursor=***", wantLimit: defaultLimit, wantFields: []string{"cursor"}},
+		{name: "desc not a boolean", query: "desc=yes", wantLimit: defaultLimit, wantFields: []string{"desc"}},
+		{name: "every field", query: "limit=-1&cursor=***&desc=yes", wantLimit: -1, wantFields: []string{"limit", "cursor", "desc"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodGet, "/api/v2/forum/slug/threads?"+test.query, nil)
+
+			limit, since, desc, err := pageParams(c, 50)
+			if limit != test.wantLimit || desc != test.wantDesc {
+				t.Errorf("limit, desc = %d, %v, want %d, %v", limit, desc, test.wantLimit, test.wantDesc)
+			}
+			if test.wantFields == nil && since != test.wantSince {
+				t.Errorf("since = %q, want %q", since, test.wantSince)
+			}
+
+			var fields []string
+			if validationError, isValidationError := err.(*errors.ValidationError); isValidationError {
+				for _, field := range validationError.Fields {
+					fields = append(fields, field.Field)
+				}
+			} else if err != nil {
+				t.Fatalf("err = %v, want a validation error", err)
+			}
+			if !reflect.DeepEqual(fields, test.wantFields) {
+				t.Errorf("fields = %v, want %v", fields, test.wantFields)
+			}
+		})
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	tests := []struct {
+		name     string
+		length   int
+		limit    int
+		wantKept int
+		wantNext string
+	}{
+		{name: "empty", length: 0, limit: 3, wantKept: 0},
+		{name: "short page", length: 2, limit: 3, wantKept: 2},
+		{name: "exactly the limit", length: 3, limit: 3, wantKept: 3},
+		{name: "extra item", length: 4, limit: 3, wantKept: 3, wantNext: cursorOf("item2")},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			page, kept := paginate(test.length, test.limit, func(i int) string {
+				return "item" + strconv.Itoa(i)
+			})
+			if kept != test.wantKept || page.Next != test.wantNext || page.Limit != test.limit {
+				t.Errorf("kept = %d, page = %+v, want %d and next %q", kept, page, test.wantKept, test.wantNext)
+			}
+		})
+	}
+}
+
+func TestSinceID(t *testing.T) {
+	tests := []struct {
+		since   string
+		want    int64
+		wantErr error
+	}{
+		{since: "", want: -1},
+		{since: "0", want: 0},
+		{since: "42", want: 42},
+		{since: "forty two", wantErr: errors.ErrBadRequest},
+		{since: "9223372036854775808", wantErr: errors.ErrBadRequest},
+	}
+	for _, test := range tests {
+		id, err := sinceID(test.since)
+		if err != test.wantErr || (err == nil && id != test.want) {
+			t.Errorf("sinceID(%q) = %d, %v, want %d, %v", test.since, id, err, test.want, test.wantErr)
+		}
+	}
+}
+
+// threadsStore keeps threads ordered by creation time and id and filters them by an inclusive
+// creation time, the way the forum threads query does
+type threadsStore struct {
+	threads models.Threads
+	fetches int
+}
+
+func (store *threadsStore) fetch(desc bool) func(limit int, since string) (*models.Threads, error) {
+	return func(limit int, since string) (*models.Threads, error) {
+		store.fetches++
+		var sinceCreated time.Time
+		if since != "" {
+			var err error
+			if sinceCreated, err = time.Parse(time.RFC3339Nano, since); err != nil {
+				return nil, err
+			}
+		}
+
+		threads := models.Threads{}
+		for i := range store.threads {
+			thread := store.threads[i]
+			if desc {
+				thread = store.threads[len(store.threads)-1-i]
+			}
+			isAfter := since == "" || (desc && !thread.Created.After(sinceCreated)) || (!desc && !thread.Created.Before(sinceCreated))
+			if isAfter && len(threads) < limit {
+				threads = append(threads, thread)
+			}
+		}
+		return &threads, nil
+	}
+}
+
+func TestPageThreads(t *testing.T) {
+	start := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+	// Threads 2 to 5 are created at the same time, so a page can end in the middle of them
+	store := &threadsStore{threads: models.Threads{
+		{ID: 1, Created: start},
+		{ID: 2, Created: start.Add(time.Second)},
+		{ID: 3, Created: start.Add(time.Second)},
+		{ID: 4, Created: start.Add(time.Second)},
+		{ID: 5, Created: start.Add(time.Second)},
+		{ID: 6, Created: start.Add(2 * time.Second)},
+	}}
+
+	tests := []struct {
+		name  string
+		limit int
+		desc  bool
+		want  [][]int64
+	}{
+		{name: "pages of two", limit: 2, want: [][]int64{{1, 2}, {3, 4}, {5, 6}}},
+		{name: "pages of four", limit: 4, want: [][]int64{{1, 2, 3, 4}, {5, 6}}},
+		{name: "one page", limit: 10, want: [][]int64{{1, 2, 3, 4, 5, 6}}},
+		{name: "descending pages of two", limit: 2, desc: true, want: [][]int64{{6, 5}, {4, 3}, {2, 1}}},
+		{name: "descending pages of three", limit: 3, desc: true, want: [][]int64{{6, 5, 4}, {3, 2, 1}}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var pages [][]int64
+			since := ""
+			for len(pages) <= len(store.threads) {
+				threads, page, err := pageThreads(test.limit, since, test.desc, store.fetch(test.desc))
+				if err != nil {
+					t.Fatal(err)
+				}
+				var ids []int64
+				for _, thread := range threads {
+					ids = append(ids, thread.ID)
+				}
+				pages = append(pages, ids)
+
+				if page.Next == "" {
+					break
+				}
+				sinceBytes, err := base64.RawURLEncoding.DecodeString(page.Next)
+				if err != nil {
+					t.Fatal(err)
+				}
+				since = string(sinceBytes)
+			}
+			if !reflect.DeepEqual(pages, test.want) {
+				t.Errorf("pages = %v, want %v", pages, test.want)
+			}
+		})
+	}
+}
+
+func TestPageThreadsFetchesPastTies(t *testing.T) {
+	created := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+	store := &threadsStore{}
+	for id := int64(1); id <= 10; id++ {
+		store.threads = append(store.threads, models.Thread{ID: id, Created: created})
+	}
+
+	// Every thread ties with the cursor, so the ones up to it have to be fetched and skipped
+	since := created.Format(time.RFC3339Nano) + "|8"
+	threads, page, err := pageThreads(1, since, false, store.fetch(false))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(threads) != 1 || threads[0].ID != 9 || page.Next == "" {
+		t.Fatalf("threads = %v, next = %q, want thread 9 and a next page", threads, page.Next)
+	}
+	if store.fetches < 2 {
+		t.Errorf("fetched %d times, want the limit raised past the ties", store.fetches)
+	}
+}
+
+func TestPageThreadsStopsFetching(t *testing.T) {
+	created := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+	store := &threadsStore{}
+	for id := int64(1); id <= 40; id++ {
+		store.threads = append(store.threads, models.Thread{ID: id, Created: created})
+	}
+	key := created.Format(time.RFC3339Nano)
+
+	tests := []struct {
+		name        string
+		limit       int
+		since       string
+		wantIDs     []int64
+		wantNext    string
+		wantFetches int
+	}{
+		{name: "nothing past the cursor", limit: 1, since: key + "|30", wantNext: cursorOf(key + "|16"), wantFetches: 4},
+		{name: "short page", limit: 2, since: key + "|22", wantIDs: []int64{23, 24}, wantNext: cursorOf(key + "|24"), wantFetches: 4},
+		{name: "full page", limit: 1, since: key + "|14", wantIDs: []int64{15}, wantNext: cursorOf(key + "|15"), wantFetches: 4},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			store.fetches = 0
+			threads, page, err := pageThreads(test.limit, test.since, false, store.fetch(false))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var ids []int64
+			for _, thread := range threads {
+				ids = append(ids, thread.ID)
+			}
+			if !reflect.DeepEqual(ids, test.wantIDs) || page.Next != test.wantNext {
+				t.Errorf("threads = %v, next = %q, want %v and %q", ids, page.Next, test.wantIDs, test.wantNext)
+			}
+			if store.fetches != test.wantFetches {
+				t.Errorf("fetched %d times, want %d", store.fetches, test.wantFetches)
+			}
+		})
+	}
+}
+
+func TestPageThreadsBadCursor(t *testing.T) {
+	store := &threadsStore{}
+	for _, since := range []string{"42", "yesterday|1", "2022-01-02T03:04:05Z|first"} {
+		if _, _, err := pageThreads(2, since, false, store.fetch(false)); err != errors.ErrBadRequest {
+			t.Errorf("pageThreads(%q) err = %v, want %v", since, err, errors.ErrBadRequest)
+		}
+	}
+	if store.fetches != 0 {
+		t.Errorf("fetched %d times for bad cursors", store.fetches)
+	}
+}
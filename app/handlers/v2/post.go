@@ -0,0 +1,166 @@
+package v2
+
+import (
+	"Technopark_DB_Project/app/models"
+	"Technopark_DB_Project/app/usecases"
+	"Technopark_DB_Project/pkg/errors"
+	"Technopark_DB_Project/pkg/expand"
+	"Technopark_DB_Project/pkg/validator"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PostHandler struct {
+	PostURL     string
+	MaxLimit    int
+	PostUseCase usecases.PostUseCase
+}
+
+func CreatePostHandler(router *gin.RouterGroup, postURL string, maxLimit int, postUseCase usecases.PostUseCase) {
+	handler := &PostHandler{
+		PostURL:     postURL,
+		MaxLimit:    maxLimit,
+		PostUseCase: postUseCase,
+	}
+
+	posts := router.Group(handler.PostURL)
+	{
+		posts.GET("/:id/details", handler.GetPost)
+		posts.POST("/:id/details", handler.UpdatePost)
+		posts.POST("/:id/vote", handler.Vote)
+		posts.GET("/:id/reactions", handler.GetReactions)
+	}
+}
+
+// postID reports a malformed id as a field error, v1 looked such posts up as id 0
+func postID(c *gin.Context) (id int64, err error) {
+	id, err = strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		validationError := new(errors.ValidationError)
+		validationError.Add("id", "must be an integer")
+		return 0, validationError
+	}
+	return
+}
+
+func (postHandler *PostHandler) GetPost(c *gin.Context) {
+	id, err := postID(c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	paths, err := expand.FromAliases("related", c.QueryArray("related"), models.PostRelated)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	expansion, err := expand.Parse("expand", append(paths, c.QueryArray("expand")...), models.PostExpansions)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	postFull, err := postHandler.PostUseCase.Get(id, expansion)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respond(c, http.StatusOK, postFull)
+}
+
+func (postHandler *PostHandler) UpdatePost(c *gin.Context) {
+	id, err := postID(c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	postUpdate := new(models.PostUpdate)
+	if err = decodeBody(c, postUpdate); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	post := &models.Post{
+		ID:      id,
+		Message: postUpdate.Message,
+	}
+	if err = validator.ValidatePostData(post, true); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	if err = postHandler.PostUseCase.Update(post); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respond(c, http.StatusOK, post)
+}
+
+func (postHandler *PostHandler) Vote(c *gin.Context) {
+	id, err := postID(c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	vote := new(models.Vote)
+	if err = decodeBody(c, vote); err != nil {
+		respondError(c, err)
+		return
+	}
+	if err = validator.ValidateVoteData(vote); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	post, err := postHandler.PostUseCase.Vote(id, vote)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respond(c, http.StatusOK, post)
+}
+
+func (postHandler *PostHandler) GetReactions(c *gin.Context) {
+	id, err := postID(c)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	limit, since, desc, err := pageParams(c, postHandler.MaxLimit)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	// The cursor is the nickname and reaction of the last item, a user may leave several reactions
+	var sinceNickname, sinceReaction string
+	if since != "" {
+		parts := strings.SplitN(since, "|", 2)
+		if len(parts) != 2 {
+			respondError(c, errors.ErrBadRequest)
+			return
+		}
+		sinceNickname, sinceReaction = parts[0], parts[1]
+	}
+
+	reactions, err := postHandler.PostUseCase.GetReactions(id, limit+1, sinceNickname, sinceReaction, desc)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	page, kept := paginate(len(*reactions), limit, func(i int) string {
+		return (*reactions)[i].Nickname + "|" + (*reactions)[i].Reaction
+	})
+	*reactions = (*reactions)[:kept]
+	respondPage(c, reactions, page)
+}
@@ -0,0 +1,45 @@
+package v2
+
+import (
+	"Technopark_DB_Project/app/models"
+	"Technopark_DB_Project/pkg/codec"
+	"Technopark_DB_Project/pkg/errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mailru/easyjson"
+)
+
+// respond wraps value into an envelope. There is no protobuf schema for envelopes,
+// so clients asking for protobuf get JSON the same way as for other models without one.
+func respond(c *gin.Context, code int, value interface{}) {
+	respondEnvelope(c, code, &models.Envelope{Data: value})
+}
+
+func respondPage(c *gin.Context, items interface{}, page *models.Page) {
+	respondEnvelope(c, http.StatusOK, &models.Envelope{Data: items, Page: page})
+}
+
+func respondError(c *gin.Context, err error) {
+	code, apiError := errors.PrepareAPIError(err)
+	respondEnvelope(c, code, &models.Envelope{Error: apiError})
+}
+
+// respondConflict reports a conflict along with the entity already holding the unique data
+func respondConflict(c *gin.Context, err error, existing interface{}) {
+	code, apiError := errors.PrepareAPIError(err)
+	respondEnvelope(c, code, &models.Envelope{Data: existing, Error: apiError})
+}
+
+func respondEnvelope(c *gin.Context, code int, envelope *models.Envelope) {
+	data, contentType, err := codec.Marshal(codec.Negotiate(c.GetHeader("Accept")), envelope)
+	if err != nil {
+		c.Data(errors.PrepareErrorResponse(err))
+		return
+	}
+	c.Data(code, contentType, data)
+}
+
+func decodeBody(c *gin.Context, value easyjson.Unmarshaler) (err error) {
+	return codec.Decode(codec.MediaType(c.ContentType()), c.Request.Body, value)
+}
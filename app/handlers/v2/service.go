@@ -0,0 +1,45 @@
+package v2
+
+import (
+	"Technopark_DB_Project/app/usecases"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ServiceHandler struct {
+	ServiceURL     string
+	ServiceUseCase usecases.ServiceUseCase
+}
+
+func CreateServiceHandler(router *gin.RouterGroup, serviceURL string, serviceUseCase usecases.ServiceUseCase) {
+	handler := &ServiceHandler{
+		ServiceURL:     serviceURL,
+		ServiceUseCase: serviceUseCase,
+	}
+
+	service := router.Group(handler.ServiceURL)
+	{
+		service.POST("/clear", handler.Clear)
+		service.GET("/status", handler.GetStatus)
+	}
+}
+
+func (serviceHandler *ServiceHandler) Clear(c *gin.Context) {
+	if err := serviceHandler.ServiceUseCase.Clear(); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (serviceHandler *ServiceHandler) GetStatus(c *gin.Context) {
+	status, err := serviceHandler.ServiceUseCase.GetStatus()
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respond(c, http.StatusOK, status)
+}
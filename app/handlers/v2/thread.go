@@ -0,0 +1,188 @@
+package v2
+
+import (
+	"Technopark_DB_Project/app/models"
+	"Technopark_DB_Project/app/usecases"
+	"Technopark_DB_Project/pkg/errors"
+	"Technopark_DB_Project/pkg/expand"
+	"Technopark_DB_Project/pkg/validator"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ThreadHandler struct {
+	ThreadURL     string
+	MaxLimit      int
+	ThreadUseCase usecases.ThreadUseCase
+}
+
+func CreateThreadHandler(router *gin.RouterGroup, threadURL string, maxLimit int, threadUseCase usecases.ThreadUseCase) {
+	handler := &ThreadHandler{
+		ThreadURL:     threadURL,
+		MaxLimit:      maxLimit,
+		ThreadUseCase: threadUseCase,
+	}
+
+	threads := router.Group(handler.ThreadURL)
+	{
+		threads.POST("/:slug_or_id/create", handler.CreatePosts)
+		threads.GET("/:slug_or_id/details", handler.GetDetails)
+		threads.POST("/:slug_or_id/details", handler.UpdateDetails)
+		threads.GET("/:slug_or_id/posts", handler.GetThreadPosts)
+		threads.POST("/:slug_or_id/vote", handler.Vote)
+		threads.DELETE("/:slug_or_id/vote", handler.Unvote)
+		threads.GET("/:slug_or_id/votes", handler.GetThreadVotes)
+	}
+}
+
+func (threadHandler *ThreadHandler) CreatePosts(c *gin.Context) {
+	posts := new(models.Posts)
+	if err := decodeBody(c, posts); err != nil {
+		respondError(c, err)
+		return
+	}
+	if err := validator.ValidatePostsData(posts); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	if err := threadHandler.ThreadUseCase.CreatePosts(c.Param("slug_or_id"), posts); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respond(c, http.StatusCreated, posts)
+}
+
+func (threadHandler *ThreadHandler) GetDetails(c *gin.Context) {
+	expansion, err := expand.Parse("expand", c.QueryArray("expand"), models.ThreadExpansions)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	threadFull, err := threadHandler.ThreadUseCase.GetFull(c.Param("slug_or_id"), expansion)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respond(c, http.StatusOK, threadFull)
+}
+
+func (threadHandler *ThreadHandler) UpdateDetails(c *gin.Context) {
+	threadUpdate := new(models.ThreadUpdate)
+	if err := decodeBody(c, threadUpdate); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	thread := &models.Thread{
+		Title:   threadUpdate.Title,
+		Message: threadUpdate.Message,
+	}
+	if err := validator.ValidateThreadData(thread, true); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	if err := threadHandler.ThreadUseCase.Update(c.Param("slug_or_id"), thread); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respond(c, http.StatusOK, thread)
+}
+
+// GetThreadPosts pages by post id. parent_tree is left to v1, its limit counts root posts rather than posts.
+func (threadHandler *ThreadHandler) GetThreadPosts(c *gin.Context) {
+	limit, since, desc, err := pageParams(c, threadHandler.MaxLimit)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	sincePost, err := sinceID(since)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	sort := c.DefaultQuery("sort", "flat")
+	if sort != "flat" && sort != "tree" && sort != "top" {
+		validationError := new(errors.ValidationError)
+		validationError.Add("sort", "must be one of flat, tree, top")
+		respondError(c, validationError)
+		return
+	}
+
+	posts, err := threadHandler.ThreadUseCase.GetPosts(c.Param("slug_or_id"), limit+1, int(sincePost), sort, desc)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	page, kept := paginate(len(*posts), limit, func(i int) string {
+		return strconv.FormatInt((*posts)[i].ID, 10)
+	})
+	*posts = (*posts)[:kept]
+	respondPage(c, posts, page)
+}
+
+func (threadHandler *ThreadHandler) Vote(c *gin.Context) {
+	vote := new(models.Vote)
+	if err := decodeBody(c, vote); err != nil {
+		respondError(c, err)
+		return
+	}
+	if err := validator.ValidateVoteData(vote); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	thread, err := threadHandler.ThreadUseCase.Vote(c.Param("slug_or_id"), vote)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respond(c, http.StatusOK, thread)
+}
+
+func (threadHandler *ThreadHandler) Unvote(c *gin.Context) {
+	nickname := c.Query("nickname")
+	if nickname == "" {
+		validationError := new(errors.ValidationError)
+		validationError.Add("nickname", "must not be empty")
+		respondError(c, validationError)
+		return
+	}
+
+	thread, err := threadHandler.ThreadUseCase.Unvote(c.Param("slug_or_id"), nickname)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respond(c, http.StatusOK, thread)
+}
+
+func (threadHandler *ThreadHandler) GetThreadVotes(c *gin.Context) {
+	limit, since, desc, err := pageParams(c, threadHandler.MaxLimit)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	votes, err := threadHandler.ThreadUseCase.GetVotes(c.Param("slug_or_id"), limit+1, since, desc)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	page, kept := paginate(len(*votes), limit, func(i int) string {
+		return (*votes)[i].Nickname
+	})
+	*votes = (*votes)[:kept]
+	respondPage(c, votes, page)
+}
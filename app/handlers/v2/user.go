@@ -0,0 +1,227 @@
+package v2
+
+import (
+	"Technopark_DB_Project/app/models"
+	"Technopark_DB_Project/app/usecases"
+	"Technopark_DB_Project/pkg/errors"
+	"Technopark_DB_Project/pkg/validator"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type UserHandler struct {
+	UserURL     string
+	MaxLimit    int
+	UserUseCase usecases.UserUseCase
+}
+
+func CreateUserHandler(router *gin.RouterGroup, userURL string, maxLimit int, userUseCase usecases.UserUseCase) {
+	handler := &UserHandler{
+		UserURL:     userURL,
+		MaxLimit:    maxLimit,
+		UserUseCase: userUseCase,
+	}
+
+	users := router.Group(handler.UserURL)
+	{
+		users.POST("/:nickname/create", handler.CreateUser)
+		users.GET("/:nickname/profile", handler.GetUser)
+		users.POST("/:nickname/profile", handler.UpdateUser)
+		users.GET("/:nickname/votes", handler.GetUserVotes)
+		users.GET("/:nickname/posts", handler.GetUserPosts)
+		users.GET("/:nickname/threads", handler.GetUserThreads)
+		users.GET("/:nickname/forums", handler.GetUserForums)
+		users.GET("/:nickname/mentions", handler.GetMentions)
+	}
+}
+
+func (userHandler *UserHandler) CreateUser(c *gin.Context) {
+	userUpdate := new(models.UserUpdate)
+	if err := decodeBody(c, userUpdate); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	user := &models.User{
+		Nickname: c.Param("nickname"),
+		Fullname: userUpdate.Fullname,
+		About:    userUpdate.About,
+		Email:    userUpdate.Email,
+	}
+	if err := validator.ValidateUserData(user, false); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	users, err := userHandler.UserUseCase.Create(user)
+	if err != nil {
+		if errors.ResolveErrorToCode(err) == http.StatusConflict {
+			respondConflict(c, err, users)
+		} else {
+			respondError(c, err)
+		}
+		return
+	}
+
+	respond(c, http.StatusCreated, user)
+}
+
+func (userHandler *UserHandler) GetUser(c *gin.Context) {
+	user, err := userHandler.UserUseCase.Get(c.Param("nickname"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respond(c, http.StatusOK, user)
+}
+
+// UpdateUser rejects bodies it can't read, v1 answered them with the unchanged profile
+func (userHandler *UserHandler) UpdateUser(c *gin.Context) {
+	userUpdate := new(models.UserUpdate)
+	if err := decodeBody(c, userUpdate); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	user := &models.User{
+		Nickname: c.Param("nickname"),
+		Fullname: userUpdate.Fullname,
+		About:    userUpdate.About,
+		Email:    userUpdate.Email,
+	}
+	if err := validator.ValidateUserData(user, true); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	if err := userHandler.UserUseCase.Update(user); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respond(c, http.StatusOK, user)
+}
+
+func (userHandler *UserHandler) GetUserVotes(c *gin.Context) {
+	limit, since, desc, err := pageParams(c, userHandler.MaxLimit)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	sinceThread, err := sinceID(since)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	votes, err := userHandler.UserUseCase.GetVotes(c.Param("nickname"), limit+1, sinceThread, desc)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	page, kept := paginate(len(*votes), limit, func(i int) string {
+		return strconv.FormatInt((*votes)[i].Thread, 10)
+	})
+	*votes = (*votes)[:kept]
+	respondPage(c, votes, page)
+}
+
+func (userHandler *UserHandler) GetUserPosts(c *gin.Context) {
+	limit, since, desc, err := pageParams(c, userHandler.MaxLimit)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	sincePost, err := sinceID(since)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	posts, err := userHandler.UserUseCase.GetPosts(c.Param("nickname"), c.Query("forum"), limit+1, sincePost, desc)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	page, kept := paginate(len(*posts), limit, func(i int) string {
+		return strconv.FormatInt((*posts)[i].ID, 10)
+	})
+	*posts = (*posts)[:kept]
+	respondPage(c, posts, page)
+}
+
+func (userHandler *UserHandler) GetUserThreads(c *gin.Context) {
+	limit, since, desc, err := pageParams(c, userHandler.MaxLimit)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	sinceThread, err := sinceID(since)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	threads, err := userHandler.UserUseCase.GetThreads(c.Param("nickname"), c.Query("forum"), limit+1, sinceThread, desc)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	page, kept := paginate(len(*threads), limit, func(i int) string {
+		return strconv.FormatInt((*threads)[i].ID, 10)
+	})
+	*threads = (*threads)[:kept]
+	respondPage(c, threads, page)
+}
+
+func (userHandler *UserHandler) GetUserForums(c *gin.Context) {
+	limit, since, desc, err := pageParams(c, userHandler.MaxLimit)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	forums, err := userHandler.UserUseCase.GetForums(c.Param("nickname"), limit+1, since, desc)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	page, kept := paginate(len(*forums), limit, func(i int) string {
+		return (*forums)[i].Forum
+	})
+	*forums = (*forums)[:kept]
+	respondPage(c, forums, page)
+}
+
+func (userHandler *UserHandler) GetMentions(c *gin.Context) {
+	limit, since, desc, err := pageParams(c, userHandler.MaxLimit)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	sincePost, err := sinceID(since)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	posts, err := userHandler.UserUseCase.GetMentions(c.Param("nickname"), limit+1, sincePost, desc)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	page, kept := paginate(len(*posts), limit, func(i int) string {
+		return strconv.FormatInt((*posts)[i].ID, 10)
+	})
+	*posts = (*posts)[:kept]
+	respondPage(c, posts, page)
+}
@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"Technopark_DB_Project/pkg/codec"
+	"Technopark_DB_Project/pkg/errors"
+	"Technopark_DB_Project/pkg/openapi"
+	"bytes"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateValidationMiddleware checks query parameters and bodies of documented routes before their handlers run.
+// Unknown body fields and query parameters are rejected only for the operations listed in strictOperations.
+// Bodies that can't be decoded at all are left to handlers, which answer them as before.
+func CreateValidationMiddleware(rootURL string, document *openapi.Document, strictOperations []string) gin.HandlerFunc {
+	isStrict := make(map[string]bool, len(strictOperations))
+	for _, operationID := range strictOperations {
+		isStrict[operationID] = true
+	}
+
+	return func(c *gin.Context) {
+		path := openapi.PathFromGin(strings.TrimPrefix(c.FullPath(), rootURL))
+		operation, isFound := document.Operation(c.Request.Method, path)
+		if !isFound {
+			c.Next()
+			return
+		}
+
+		validationError := new(errors.ValidationError)
+		validateQuery(c, document, operation, isStrict[operation.OperationID], validationError)
+		if err := validateBody(c, document, operation, isStrict[operation.OperationID], validationError); err != nil {
+			respondError(c, err)
+			c.Abort()
+			return
+		}
+		if err := validationError.OrNil(); err != nil {
+			respondError(c, err)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+func validateQuery(c *gin.Context, document *openapi.Document, operation *openapi.Operation, strict bool, validationError *errors.ValidationError) {
+	query := c.Request.URL.Query()
+	known := make(map[string]bool, len(operation.Parameters))
+	for _, parameter := range operation.Parameters {
+		if parameter.In != "query" {
+			continue
+		}
+		known[parameter.Name] = true
+
+		// Handlers treat empty values as missing ones
+		value := query.Get(parameter.Name)
+		if value == "" {
+			if parameter.Required {
+				validationError.Add(parameter.Name, "is required")
+			}
+			continue
+		}
+		document.ValidateParameter(parameter, value, validationError)
+	}
+
+	if strict {
+		var unknown []string
+		for name := range query {
+			if !known[name] {
+				unknown = append(unknown, name)
+			}
+		}
+		sort.Strings(unknown)
+		for _, name := range unknown {
+			validationError.Add(name, "is not allowed")
+		}
+	}
+}
+
+// validateBody reads the body and puts it back for the handler, only an I/O failure is returned
+func validateBody(c *gin.Context, document *openapi.Document, operation *openapi.Operation, strict bool, validationError *errors.ValidationError) (err error) {
+	if operation.RequestBody == nil || c.Request.Body == nil {
+		return
+	}
+	mediaType, isFound := operation.RequestBody.Content[codec.MIMEJSON]
+	if !isFound {
+		return
+	}
+
+	data, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return errors.ErrBadRequest
+	}
+	c.Request.Body = io.NopCloser(bytes.NewReader(data))
+
+	value, err := codec.DecodeValue(codec.MediaType(c.ContentType()), data)
+	if err != nil {
+		return nil
+	}
+	document.Validate(mediaType.Schema, value, strict, "", validationError)
+	return
+}
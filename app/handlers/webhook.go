@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"Technopark_DB_Project/app/models"
+	"Technopark_DB_Project/app/usecases"
+	"Technopark_DB_Project/pkg/errors"
+	"Technopark_DB_Project/pkg/validator"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WebhookHandler lets a forum owner manage webhooks. The owner is recognised by the nickname the caller passes,
+// the API has no authentication to check it against.
+type WebhookHandler struct {
+	ForumURL       string
+	WebhookUseCase usecases.WebhookUseCase
+}
+
+func CreateWebhookHandler(router *gin.RouterGroup, forumURL string, webhookUseCase usecases.WebhookUseCase) {
+	handler := &WebhookHandler{
+		ForumURL:       forumURL,
+		WebhookUseCase: webhookUseCase,
+	}
+
+	webhooks := router.Group(handler.ForumURL)
+	{
+		webhooks.POST("/:slug/webhooks", handler.CreateWebhook)
+		webhooks.GET("/:slug/webhooks", handler.GetWebhooks)
+		webhooks.DELETE("/:slug/webhooks/:id", handler.DeleteWebhook)
+		webhooks.GET("/:slug/webhooks/:id/deliveries", handler.GetDeliveries)
+	}
+}
+
+func (webhookHandler *WebhookHandler) CreateWebhook(c *gin.Context) {
+	slug := c.Param("slug")
+
+	webhook := new(models.Webhook)
+	if err := decodeBody(c, webhook); err != nil {
+		respondError(c, err)
+		return
+	}
+	if err := validator.ValidateWebhookData(webhook); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	err := webhookHandler.WebhookUseCase.Create(slug, webhook)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respond(c, http.StatusCreated, webhook)
+}
+
+func (webhookHandler *WebhookHandler) GetWebhooks(c *gin.Context) {
+	slug := c.Param("slug")
+	nickname := c.Query("nickname")
+
+	webhooks, err := webhookHandler.WebhookUseCase.GetByForum(slug, nickname)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respond(c, http.StatusOK, webhooks)
+}
+
+func (webhookHandler *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	slug := c.Param("slug")
+	nickname := c.Query("nickname")
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		respondError(c, errors.ErrBadRequest)
+		return
+	}
+
+	err = webhookHandler.WebhookUseCase.Delete(slug, id, nickname)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (webhookHandler *WebhookHandler) GetDeliveries(c *gin.Context) {
+	slug := c.Param("slug")
+	nickname := c.Query("nickname")
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		respondError(c, errors.ErrBadRequest)
+		return
+	}
+	limitStr := c.Query("limit")
+	limit := 100
+	if limitStr != "" {
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil {
+			respondError(c, errors.ErrBadRequest)
+			return
+		}
+	}
+	sinceStr := c.Query("since")
+	var since int64 = -1
+	if sinceStr != "" {
+		since, err = strconv.ParseInt(sinceStr, 10, 64)
+		if err != nil {
+			respondError(c, errors.ErrBadRequest)
+			return
+		}
+	}
+	descStr := c.Query("desc")
+	desc := false
+	if descStr != "" {
+		desc, err = strconv.ParseBool(descStr)
+		if err != nil {
+			respondError(c, errors.ErrBadRequest)
+			return
+		}
+	}
+
+	deliveries, err := webhookHandler.WebhookUseCase.GetDeliveries(slug, id, nickname, limit, since, desc)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respond(c, http.StatusOK, deliveries)
+}
@@ -0,0 +1,29 @@
+package models
+
+//easyjson:json
+type BatchNicknames struct {
+	Nicknames []string `json:"nicknames"`
+}
+
+//easyjson:json
+type BatchIDs struct {
+	IDs []int64 `json:"ids"`
+}
+
+//easyjson:json
+type UsersBatch struct {
+	Users    Users    `json:"users"`
+	NotFound []string `json:"notFound"`
+}
+
+//easyjson:json
+type ThreadsBatch struct {
+	Threads  Threads `json:"threads"`
+	NotFound []int64 `json:"notFound"`
+}
+
+//easyjson:json
+type PostsBatch struct {
+	Posts    Posts   `json:"posts"`
+	NotFound []int64 `json:"notFound"`
+}
@@ -0,0 +1,530 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package models
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson917759c2DecodeTechnoparkDBProjectAppModels(in *jlexer.Lexer, out *UsersBatch) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "users":
+			(out.Users).UnmarshalEasyJSON(in)
+		case "notFound":
+			if in.IsNull() {
+				in.Skip()
+				out.NotFound = nil
+			} else {
+				in.Delim('[')
+				if out.NotFound == nil {
+					if !in.IsDelim(']') {
+						out.NotFound = make([]string, 0, 4)
+					} else {
+						out.NotFound = []string{}
+					}
+				} else {
+					out.NotFound = (out.NotFound)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 string
+					v1 = string(in.String())
+					out.NotFound = append(out.NotFound, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson917759c2EncodeTechnoparkDBProjectAppModels(out *jwriter.Writer, in UsersBatch) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"users\":"
+		out.RawString(prefix[1:])
+		(in.Users).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"notFound\":"
+		out.RawString(prefix)
+		if in.NotFound == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.NotFound {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v3))
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v UsersBatch) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson917759c2EncodeTechnoparkDBProjectAppModels(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v UsersBatch) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson917759c2EncodeTechnoparkDBProjectAppModels(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *UsersBatch) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson917759c2DecodeTechnoparkDBProjectAppModels(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *UsersBatch) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson917759c2DecodeTechnoparkDBProjectAppModels(l, v)
+}
+func easyjson917759c2DecodeTechnoparkDBProjectAppModels1(in *jlexer.Lexer, out *ThreadsBatch) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "threads":
+			(out.Threads).UnmarshalEasyJSON(in)
+		case "notFound":
+			if in.IsNull() {
+				in.Skip()
+				out.NotFound = nil
+			} else {
+				in.Delim('[')
+				if out.NotFound == nil {
+					if !in.IsDelim(']') {
+						out.NotFound = make([]int64, 0, 8)
+					} else {
+						out.NotFound = []int64{}
+					}
+				} else {
+					out.NotFound = (out.NotFound)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v4 int64
+					v4 = int64(in.Int64())
+					out.NotFound = append(out.NotFound, v4)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson917759c2EncodeTechnoparkDBProjectAppModels1(out *jwriter.Writer, in ThreadsBatch) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"threads\":"
+		out.RawString(prefix[1:])
+		(in.Threads).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"notFound\":"
+		out.RawString(prefix)
+		if in.NotFound == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v5, v6 := range in.NotFound {
+				if v5 > 0 {
+					out.RawByte(',')
+				}
+				out.Int64(int64(v6))
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ThreadsBatch) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson917759c2EncodeTechnoparkDBProjectAppModels1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ThreadsBatch) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson917759c2EncodeTechnoparkDBProjectAppModels1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ThreadsBatch) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson917759c2DecodeTechnoparkDBProjectAppModels1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ThreadsBatch) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson917759c2DecodeTechnoparkDBProjectAppModels1(l, v)
+}
+func easyjson917759c2DecodeTechnoparkDBProjectAppModels2(in *jlexer.Lexer, out *PostsBatch) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "posts":
+			(out.Posts).UnmarshalEasyJSON(in)
+		case "notFound":
+			if in.IsNull() {
+				in.Skip()
+				out.NotFound = nil
+			} else {
+				in.Delim('[')
+				if out.NotFound == nil {
+					if !in.IsDelim(']') {
+						out.NotFound = make([]int64, 0, 8)
+					} else {
+						out.NotFound = []int64{}
+					}
+				} else {
+					out.NotFound = (out.NotFound)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v7 int64
+					v7 = int64(in.Int64())
+					out.NotFound = append(out.NotFound, v7)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson917759c2EncodeTechnoparkDBProjectAppModels2(out *jwriter.Writer, in PostsBatch) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"posts\":"
+		out.RawString(prefix[1:])
+		(in.Posts).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"notFound\":"
+		out.RawString(prefix)
+		if in.NotFound == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v8, v9 := range in.NotFound {
+				if v8 > 0 {
+					out.RawByte(',')
+				}
+				out.Int64(int64(v9))
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PostsBatch) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson917759c2EncodeTechnoparkDBProjectAppModels2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PostsBatch) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson917759c2EncodeTechnoparkDBProjectAppModels2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PostsBatch) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson917759c2DecodeTechnoparkDBProjectAppModels2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PostsBatch) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson917759c2DecodeTechnoparkDBProjectAppModels2(l, v)
+}
+func easyjson917759c2DecodeTechnoparkDBProjectAppModels3(in *jlexer.Lexer, out *BatchNicknames) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "nicknames":
+			if in.IsNull() {
+				in.Skip()
+				out.Nicknames = nil
+			} else {
+				in.Delim('[')
+				if out.Nicknames == nil {
+					if !in.IsDelim(']') {
+						out.Nicknames = make([]string, 0, 4)
+					} else {
+						out.Nicknames = []string{}
+					}
+				} else {
+					out.Nicknames = (out.Nicknames)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v10 string
+					v10 = string(in.String())
+					out.Nicknames = append(out.Nicknames, v10)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson917759c2EncodeTechnoparkDBProjectAppModels3(out *jwriter.Writer, in BatchNicknames) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"nicknames\":"
+		out.RawString(prefix[1:])
+		if in.Nicknames == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v11, v12 := range in.Nicknames {
+				if v11 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v12))
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v BatchNicknames) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson917759c2EncodeTechnoparkDBProjectAppModels3(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v BatchNicknames) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson917759c2EncodeTechnoparkDBProjectAppModels3(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *BatchNicknames) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson917759c2DecodeTechnoparkDBProjectAppModels3(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *BatchNicknames) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson917759c2DecodeTechnoparkDBProjectAppModels3(l, v)
+}
+func easyjson917759c2DecodeTechnoparkDBProjectAppModels4(in *jlexer.Lexer, out *BatchIDs) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "ids":
+			if in.IsNull() {
+				in.Skip()
+				out.IDs = nil
+			} else {
+				in.Delim('[')
+				if out.IDs == nil {
+					if !in.IsDelim(']') {
+						out.IDs = make([]int64, 0, 8)
+					} else {
+						out.IDs = []int64{}
+					}
+				} else {
+					out.IDs = (out.IDs)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v13 int64
+					v13 = int64(in.Int64())
+					out.IDs = append(out.IDs, v13)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson917759c2EncodeTechnoparkDBProjectAppModels4(out *jwriter.Writer, in BatchIDs) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"ids\":"
+		out.RawString(prefix[1:])
+		if in.IDs == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v14, v15 := range in.IDs {
+				if v14 > 0 {
+					out.RawByte(',')
+				}
+				out.Int64(int64(v15))
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v BatchIDs) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson917759c2EncodeTechnoparkDBProjectAppModels4(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v BatchIDs) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson917759c2EncodeTechnoparkDBProjectAppModels4(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *BatchIDs) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson917759c2DecodeTechnoparkDBProjectAppModels4(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *BatchIDs) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson917759c2DecodeTechnoparkDBProjectAppModels4(l, v)
+}
@@ -0,0 +1,19 @@
+package models
+
+import (
+	"time"
+
+	"github.com/mailru/easyjson"
+)
+
+//easyjson:json
+type Changes []Change
+
+//easyjson:json
+type Change struct {
+	ID        int64               `json:"id"`
+	Entity    string              `json:"entity"`
+	Operation string              `json:"operation"`
+	Data      easyjson.RawMessage `json:"data"`
+	Created   time.Time           `json:"created"`
+}
@@ -0,0 +1,24 @@
+package models
+
+// Envelope wraps every response of API v2. Data is set on success and, for conflicts, holds the existing entity.
+//
+//easyjson:json
+type Envelope struct {
+	Data  interface{} `json:"data,omitempty"`
+	Page  *Page       `json:"page,omitempty"`
+	Error *APIError   `json:"error,omitempty"`
+}
+
+//easyjson:json
+type Page struct {
+	Limit int `json:"limit"`
+	// Next is the cursor of the following page, it is empty on the last one
+	Next string `json:"next,omitempty"`
+}
+
+//easyjson:json
+type APIError struct {
+	Code    string       `json:"code"`
+	Message string       `json:"message"`
+	Fields  []FieldError `json:"fields,omitempty"`
+}
@@ -1,5 +1,11 @@
 package models
 
 type Error struct {
+	Message string       `json:"message"`
+	Fields  []FieldError `json:"fields,omitempty"`
+}
+
+type FieldError struct {
+	Field   string `json:"field"`
 	Message string `json:"message"`
 }
@@ -0,0 +1,17 @@
+package models
+
+const (
+	EventThreadCreated = "thread.created"
+	EventPostCreated   = "post.created"
+	EventPostUpdated   = "post.updated"
+	EventVoteCast      = "vote.cast"
+)
+
+//easyjson:json
+type Event struct {
+	Type   string  `json:"type"`
+	Forum  string  `json:"forum"`
+	Thread *Thread `json:"thread,omitempty"`
+	Post   *Post   `json:"post,omitempty"`
+	Vote   *Vote   `json:"vote,omitempty"`
+}
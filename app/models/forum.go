@@ -1,5 +1,7 @@
 package models
 
+type Forums []Forum
+
 type Forum struct {
 	Title   string `json:"title"`
 	User    string `json:"user"`
@@ -7,3 +9,16 @@ type Forum struct {
 	Posts   int64  `json:"posts"`
 	Threads int32  `json:"threads"`
 }
+
+// ForumExpansions are the related data paths a forum can be expanded with
+var ForumExpansions = []string{"user"}
+
+// ForumFull is a forum with the related data asked for
+type ForumFull struct {
+	Forum
+	Expanded *ForumExpanded `json:"expanded,omitempty"`
+}
+
+type ForumExpanded struct {
+	User *User `json:"user,omitempty"`
+}
@@ -17,7 +17,73 @@ var (
 	_ easyjson.Marshaler
 )
 
-func easyjsonC8d74561DecodeTechnoparkDBProjectAppModels(in *jlexer.Lexer, out *Forum) {
+func easyjsonC8d74561DecodeTechnoparkDBProjectAppModels(in *jlexer.Lexer, out *Forums) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(Forums, 0, 1)
+			} else {
+				*out = Forums{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v1 Forum
+			(v1).UnmarshalEasyJSON(in)
+			*out = append(*out, v1)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonC8d74561EncodeTechnoparkDBProjectAppModels(out *jwriter.Writer, in Forums) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v2, v3 := range in {
+			if v2 > 0 {
+				out.RawByte(',')
+			}
+			(v3).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Forums) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonC8d74561EncodeTechnoparkDBProjectAppModels(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Forums) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonC8d74561EncodeTechnoparkDBProjectAppModels(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Forums) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonC8d74561DecodeTechnoparkDBProjectAppModels(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Forums) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonC8d74561DecodeTechnoparkDBProjectAppModels(l, v)
+}
+func easyjsonC8d74561DecodeTechnoparkDBProjectAppModels1(in *jlexer.Lexer, out *ForumFull) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -36,6 +102,16 @@ func easyjsonC8d74561DecodeTechnoparkDBProjectAppModels(in *jlexer.Lexer, out *F
 			continue
 		}
 		switch key {
+		case "expanded":
+			if in.IsNull() {
+				in.Skip()
+				out.Expanded = nil
+			} else {
+				if out.Expanded == nil {
+					out.Expanded = new(ForumExpanded)
+				}
+				(*out.Expanded).UnmarshalEasyJSON(in)
+			}
 		case "title":
 			out.Title = string(in.String())
 		case "user":
@@ -56,7 +132,187 @@ func easyjsonC8d74561DecodeTechnoparkDBProjectAppModels(in *jlexer.Lexer, out *F
 		in.Consumed()
 	}
 }
-func easyjsonC8d74561EncodeTechnoparkDBProjectAppModels(out *jwriter.Writer, in Forum) {
+func easyjsonC8d74561EncodeTechnoparkDBProjectAppModels1(out *jwriter.Writer, in ForumFull) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.Expanded != nil {
+		const prefix string = ",\"expanded\":"
+		first = false
+		out.RawString(prefix[1:])
+		(*in.Expanded).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"title\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Title))
+	}
+	{
+		const prefix string = ",\"user\":"
+		out.RawString(prefix)
+		out.String(string(in.User))
+	}
+	{
+		const prefix string = ",\"slug\":"
+		out.RawString(prefix)
+		out.String(string(in.Slug))
+	}
+	{
+		const prefix string = ",\"posts\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Posts))
+	}
+	{
+		const prefix string = ",\"threads\":"
+		out.RawString(prefix)
+		out.Int32(int32(in.Threads))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ForumFull) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonC8d74561EncodeTechnoparkDBProjectAppModels1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ForumFull) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonC8d74561EncodeTechnoparkDBProjectAppModels1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ForumFull) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonC8d74561DecodeTechnoparkDBProjectAppModels1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ForumFull) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonC8d74561DecodeTechnoparkDBProjectAppModels1(l, v)
+}
+func easyjsonC8d74561DecodeTechnoparkDBProjectAppModels2(in *jlexer.Lexer, out *ForumExpanded) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "user":
+			if in.IsNull() {
+				in.Skip()
+				out.User = nil
+			} else {
+				if out.User == nil {
+					out.User = new(User)
+				}
+				(*out.User).UnmarshalEasyJSON(in)
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonC8d74561EncodeTechnoparkDBProjectAppModels2(out *jwriter.Writer, in ForumExpanded) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.User != nil {
+		const prefix string = ",\"user\":"
+		first = false
+		out.RawString(prefix[1:])
+		(*in.User).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ForumExpanded) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonC8d74561EncodeTechnoparkDBProjectAppModels2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ForumExpanded) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonC8d74561EncodeTechnoparkDBProjectAppModels2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ForumExpanded) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonC8d74561DecodeTechnoparkDBProjectAppModels2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ForumExpanded) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonC8d74561DecodeTechnoparkDBProjectAppModels2(l, v)
+}
+func easyjsonC8d74561DecodeTechnoparkDBProjectAppModels3(in *jlexer.Lexer, out *Forum) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "title":
+			out.Title = string(in.String())
+		case "user":
+			out.User = string(in.String())
+		case "slug":
+			out.Slug = string(in.String())
+		case "posts":
+			out.Posts = int64(in.Int64())
+		case "threads":
+			out.Threads = int32(in.Int32())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonC8d74561EncodeTechnoparkDBProjectAppModels3(out *jwriter.Writer, in Forum) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -91,23 +347,23 @@ func easyjsonC8d74561EncodeTechnoparkDBProjectAppModels(out *jwriter.Writer, in
 // MarshalJSON supports json.Marshaler interface
 func (v Forum) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonC8d74561EncodeTechnoparkDBProjectAppModels(&w, v)
+	easyjsonC8d74561EncodeTechnoparkDBProjectAppModels3(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v Forum) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonC8d74561EncodeTechnoparkDBProjectAppModels(w, v)
+	easyjsonC8d74561EncodeTechnoparkDBProjectAppModels3(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *Forum) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonC8d74561DecodeTechnoparkDBProjectAppModels(&r, v)
+	easyjsonC8d74561DecodeTechnoparkDBProjectAppModels3(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *Forum) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonC8d74561DecodeTechnoparkDBProjectAppModels(l, v)
+	easyjsonC8d74561DecodeTechnoparkDBProjectAppModels3(l, v)
 }
@@ -0,0 +1,43 @@
+package models
+
+import "github.com/mailru/easyjson"
+
+const (
+	ImportUser   = "user"
+	ImportForum  = "forum"
+	ImportThread = "thread"
+	ImportPost   = "post"
+)
+
+//easyjson:json
+type ImportRecord struct {
+	Type string              `json:"type"`
+	Data easyjson.RawMessage `json:"data"`
+}
+
+//easyjson:json
+type ImportResult struct {
+	Users   int64         `json:"users"`
+	Forums  int64         `json:"forums"`
+	Threads int64         `json:"threads"`
+	Posts   int64         `json:"posts"`
+	Errors  []ImportError `json:"errors"`
+}
+
+//easyjson:json
+type ImportError struct {
+	Line    int64        `json:"line"`
+	Type    string       `json:"type,omitempty"`
+	Message string       `json:"message"`
+	Fields  []FieldError `json:"fields,omitempty"`
+}
+
+// ImportedRecord is a decoded record along with its line number in the source, only the field of Type is set
+type ImportedRecord struct {
+	Line   int64
+	Type   string
+	User   *User
+	Forum  *Forum
+	Thread *Thread
+	Post   *Post
+}
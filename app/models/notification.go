@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+const (
+	NotificationThreadCreated = "thread"
+	NotificationPostCreated   = "post"
+	NotificationReply         = "reply"
+)
+
+//easyjson:json
+type Notifications []Notification
+
+//easyjson:json
+type Notification struct {
+	ID      int64     `json:"id"`
+	Kind    string    `json:"kind"`
+	Author  string    `json:"author"`
+	Forum   string    `json:"forum"`
+	Thread  int64     `json:"thread"`
+	Post    int64     `json:"post,omitempty"`
+	IsRead  bool      `json:"isRead"`
+	Created time.Time `json:"created"`
+}
+
+//easyjson:json
+type NotificationsRead struct {
+	IDs []int64 `json:"ids"`
+}
@@ -0,0 +1,267 @@
+package pb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative models.proto service.proto
+
+import (
+	"Technopark_DB_Project/app/models"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// ToMessage converts a model into its protobuf counterpart, nil is returned for models without a schema.
+// Messages have no place for nested expanded data, so models carrying it are left to JSON as well.
+func ToMessage(value interface{}) proto.Message {
+	switch model := value.(type) {
+	case *models.User:
+		return userToMessage(model)
+	case *models.Users:
+		message := &Users{Users: make([]*User, 0, len(*model))}
+		for i := range *model {
+			message.Users = append(message.Users, userToMessage(&(*model)[i]))
+		}
+		return message
+	case *models.UserUpdate:
+		return &UserUpdate{Fullname: model.Fullname, About: model.About, Email: model.Email}
+	case *models.Forum:
+		return forumToMessage(model)
+	case *models.ForumFull:
+		if model.Expanded == nil {
+			return forumToMessage(&model.Forum)
+		}
+	case *models.Thread:
+		return threadToMessage(model)
+	case *models.ThreadFull:
+		if model.Expanded == nil {
+			return threadToMessage(&model.Thread)
+		}
+	case *models.Threads:
+		message := &Threads{Threads: make([]*Thread, 0, len(*model))}
+		for i := range *model {
+			message.Threads = append(message.Threads, threadToMessage(&(*model)[i]))
+		}
+		return message
+	case *models.ThreadUpdate:
+		return &ThreadUpdate{Title: model.Title, Message: model.Message}
+	case *models.Post:
+		return postToMessage(model)
+	case *models.Posts:
+		message := &Posts{Posts: make([]*Post, 0, len(*model))}
+		for i := range *model {
+			message.Posts = append(message.Posts, postToMessage(&(*model)[i]))
+		}
+		return message
+	case *models.PostUpdate:
+		return &PostUpdate{Message: model.Message}
+	case *models.PostFull:
+		if model.Parent != nil || (model.Thread != nil && model.Thread.Expanded != nil) || (model.Forum != nil && model.Forum.Expanded != nil) {
+			break
+		}
+		message := &PostFull{Post: postToMessage(model.Post)}
+		if model.Author != nil {
+			message.Author = userToMessage(model.Author)
+		}
+		if model.Thread != nil {
+			message.Thread = threadToMessage(&model.Thread.Thread)
+		}
+		if model.Forum != nil {
+			message.Forum = forumToMessage(&model.Forum.Forum)
+		}
+		return message
+	case *models.Vote:
+		return voteToMessage(model)
+	case *models.Votes:
+		message := &Votes{Votes: make([]*Vote, 0, len(*model))}
+		for i := range *model {
+			message.Votes = append(message.Votes, voteToMessage(&(*model)[i]))
+		}
+		return message
+	case *models.Status:
+		return &Status{User: model.User, Forum: model.Forum, Thread: model.Thread, Post: model.Post}
+	case *models.Error:
+		message := &Error{Message: model.Message}
+		for _, field := range model.Fields {
+			message.Fields = append(message.Fields, &FieldError{Field: field.Field, Message: field.Message})
+		}
+		return message
+	}
+	return nil
+}
+
+// NewMessage returns an empty protobuf message a model can be decoded from, nil is returned for models without a schema
+func NewMessage(value interface{}) proto.Message {
+	switch value.(type) {
+	case *models.User:
+		return new(User)
+	case *models.UserUpdate:
+		return new(UserUpdate)
+	case *models.Forum:
+		return new(Forum)
+	case *models.Thread:
+		return new(Thread)
+	case *models.ThreadUpdate:
+		return new(ThreadUpdate)
+	case *models.Post:
+		return new(Post)
+	case *models.Posts:
+		return new(Posts)
+	case *models.PostUpdate:
+		return new(PostUpdate)
+	case *models.Vote:
+		return new(Vote)
+	case *models.Status:
+		return new(Status)
+	case *models.Error:
+		return new(Error)
+	}
+	return nil
+}
+
+// FromMessage fills a model from a message created by NewMessage
+func FromMessage(message proto.Message, value interface{}) (err error) {
+	switch model := value.(type) {
+	case *models.User:
+		*model = userFromMessage(message.(*User))
+	case *models.UserUpdate:
+		userUpdate := message.(*UserUpdate)
+		*model = models.UserUpdate{Fullname: userUpdate.Fullname, About: userUpdate.About, Email: userUpdate.Email}
+	case *models.Forum:
+		*model = forumFromMessage(message.(*Forum))
+	case *models.Thread:
+		*model, err = threadFromMessage(message.(*Thread))
+	case *models.ThreadUpdate:
+		threadUpdate := message.(*ThreadUpdate)
+		*model = models.ThreadUpdate{Title: threadUpdate.Title, Message: threadUpdate.Message}
+	case *models.Post:
+		*model = postFromMessage(message.(*Post))
+	case *models.Posts:
+		posts := message.(*Posts)
+		*model = make(models.Posts, 0, len(posts.Posts))
+		for _, post := range posts.Posts {
+			*model = append(*model, postFromMessage(post))
+		}
+	case *models.PostUpdate:
+		*model = models.PostUpdate{Message: message.(*PostUpdate).Message}
+	case *models.Vote:
+		vote := message.(*Vote)
+		*model = models.Vote{Nickname: vote.Nickname, Thread: vote.Thread, Voice: vote.Voice}
+	case *models.Status:
+		status := message.(*Status)
+		*model = models.Status{User: status.User, Forum: status.Forum, Thread: status.Thread, Post: status.Post}
+	case *models.Error:
+		errorMessage := message.(*Error)
+		*model = models.Error{Message: errorMessage.Message}
+		for _, field := range errorMessage.Fields {
+			model.Fields = append(model.Fields, models.FieldError{Field: field.Field, Message: field.Message})
+		}
+	}
+	return
+}
+
+func userToMessage(user *models.User) *User {
+	return &User{
+		Nickname:   user.Nickname,
+		Fullname:   user.Fullname,
+		About:      user.About,
+		Email:      user.Email,
+		Reputation: user.Reputation,
+	}
+}
+
+func userFromMessage(user *User) models.User {
+	return models.User{
+		Nickname:   user.Nickname,
+		Fullname:   user.Fullname,
+		About:      user.About,
+		Email:      user.Email,
+		Reputation: user.Reputation,
+	}
+}
+
+func forumToMessage(forum *models.Forum) *Forum {
+	return &Forum{
+		Title:   forum.Title,
+		User:    forum.User,
+		Slug:    forum.Slug,
+		Posts:   forum.Posts,
+		Threads: forum.Threads,
+	}
+}
+
+func forumFromMessage(forum *Forum) models.Forum {
+	return models.Forum{
+		Title:   forum.Title,
+		User:    forum.User,
+		Slug:    forum.Slug,
+		Posts:   forum.Posts,
+		Threads: forum.Threads,
+	}
+}
+
+func threadToMessage(thread *models.Thread) *Thread {
+	message := &Thread{
+		Id:      thread.ID,
+		Title:   thread.Title,
+		Author:  thread.Author,
+		Forum:   thread.Forum,
+		Message: thread.Message,
+		Votes:   thread.Votes,
+		Slug:    thread.Slug,
+	}
+	if !thread.Created.IsZero() {
+		message.Created = thread.Created.Format(time.RFC3339Nano)
+	}
+	return message
+}
+
+func threadFromMessage(thread *Thread) (model models.Thread, err error) {
+	model = models.Thread{
+		ID:      thread.Id,
+		Title:   thread.Title,
+		Author:  thread.Author,
+		Forum:   thread.Forum,
+		Message: thread.Message,
+		Votes:   thread.Votes,
+		Slug:    thread.Slug,
+	}
+	if thread.Created != "" {
+		model.Created, err = time.Parse(time.RFC3339Nano, thread.Created)
+	}
+	return
+}
+
+func postToMessage(post *models.Post) *Post {
+	return &Post{
+		Id:        post.ID,
+		Parent:    post.Parent,
+		Author:    post.Author,
+		Message:   post.Message,
+		IsEdited:  post.IsEdited,
+		Forum:     post.Forum,
+		Thread:    post.Thread,
+		Created:   post.Created,
+		Votes:     post.Votes,
+		Reactions: post.Reactions,
+		Mentions:  post.Mentions,
+	}
+}
+
+func postFromMessage(post *Post) models.Post {
+	return models.Post{
+		ID:        post.Id,
+		Parent:    post.Parent,
+		Author:    post.Author,
+		Message:   post.Message,
+		IsEdited:  post.IsEdited,
+		Forum:     post.Forum,
+		Thread:    post.Thread,
+		Created:   post.Created,
+		Votes:     post.Votes,
+		Reactions: post.Reactions,
+		Mentions:  post.Mentions,
+	}
+}
+
+func voteToMessage(vote *models.Vote) *Vote {
+	return &Vote{Nickname: vote.Nickname, Thread: vote.Thread, Voice: vote.Voice}
+}
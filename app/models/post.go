@@ -13,6 +13,10 @@ type Post struct {
 	Forum    string `json:"forum"`
 	Thread   int64  `json:"thread"`
 	Created  string `json:"created"`
+	Votes    int32  `json:"votes"`
+
+	Reactions map[string]int32 `json:"reactions,omitempty"`
+	Mentions  []string         `json:"mentions,omitempty"`
 }
 
 //easyjson:json
@@ -20,10 +24,26 @@ type PostUpdate struct {
 	Message string `json:"message"`
 }
 
+// PostExpansions are the related data paths a post can be expanded with
+var PostExpansions = []string{
+	"author",
+	"thread", "thread.author", "thread.forum", "thread.forum.user",
+	"forum", "forum.user",
+	"parent", "parent.author",
+}
+
+// PostRelated maps the values of the related parameter to expansion paths
+var PostRelated = map[string]string{
+	"user":   "author",
+	"thread": "thread",
+	"forum":  "forum",
+}
+
 //easyjson:json
 type PostFull struct {
-	Post   *Post   `json:"post"`
-	Author *User   `json:"author,omitempty"`
-	Thread *Thread `json:"thread,omitempty"`
-	Forum  *Forum  `json:"forum,omitempty"`
+	Post   *Post       `json:"post"`
+	Author *User       `json:"author,omitempty"`
+	Thread *ThreadFull `json:"thread,omitempty"`
+	Forum  *ForumFull  `json:"forum,omitempty"`
+	Parent *PostFull   `json:"parent,omitempty"`
 }
@@ -194,7 +194,7 @@ func easyjson5a72dc82DecodeTechnoparkDBProjectAppModels2(in *jlexer.Lexer, out *
 				out.Thread = nil
 			} else {
 				if out.Thread == nil {
-					out.Thread = new(Thread)
+					out.Thread = new(ThreadFull)
 				}
 				(*out.Thread).UnmarshalEasyJSON(in)
 			}
@@ -204,10 +204,20 @@ func easyjson5a72dc82DecodeTechnoparkDBProjectAppModels2(in *jlexer.Lexer, out *
 				out.Forum = nil
 			} else {
 				if out.Forum == nil {
-					out.Forum = new(Forum)
+					out.Forum = new(ForumFull)
 				}
 				(*out.Forum).UnmarshalEasyJSON(in)
 			}
+		case "parent":
+			if in.IsNull() {
+				in.Skip()
+				out.Parent = nil
+			} else {
+				if out.Parent == nil {
+					out.Parent = new(PostFull)
+				}
+				(*out.Parent).UnmarshalEasyJSON(in)
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -246,6 +256,11 @@ func easyjson5a72dc82EncodeTechnoparkDBProjectAppModels2(out *jwriter.Writer, in
 		out.RawString(prefix)
 		(*in.Forum).MarshalEasyJSON(out)
 	}
+	if in.Parent != nil {
+		const prefix string = ",\"parent\":"
+		out.RawString(prefix)
+		(*in.Parent).MarshalEasyJSON(out)
+	}
 	out.RawByte('}')
 }
 
@@ -307,6 +322,51 @@ func easyjson5a72dc82DecodeTechnoparkDBProjectAppModels3(in *jlexer.Lexer, out *
 			out.Thread = int64(in.Int64())
 		case "created":
 			out.Created = string(in.String())
+		case "votes":
+			out.Votes = int32(in.Int32())
+		case "reactions":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				if !in.IsDelim('}') {
+					out.Reactions = make(map[string]int32)
+				} else {
+					out.Reactions = nil
+				}
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v4 int32
+					v4 = int32(in.Int32())
+					(out.Reactions)[key] = v4
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		case "mentions":
+			if in.IsNull() {
+				in.Skip()
+				out.Mentions = nil
+			} else {
+				in.Delim('[')
+				if out.Mentions == nil {
+					if !in.IsDelim(']') {
+						out.Mentions = make([]string, 0, 4)
+					} else {
+						out.Mentions = []string{}
+					}
+				} else {
+					out.Mentions = (out.Mentions)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v5 string
+					v5 = string(in.String())
+					out.Mentions = append(out.Mentions, v5)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -361,6 +421,44 @@ func easyjson5a72dc82EncodeTechnoparkDBProjectAppModels3(out *jwriter.Writer, in
 		out.RawString(prefix)
 		out.String(string(in.Created))
 	}
+	{
+		const prefix string = ",\"votes\":"
+		out.RawString(prefix)
+		out.Int32(int32(in.Votes))
+	}
+	if len(in.Reactions) != 0 {
+		const prefix string = ",\"reactions\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('{')
+			v6First := true
+			for v6Name, v6Value := range in.Reactions {
+				if v6First {
+					v6First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v6Name))
+				out.RawByte(':')
+				out.Int32(int32(v6Value))
+			}
+			out.RawByte('}')
+		}
+	}
+	if len(in.Mentions) != 0 {
+		const prefix string = ",\"mentions\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v7, v8 := range in.Mentions {
+				if v7 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v8))
+			}
+			out.RawByte(']')
+		}
+	}
 	out.RawByte('}')
 }
 
@@ -0,0 +1,10 @@
+package models
+
+//easyjson:json
+type Reactions []Reaction
+
+//easyjson:json
+type Reaction struct {
+	Nickname string `json:"nickname"`
+	Reaction string `json:"reaction"`
+}
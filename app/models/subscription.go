@@ -0,0 +1,8 @@
+package models
+
+//easyjson:json
+type Subscription struct {
+	Nickname string `json:"nickname"`
+	Forum    string `json:"forum,omitempty"`
+	Thread   int64  `json:"thread,omitempty"`
+}
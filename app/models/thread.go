@@ -17,6 +17,23 @@ type Thread struct {
 	Created time.Time `json:"created"`
 }
 
+// ThreadExpansions are the related data paths a thread can be expanded with
+var ThreadExpansions = []string{"author", "forum", "forum.user"}
+
+// ThreadFull is a thread with the related data asked for, its own fields keep the nickname and the slug
+//
+//easyjson:json
+type ThreadFull struct {
+	Thread
+	Expanded *ThreadExpanded `json:"expanded,omitempty"`
+}
+
+//easyjson:json
+type ThreadExpanded struct {
+	Author *User      `json:"author,omitempty"`
+	Forum  *ForumFull `json:"forum,omitempty"`
+}
+
 //easyjson:json
 type ThreadUpdate struct {
 	Title   string `json:"title"`
@@ -156,7 +156,240 @@ func (v *ThreadUpdate) UnmarshalJSON(data []byte) error {
 func (v *ThreadUpdate) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjson2d00218DecodeTechnoparkDBProjectAppModels1(l, v)
 }
-func easyjson2d00218DecodeTechnoparkDBProjectAppModels2(in *jlexer.Lexer, out *Thread) {
+func easyjson2d00218DecodeTechnoparkDBProjectAppModels2(in *jlexer.Lexer, out *ThreadFull) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "expanded":
+			if in.IsNull() {
+				in.Skip()
+				out.Expanded = nil
+			} else {
+				if out.Expanded == nil {
+					out.Expanded = new(ThreadExpanded)
+				}
+				(*out.Expanded).UnmarshalEasyJSON(in)
+			}
+		case "id":
+			out.ID = int64(in.Int64())
+		case "title":
+			out.Title = string(in.String())
+		case "author":
+			out.Author = string(in.String())
+		case "forum":
+			out.Forum = string(in.String())
+		case "message":
+			out.Message = string(in.String())
+		case "votes":
+			out.Votes = int32(in.Int32())
+		case "slug":
+			out.Slug = string(in.String())
+		case "created":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Created).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson2d00218EncodeTechnoparkDBProjectAppModels2(out *jwriter.Writer, in ThreadFull) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.Expanded != nil {
+		const prefix string = ",\"expanded\":"
+		first = false
+		out.RawString(prefix[1:])
+		(*in.Expanded).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"id\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.ID))
+	}
+	{
+		const prefix string = ",\"title\":"
+		out.RawString(prefix)
+		out.String(string(in.Title))
+	}
+	{
+		const prefix string = ",\"author\":"
+		out.RawString(prefix)
+		out.String(string(in.Author))
+	}
+	{
+		const prefix string = ",\"forum\":"
+		out.RawString(prefix)
+		out.String(string(in.Forum))
+	}
+	{
+		const prefix string = ",\"message\":"
+		out.RawString(prefix)
+		out.String(string(in.Message))
+	}
+	{
+		const prefix string = ",\"votes\":"
+		out.RawString(prefix)
+		out.Int32(int32(in.Votes))
+	}
+	{
+		const prefix string = ",\"slug\":"
+		out.RawString(prefix)
+		out.String(string(in.Slug))
+	}
+	{
+		const prefix string = ",\"created\":"
+		out.RawString(prefix)
+		out.Raw((in.Created).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ThreadFull) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson2d00218EncodeTechnoparkDBProjectAppModels2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ThreadFull) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson2d00218EncodeTechnoparkDBProjectAppModels2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ThreadFull) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson2d00218DecodeTechnoparkDBProjectAppModels2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ThreadFull) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson2d00218DecodeTechnoparkDBProjectAppModels2(l, v)
+}
+func easyjson2d00218DecodeTechnoparkDBProjectAppModels3(in *jlexer.Lexer, out *ThreadExpanded) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "author":
+			if in.IsNull() {
+				in.Skip()
+				out.Author = nil
+			} else {
+				if out.Author == nil {
+					out.Author = new(User)
+				}
+				(*out.Author).UnmarshalEasyJSON(in)
+			}
+		case "forum":
+			if in.IsNull() {
+				in.Skip()
+				out.Forum = nil
+			} else {
+				if out.Forum == nil {
+					out.Forum = new(ForumFull)
+				}
+				(*out.Forum).UnmarshalEasyJSON(in)
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson2d00218EncodeTechnoparkDBProjectAppModels3(out *jwriter.Writer, in ThreadExpanded) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.Author != nil {
+		const prefix string = ",\"author\":"
+		first = false
+		out.RawString(prefix[1:])
+		(*in.Author).MarshalEasyJSON(out)
+	}
+	if in.Forum != nil {
+		const prefix string = ",\"forum\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		(*in.Forum).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ThreadExpanded) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson2d00218EncodeTechnoparkDBProjectAppModels3(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ThreadExpanded) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson2d00218EncodeTechnoparkDBProjectAppModels3(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ThreadExpanded) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson2d00218DecodeTechnoparkDBProjectAppModels3(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ThreadExpanded) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson2d00218DecodeTechnoparkDBProjectAppModels3(l, v)
+}
+func easyjson2d00218DecodeTechnoparkDBProjectAppModels4(in *jlexer.Lexer, out *Thread) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -203,7 +436,7 @@ func easyjson2d00218DecodeTechnoparkDBProjectAppModels2(in *jlexer.Lexer, out *T
 		in.Consumed()
 	}
 }
-func easyjson2d00218EncodeTechnoparkDBProjectAppModels2(out *jwriter.Writer, in Thread) {
+func easyjson2d00218EncodeTechnoparkDBProjectAppModels4(out *jwriter.Writer, in Thread) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -253,23 +486,23 @@ func easyjson2d00218EncodeTechnoparkDBProjectAppModels2(out *jwriter.Writer, in
 // MarshalJSON supports json.Marshaler interface
 func (v Thread) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson2d00218EncodeTechnoparkDBProjectAppModels2(&w, v)
+	easyjson2d00218EncodeTechnoparkDBProjectAppModels4(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v Thread) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson2d00218EncodeTechnoparkDBProjectAppModels2(w, v)
+	easyjson2d00218EncodeTechnoparkDBProjectAppModels4(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *Thread) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson2d00218DecodeTechnoparkDBProjectAppModels2(&r, v)
+	easyjson2d00218DecodeTechnoparkDBProjectAppModels4(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *Thread) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson2d00218DecodeTechnoparkDBProjectAppModels2(l, v)
+	easyjson2d00218DecodeTechnoparkDBProjectAppModels4(l, v)
 }
@@ -5,10 +5,11 @@ type Users []User
 
 //easyjson:json
 type User struct {
-	Nickname string `json:"nickname"`
-	Fullname string `json:"fullname"`
-	About    string `json:"about"`
-	Email    string `json:"email"`
+	Nickname   string `json:"nickname"`
+	Fullname   string `json:"fullname"`
+	About      string `json:"about"`
+	Email      string `json:"email"`
+	Reputation int32  `json:"reputation"`
 }
 
 //easyjson:json
@@ -26,7 +26,7 @@ func easyjson9e1087fdDecodeTechnoparkDBProjectAppModels(in *jlexer.Lexer, out *U
 		in.Delim('[')
 		if *out == nil {
 			if !in.IsDelim(']') {
-				*out = make(Users, 0, 1)
+				*out = make(Users, 0, 0)
 			} else {
 				*out = Users{}
 			}
@@ -190,6 +190,8 @@ func easyjson9e1087fdDecodeTechnoparkDBProjectAppModels2(in *jlexer.Lexer, out *
 			out.About = string(in.String())
 		case "email":
 			out.Email = string(in.String())
+		case "reputation":
+			out.Reputation = int32(in.Int32())
 		default:
 			in.SkipRecursive()
 		}
@@ -224,6 +226,11 @@ func easyjson9e1087fdEncodeTechnoparkDBProjectAppModels2(out *jwriter.Writer, in
 		out.RawString(prefix)
 		out.String(string(in.Email))
 	}
+	{
+		const prefix string = ",\"reputation\":"
+		out.RawString(prefix)
+		out.Int32(int32(in.Reputation))
+	}
 	out.RawByte('}')
 }
 
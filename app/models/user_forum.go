@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+//easyjson:json
+type UserForums []UserForum
+
+//easyjson:json
+type UserForum struct {
+	Forum        string    `json:"forum"`
+	Title        string    `json:"title"`
+	Posts        int64     `json:"posts"`
+	Threads      int32     `json:"threads"`
+	LastActivity time.Time `json:"lastActivity"`
+}
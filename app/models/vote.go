@@ -5,7 +5,11 @@ const (
 	Like    = iota
 )
 
+//easyjson:json
+type Votes []Vote
+
 type Vote struct {
 	Nickname string `json:"nickname"`
+	Thread   int64  `json:"thread,omitempty"`
 	Voice    int32  `json:"voice"`
 }
@@ -17,7 +17,73 @@ var (
 	_ easyjson.Marshaler
 )
 
-func easyjsonE3ecfa40DecodeTechnoparkDBProjectAppModels(in *jlexer.Lexer, out *Vote) {
+func easyjsonE3ecfa40DecodeTechnoparkDBProjectAppModels(in *jlexer.Lexer, out *Votes) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(Votes, 0, 2)
+			} else {
+				*out = Votes{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v1 Vote
+			(v1).UnmarshalEasyJSON(in)
+			*out = append(*out, v1)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonE3ecfa40EncodeTechnoparkDBProjectAppModels(out *jwriter.Writer, in Votes) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v2, v3 := range in {
+			if v2 > 0 {
+				out.RawByte(',')
+			}
+			(v3).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Votes) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonE3ecfa40EncodeTechnoparkDBProjectAppModels(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Votes) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonE3ecfa40EncodeTechnoparkDBProjectAppModels(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Votes) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonE3ecfa40DecodeTechnoparkDBProjectAppModels(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Votes) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonE3ecfa40DecodeTechnoparkDBProjectAppModels(l, v)
+}
+func easyjsonE3ecfa40DecodeTechnoparkDBProjectAppModels1(in *jlexer.Lexer, out *Vote) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -38,6 +104,8 @@ func easyjsonE3ecfa40DecodeTechnoparkDBProjectAppModels(in *jlexer.Lexer, out *V
 		switch key {
 		case "nickname":
 			out.Nickname = string(in.String())
+		case "thread":
+			out.Thread = int64(in.Int64())
 		case "voice":
 			out.Voice = int32(in.Int32())
 		default:
@@ -50,7 +118,7 @@ func easyjsonE3ecfa40DecodeTechnoparkDBProjectAppModels(in *jlexer.Lexer, out *V
 		in.Consumed()
 	}
 }
-func easyjsonE3ecfa40EncodeTechnoparkDBProjectAppModels(out *jwriter.Writer, in Vote) {
+func easyjsonE3ecfa40EncodeTechnoparkDBProjectAppModels1(out *jwriter.Writer, in Vote) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -59,6 +127,11 @@ func easyjsonE3ecfa40EncodeTechnoparkDBProjectAppModels(out *jwriter.Writer, in
 		out.RawString(prefix[1:])
 		out.String(string(in.Nickname))
 	}
+	if in.Thread != 0 {
+		const prefix string = ",\"thread\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Thread))
+	}
 	{
 		const prefix string = ",\"voice\":"
 		out.RawString(prefix)
@@ -70,23 +143,23 @@ func easyjsonE3ecfa40EncodeTechnoparkDBProjectAppModels(out *jwriter.Writer, in
 // MarshalJSON supports json.Marshaler interface
 func (v Vote) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonE3ecfa40EncodeTechnoparkDBProjectAppModels(&w, v)
+	easyjsonE3ecfa40EncodeTechnoparkDBProjectAppModels1(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v Vote) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonE3ecfa40EncodeTechnoparkDBProjectAppModels(w, v)
+	easyjsonE3ecfa40EncodeTechnoparkDBProjectAppModels1(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *Vote) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonE3ecfa40DecodeTechnoparkDBProjectAppModels(&r, v)
+	easyjsonE3ecfa40DecodeTechnoparkDBProjectAppModels1(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *Vote) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonE3ecfa40DecodeTechnoparkDBProjectAppModels(l, v)
+	easyjsonE3ecfa40DecodeTechnoparkDBProjectAppModels1(l, v)
 }
@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+//easyjson:json
+type Webhooks []Webhook
+
+//easyjson:json
+type Webhook struct {
+	ID       int64     `json:"id"`
+	Forum    string    `json:"forum"`
+	Nickname string    `json:"nickname,omitempty"`
+	URL      string    `json:"url"`
+	Events   []string  `json:"events"`
+	Secret   string    `json:"secret,omitempty"`
+	Created  time.Time `json:"created"`
+}
+
+//easyjson:json
+type WebhookDeliveries []WebhookDelivery
+
+//easyjson:json
+type WebhookDelivery struct {
+	ID         int64     `json:"id"`
+	Webhook    int64     `json:"webhook"`
+	Message    int64     `json:"message"`
+	Event      string    `json:"event"`
+	Attempt    int32     `json:"attempt"`
+	StatusCode int32     `json:"statusCode,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	Duration   int64     `json:"duration"`
+	Created    time.Time `json:"created"`
+}
+
+// WebhookMessage is an outbox entry claimed by the delivery worker
+type WebhookMessage struct {
+	ID       int64
+	Webhook  int64
+	URL      string
+	Secret   string
+	Event    string
+	Payload  []byte
+	Attempts int32
+}
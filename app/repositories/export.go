@@ -0,0 +1,7 @@
+package repositories
+
+import "Technopark_DB_Project/app/models"
+
+type ExportRepository interface {
+	ExportForum(slug string, onThread func(thread *models.Thread) error, onPost func(post *models.Post, depth int) error) (err error)
+}
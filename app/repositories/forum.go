@@ -5,6 +5,10 @@ import "Technopark_DB_Project/app/models"
 type ForumRepository interface {
 	Create(forum *models.Forum) (err error)
 	GetBySlug(slug string) (forum *models.Forum, err error)
+	GetBySlugs(slugs []string) (forums *[]models.Forum, err error)
 	GetUsers(slug string, limit int, since string, desc bool) (users *[]models.User, err error)
+	GetUsersByReputation(slug string, limit int, since string, desc bool) (users *[]models.User, err error)
+	GetByUser(nickname string, limit int, since string, desc bool) (forums *[]models.UserForum, err error)
 	GetThreads(slug string, limit int, since string, desc bool) (threads *[]models.Thread, err error)
+	IterateThreads(slug string, limit int, since string, desc bool, onThread func(thread *models.Thread) error) (err error)
 }
@@ -0,0 +1,8 @@
+package repositories
+
+import "Technopark_DB_Project/app/models"
+
+type ImportRepository interface {
+	// Import reads the records with next until it returns io.EOF, any other error aborts the import
+	Import(next func() (record *models.ImportedRecord, err error)) (result *models.ImportResult, err error)
+}
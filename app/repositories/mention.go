@@ -0,0 +1,8 @@
+package repositories
+
+import "Technopark_DB_Project/app/models"
+
+type MentionRepository interface {
+	GetByPosts(postIDs []int64) (mentions map[int64][]string, err error)
+	GetPostsByUser(nickname string, limit int, since int64, desc bool) (posts *[]models.Post, err error)
+}
@@ -0,0 +1,8 @@
+package repositories
+
+import "Technopark_DB_Project/app/models"
+
+type NotificationRepository interface {
+	GetByUser(nickname, kind string, limit int, since int64, unreadOnly, desc bool) (notifications *[]models.Notification, err error)
+	MarkAsRead(nickname string, ids []int64) (err error)
+}
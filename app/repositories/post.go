@@ -4,5 +4,8 @@ import "Technopark_DB_Project/app/models"
 
 type PostRepository interface {
 	GetByID(id int64) (post *models.Post, err error)
+	GetByIDs(ids []int64) (posts *[]models.Post, err error)
+	GetWithRelated(id int64, withThread, withForum, withParent bool) (post *models.Post, thread *models.Thread, forum *models.Forum, parent *models.Post, err error)
 	Update(post *models.Post) (err error)
+	GetByAuthor(nickname, forum string, limit int, since int64, desc bool) (posts *[]models.Post, err error)
 }
@@ -0,0 +1,10 @@
+package repositories
+
+import "Technopark_DB_Project/app/models"
+
+type ReactionRepository interface {
+	Add(postID int64, reaction *models.Reaction) (err error)
+	Delete(postID int64, reaction *models.Reaction) (err error)
+	GetByPost(postID int64, limit int, sinceNickname, sinceReaction string, desc bool) (reactions *[]models.Reaction, err error)
+	GetCounts(postIDs []int64) (counts map[int64]map[string]int32, err error)
+}
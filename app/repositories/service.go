@@ -5,4 +5,5 @@ import "Technopark_DB_Project/app/models"
 type ServiceRepository interface {
 	Clear() (err error)
 	GetStatus() (status *models.Status, err error)
+	GetChanges(after int64, limit int) (changes *[]models.Change, err error)
 }
@@ -0,0 +1,62 @@
+package stores
+
+import (
+	"Technopark_DB_Project/app/models"
+	"Technopark_DB_Project/app/repositories"
+	"time"
+
+	"github.com/jackc/pgx"
+	_ "github.com/lib/pq"
+)
+
+type ExportStore struct {
+	db *pgx.ConnPool
+}
+
+func CreateExportRepository(db *pgx.ConnPool) repositories.ExportRepository {
+	return &ExportStore{db: db}
+}
+
+func (exportStore *ExportStore) ExportForum(slug string, onThread func(thread *models.Thread) error, onPost func(post *models.Post, depth int) error) (err error) {
+	// Threads and posts come in one ordered stream: every thread is followed by its posts in tree order,
+	// rows are handed out as they arrive, so the forum is never held in memory as a whole
+	resultRows, err := exportStore.db.Query("SELECT 0 AS kind, t.created AS thread_created, t.id AS thread_id, "+
+		"ARRAY []::bigint[] AS path, t.id, 0, t.title, t.author, t.forum, t.message, t.votes, t.slug, FALSE, t.created "+
+		"FROM threads AS t WHERE t.forum = $1 "+
+		"UNION ALL "+
+		"SELECT 1, t.created, t.id, p.path, p.id, COALESCE(p.parent, 0), '', p.author, p.forum, p.message, p.votes, '', "+
+		"p.is_edited, p.created "+
+		"FROM posts AS p JOIN threads AS t ON t.id = p.thread WHERE t.forum = $1 "+
+		"ORDER BY thread_created, thread_id, kind, path;", slug)
+	if err != nil {
+		return
+	}
+	defer resultRows.Close()
+
+	for resultRows.Next() {
+		var kind int32
+		var threadCreated, created time.Time
+		var threadID, id, parent int64
+		var path []int64
+		var title, author, forum, message, threadSlug string
+		var votes int32
+		var isEdited bool
+		err = resultRows.Scan(&kind, &threadCreated, &threadID, &path, &id, &parent, &title, &author, &forum,
+			&message, &votes, &threadSlug, &isEdited, &created)
+		if err != nil {
+			return
+		}
+
+		if kind == 0 {
+			err = onThread(&models.Thread{ID: id, Title: title, Author: author, Forum: forum, Message: message,
+				Votes: votes, Slug: threadSlug, Created: created})
+		} else {
+			err = onPost(&models.Post{ID: id, Parent: parent, Author: author, Message: message, IsEdited: isEdited,
+				Forum: forum, Thread: threadID, Created: created.Format(time.RFC3339Nano), Votes: votes}, len(path)-1)
+		}
+		if err != nil {
+			return
+		}
+	}
+	return resultRows.Err()
+}
@@ -29,12 +29,33 @@ func (forumStore *ForumStore) GetBySlug(slug string) (forum *models.Forum, err e
 	return
 }
 
+func (forumStore *ForumStore) GetBySlugs(slugs []string) (forums *[]models.Forum, err error) {
+	forumsSlice := make([]models.Forum, 0, len(slugs))
+
+	resultRows, err := forumStore.db.Query("SELECT title, user_, slug, posts, threads FROM forums "+
+		"WHERE slug = ANY($1::text[]::citext[]);", slugs)
+	if err != nil {
+		return
+	}
+	defer resultRows.Close()
+
+	for resultRows.Next() {
+		forum := models.Forum{}
+		err = resultRows.Scan(&forum.Title, &forum.User, &forum.Slug, &forum.Posts, &forum.Threads)
+		if err != nil {
+			return
+		}
+		forumsSlice = append(forumsSlice, forum)
+	}
+	return &forumsSlice, resultRows.Err()
+}
+
 func (forumStore *ForumStore) GetUsers(slug string, limit int, since string, desc bool) (users *[]models.User, err error) {
 	var usersSlice []models.User
 
 	var resultRows *pgx.Rows
 
-	query := "SELECT users.nickname, users.fullname, users.about, users.email FROM users " +
+	query := "SELECT users.nickname, users.fullname, users.about, users.email, users.reputation FROM users " +
 		"LEFT JOIN user_forum ON users.nickname = user_forum.nickname WHERE user_forum.forum = $1"
 
 	if since != "" {
@@ -62,7 +83,51 @@ func (forumStore *ForumStore) GetUsers(slug string, limit int, since string, des
 
 	for resultRows.Next() {
 		user := models.User{}
-		err = resultRows.Scan(&user.Nickname, &user.Fullname, &user.About, &user.Email)
+		err = resultRows.Scan(&user.Nickname, &user.Fullname, &user.About, &user.Email, &user.Reputation)
+		if err != nil {
+			return
+		}
+		usersSlice = append(usersSlice, user)
+	}
+	return &usersSlice, nil
+}
+
+func (forumStore *ForumStore) GetUsersByReputation(slug string, limit int, since string, desc bool) (users *[]models.User, err error) {
+	var usersSlice []models.User
+
+	var resultRows *pgx.Rows
+
+	query := "SELECT users.nickname, users.fullname, users.about, users.email, users.reputation FROM users " +
+		"LEFT JOIN user_forum ON users.nickname = user_forum.nickname WHERE user_forum.forum = $1"
+
+	if since != "" {
+		if desc {
+			query += " AND (users.reputation, users.nickname) < (SELECT reputation, nickname FROM users WHERE nickname = $2)" +
+				" ORDER BY users.reputation DESC, users.nickname DESC"
+		} else {
+			query += " AND (users.reputation, users.nickname) > (SELECT reputation, nickname FROM users WHERE nickname = $2)" +
+				" ORDER BY users.reputation, users.nickname"
+		}
+		query += " LIMIT $3;"
+		resultRows, err = forumStore.db.Query(query, slug, since, limit)
+	} else {
+		if desc {
+			query += " ORDER BY users.reputation DESC, users.nickname DESC"
+		} else {
+			query += " ORDER BY users.reputation, users.nickname"
+		}
+		query += " LIMIT $2;"
+		resultRows, err = forumStore.db.Query(query, slug, limit)
+	}
+
+	if err != nil {
+		return
+	}
+	defer resultRows.Close()
+
+	for resultRows.Next() {
+		user := models.User{}
+		err = resultRows.Scan(&user.Nickname, &user.Fullname, &user.About, &user.Email, &user.Reputation)
 		if err != nil {
 			return
 		}
@@ -71,26 +136,78 @@ func (forumStore *ForumStore) GetUsers(slug string, limit int, since string, des
 	return &usersSlice, nil
 }
 
+func (forumStore *ForumStore) GetByUser(nickname string, limit int, since string, desc bool) (forums *[]models.UserForum, err error) {
+	var forumsSlice []models.UserForum
+
+	var resultRows *pgx.Rows
+
+	query := "SELECT forums.slug, forums.title, user_forum.posts, user_forum.threads, user_forum.last_activity FROM user_forum " +
+		"JOIN forums ON forums.slug = user_forum.forum WHERE user_forum.nickname = $1"
+
+	if since != "" {
+		if desc {
+			query += " AND user_forum.forum < $2 ORDER BY user_forum.forum DESC"
+		} else {
+			query += " AND user_forum.forum > $2 ORDER BY user_forum.forum"
+		}
+		query += " LIMIT $3;"
+		resultRows, err = forumStore.db.Query(query, nickname, since, limit)
+	} else {
+		if desc {
+			query += " ORDER BY user_forum.forum DESC"
+		} else {
+			query += " ORDER BY user_forum.forum"
+		}
+		query += " LIMIT $2;"
+		resultRows, err = forumStore.db.Query(query, nickname, limit)
+	}
+
+	if err != nil {
+		return
+	}
+	defer resultRows.Close()
+
+	for resultRows.Next() {
+		forum := models.UserForum{}
+		err = resultRows.Scan(&forum.Forum, &forum.Title, &forum.Posts, &forum.Threads, &forum.LastActivity)
+		if err != nil {
+			return
+		}
+		forumsSlice = append(forumsSlice, forum)
+	}
+	return &forumsSlice, nil
+}
+
 func (forumStore *ForumStore) GetThreads(slug string, limit int, since string, desc bool) (threads *[]models.Thread, err error) {
 	var threadsSlice []models.Thread
+	err = forumStore.IterateThreads(slug, limit, since, desc, func(thread *models.Thread) error {
+		threadsSlice = append(threadsSlice, *thread)
+		return nil
+	})
+	if err != nil {
+		return
+	}
+	return &threadsSlice, nil
+}
 
+func (forumStore *ForumStore) IterateThreads(slug string, limit int, since string, desc bool, onThread func(thread *models.Thread) error) (err error) {
 	var resultRows *pgx.Rows
 
 	query := "SELECT id, title, author, forum, message, votes, slug, created FROM threads WHERE forum = $1"
 
 	if since != "" {
 		if desc {
-			query += " AND created <= $2 ORDER BY created DESC"
+			query += " AND created <= $2 ORDER BY created DESC, id DESC"
 		} else {
-			query += " AND created >= $2 ORDER BY created ASC"
+			query += " AND created >= $2 ORDER BY created ASC, id ASC"
 		}
 		query += " LIMIT $3;"
 		resultRows, err = forumStore.db.Query(query, slug, since, limit)
 	} else {
 		if desc {
-			query += " ORDER BY created DESC"
+			query += " ORDER BY created DESC, id DESC"
 		} else {
-			query += " ORDER BY created ASC"
+			query += " ORDER BY created ASC, id ASC"
 		}
 		query += " LIMIT $2;"
 		resultRows, err = forumStore.db.Query(query, slug, limit)
@@ -107,7 +224,9 @@ func (forumStore *ForumStore) GetThreads(slug string, limit int, since string, d
 		if err != nil {
 			return
 		}
-		threadsSlice = append(threadsSlice, thread)
+		if err = onThread(&thread); err != nil {
+			return
+		}
 	}
-	return &threadsSlice, nil
+	return resultRows.Err()
 }
@@ -0,0 +1,314 @@
+package stores
+
+import (
+	"Technopark_DB_Project/app/models"
+	"Technopark_DB_Project/app/repositories"
+	"io"
+	"time"
+
+	"github.com/jackc/pgx"
+	_ "github.com/lib/pq"
+)
+
+// Records of every type are copied into import_records as they are read, then split into the staging tables
+var importStagingTables = []string{
+	"CREATE TEMP TABLE import_records (line bigint, type text, id bigint, nickname citext, fullname text, about text, " +
+		"email citext, title text, user_ citext, slug citext, author citext, forum citext, message text, parent bigint, " +
+		"is_edited bool, thread bigint, created timestamp with time zone) ON COMMIT DROP;",
+	"CREATE TEMP TABLE import_users (line bigint, nickname citext, fullname text, about text, email citext) ON COMMIT DROP;",
+	"CREATE TEMP TABLE import_forums (line bigint, title text, user_ citext, slug citext) ON COMMIT DROP;",
+	"CREATE TEMP TABLE import_threads (line bigint, id bigint, title text, author citext, forum citext, " +
+		"message text, slug citext, created timestamp with time zone) ON COMMIT DROP;",
+	"CREATE TEMP TABLE import_posts (line bigint, id bigint, parent bigint, author citext, message text, " +
+		"is_edited bool, thread bigint, created timestamp with time zone) ON COMMIT DROP;",
+}
+
+var importRecordColumns = []string{"line", "type", "id", "nickname", "fullname", "about", "email", "title", "user_", "slug",
+	"author", "forum", "message", "parent", "is_edited", "thread", "created"}
+
+var importSplitQueries = []string{
+	"INSERT INTO import_users SELECT line, nickname, fullname, about, email FROM import_records WHERE type = 'user';",
+	"INSERT INTO import_forums SELECT line, title, user_, slug FROM import_records WHERE type = 'forum';",
+	"INSERT INTO import_threads SELECT line, id, title, author, forum, message, slug, created " +
+		"FROM import_records WHERE type = 'thread';",
+	"INSERT INTO import_posts SELECT line, id, parent, author, message, is_edited, thread, created " +
+		"FROM import_records WHERE type = 'post';",
+}
+
+// Every check removes failed records from the staging table and reports their lines,
+// later entities are checked against the already imported ones
+var (
+	importUserChecks = []importCheck{
+		{"user already exists", "DELETE FROM import_users i USING import_users d " +
+			"WHERE (d.nickname = i.nickname OR d.email = i.email) AND d.line < i.line RETURNING i.line;"},
+		{"user already exists", "DELETE FROM import_users i WHERE " +
+			"EXISTS (SELECT 1 FROM users WHERE nickname = i.nickname) OR " +
+			"EXISTS (SELECT 1 FROM users WHERE email = i.email) RETURNING i.line;"},
+	}
+	importForumChecks = []importCheck{
+		{"forum already exists", "DELETE FROM import_forums i USING import_forums d " +
+			"WHERE d.slug = i.slug AND d.line < i.line RETURNING i.line;"},
+		{"forum already exists", "DELETE FROM import_forums i " +
+			"WHERE EXISTS (SELECT 1 FROM forums WHERE slug = i.slug) RETURNING i.line;"},
+		{"forum owner not found", "DELETE FROM import_forums i " +
+			"WHERE NOT EXISTS (SELECT 1 FROM users WHERE nickname = i.user_) RETURNING i.line;"},
+	}
+	importThreadChecks = []importCheck{
+		{"thread already exists", "DELETE FROM import_threads i USING import_threads d " +
+			"WHERE (d.id = i.id OR (d.slug = i.slug AND i.slug <> '')) AND d.line < i.line RETURNING i.line;"},
+		{"thread already exists", "DELETE FROM import_threads i WHERE " +
+			"EXISTS (SELECT 1 FROM threads WHERE id = i.id) OR " +
+			"(i.slug <> '' AND EXISTS (SELECT 1 FROM threads WHERE slug = i.slug)) RETURNING i.line;"},
+		{"thread author not found", "DELETE FROM import_threads i " +
+			"WHERE NOT EXISTS (SELECT 1 FROM users WHERE nickname = i.author) RETURNING i.line;"},
+		{"thread forum not found", "DELETE FROM import_threads i " +
+			"WHERE NOT EXISTS (SELECT 1 FROM forums WHERE slug = i.forum) RETURNING i.line;"},
+	}
+	importPostChecks = []importCheck{
+		{"post already exists", "DELETE FROM import_posts i USING import_posts d " +
+			"WHERE d.id = i.id AND d.line < i.line RETURNING i.line;"},
+		{"post already exists", "DELETE FROM import_posts i " +
+			"WHERE EXISTS (SELECT 1 FROM posts WHERE id = i.id) RETURNING i.line;"},
+		{"post author not found", "DELETE FROM import_posts i " +
+			"WHERE NOT EXISTS (SELECT 1 FROM users WHERE nickname = i.author) RETURNING i.line;"},
+		{"post thread not found", "DELETE FROM import_posts i " +
+			"WHERE NOT EXISTS (SELECT 1 FROM threads WHERE id = i.thread) RETURNING i.line;"},
+		// Ids grow with time, so a reply can't be older than its parent. It also rules out cycles
+		{"parent post must be older than the reply", "DELETE FROM import_posts i " +
+			"WHERE i.parent >= i.id RETURNING i.line;"},
+	}
+	// Removing a post orphans its replies, so this check is repeated until nothing is removed
+	importPostParentCheck = importCheck{"parent post not found in thread", "DELETE FROM import_posts i " +
+		"WHERE i.parent <> 0 " +
+		"AND NOT EXISTS (SELECT 1 FROM posts WHERE id = i.parent AND thread = i.thread) " +
+		"AND NOT EXISTS (SELECT 1 FROM import_posts WHERE id = i.parent AND thread = i.thread) RETURNING i.line;"}
+)
+
+type importCheck struct {
+	message string
+	query   string
+}
+
+type ImportStore struct {
+	db *pgx.ConnPool
+}
+
+func CreateImportRepository(db *pgx.ConnPool) repositories.ImportRepository {
+	return &ImportStore{db: db}
+}
+
+// Import copies the records into staging tables as next reads them, so the source is never kept in memory whole.
+// Webhooks and new post notifications are skipped for the imported rows, see is_importing in db.sql.
+// The change feed still gets an insert for each of them.
+func (importStore *ImportStore) Import(next func() (record *models.ImportedRecord, err error)) (result *models.ImportResult, err error) {
+	tx, err := importStore.db.Begin()
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+
+	if _, err = tx.Exec("SET LOCAL forum.importing = 'on';"); err != nil {
+		return
+	}
+	for _, query := range importStagingTables {
+		if _, err = tx.Exec(query); err != nil {
+			return
+		}
+	}
+	if _, err = tx.CopyFrom(pgx.Identifier{"import_records"}, importRecordColumns, &importSource{next: next}); err != nil {
+		return
+	}
+	for _, query := range importSplitQueries {
+		if _, err = tx.Exec(query); err != nil {
+			return
+		}
+	}
+
+	result = &models.ImportResult{Errors: []models.ImportError{}}
+
+	// Users
+	if err = runImportChecks(tx, result, models.ImportUser, importUserChecks); err != nil {
+		return
+	}
+	commandTag, err := tx.Exec("INSERT INTO users (nickname, fullname, about, email) " +
+		"SELECT nickname, fullname, about, email FROM import_users ORDER BY line;")
+	if err != nil {
+		return
+	}
+	result.Users = commandTag.RowsAffected()
+
+	// Forums
+	if err = runImportChecks(tx, result, models.ImportForum, importForumChecks); err != nil {
+		return
+	}
+	commandTag, err = tx.Exec("INSERT INTO forums (title, user_, slug) " +
+		"SELECT i.title, users.nickname, i.slug FROM import_forums i " +
+		"JOIN users ON users.nickname = i.user_ ORDER BY i.line;")
+	if err != nil {
+		return
+	}
+	result.Forums = commandTag.RowsAffected()
+
+	// Threads. Sequence is moved past the imported ids first, so generated ids never collide with them
+	_, err = tx.Exec("SELECT setval(pg_get_serial_sequence('threads', 'id'), " +
+		"GREATEST((SELECT max(id) FROM threads), (SELECT max(id) FROM import_threads), 1));")
+	if err != nil {
+		return
+	}
+	_, err = tx.Exec("UPDATE import_threads SET id = nextval(pg_get_serial_sequence('threads', 'id')) WHERE id = 0;")
+	if err != nil {
+		return
+	}
+	if err = runImportChecks(tx, result, models.ImportThread, importThreadChecks); err != nil {
+		return
+	}
+	commandTag, err = tx.Exec("INSERT INTO threads (id, title, author, forum, message, slug, created) " +
+		"SELECT i.id, i.title, users.nickname, forums.slug, i.message, i.slug, COALESCE(i.created, now()) " +
+		"FROM import_threads i JOIN users ON users.nickname = i.author JOIN forums ON forums.slug = i.forum " +
+		"ORDER BY i.id;")
+	if err != nil {
+		return
+	}
+	result.Threads = commandTag.RowsAffected()
+
+	// Posts
+	_, err = tx.Exec("SELECT setval(pg_get_serial_sequence('posts', 'id'), " +
+		"GREATEST((SELECT max(id) FROM posts), (SELECT max(id) FROM import_posts), 1));")
+	if err != nil {
+		return
+	}
+	_, err = tx.Exec("UPDATE import_posts SET id = nextval(pg_get_serial_sequence('posts', 'id')) WHERE id = 0;")
+	if err != nil {
+		return
+	}
+	if err = runImportChecks(tx, result, models.ImportPost, importPostChecks); err != nil {
+		return
+	}
+	for removed := true; removed; {
+		removed, err = runImportCheck(tx, result, models.ImportPost, importPostParentCheck)
+		if err != nil {
+			return
+		}
+	}
+	commandTag, err = tx.Exec("INSERT INTO posts (id, parent, author, message, is_edited, forum, thread, created) " +
+		"SELECT i.id, NULLIF(i.parent, 0), users.nickname, i.message, i.is_edited, threads.forum, threads.id, " +
+		"COALESCE(i.created, now()) " +
+		"FROM import_posts i JOIN users ON users.nickname = i.author JOIN threads ON threads.id = i.thread " +
+		"ORDER BY i.id;")
+	if err != nil {
+		return
+	}
+	result.Posts = commandTag.RowsAffected()
+
+	// Path is rebuilt from the imported parent chains instead of relying on the insertion order
+	_, err = tx.Exec("WITH RECURSIVE tree (id, path) AS (" +
+		"SELECT i.id, COALESCE((SELECT path FROM posts WHERE id = i.parent), ARRAY []::bigint[]) || i.id " +
+		"FROM import_posts i WHERE NOT EXISTS (SELECT 1 FROM import_posts WHERE id = i.parent) " +
+		"UNION ALL " +
+		"SELECT i.id, tree.path || i.id FROM import_posts i JOIN tree ON i.parent = tree.id) " +
+		"UPDATE posts SET path = tree.path FROM tree WHERE posts.id = tree.id;")
+	if err != nil {
+		return
+	}
+
+	_, err = tx.Exec("UPDATE forums SET " +
+		"threads = (SELECT count(*) FROM threads WHERE threads.forum = forums.slug), " +
+		"posts = (SELECT count(*) FROM posts WHERE posts.forum = forums.slug) " +
+		"WHERE slug IN (SELECT forum FROM threads WHERE id IN " +
+		"(SELECT id FROM import_threads UNION SELECT thread FROM import_posts));")
+	if err != nil {
+		return
+	}
+
+	err = tx.Commit()
+	return
+}
+
+// importSource feeds COPY with the records next reads
+type importSource struct {
+	next   func() (record *models.ImportedRecord, err error)
+	values []interface{}
+	err    error
+}
+
+func (source *importSource) Next() bool {
+	record, err := source.next()
+	if err != nil {
+		if err != io.EOF {
+			source.err = err
+		}
+		return false
+	}
+	source.values = importRecordValues(record)
+	return true
+}
+
+func (source *importSource) Values() ([]interface{}, error) {
+	return source.values, nil
+}
+
+func (source *importSource) Err() error {
+	return source.err
+}
+
+// importRecordValues lays the record out in importRecordColumns, the columns of other types are left NULL
+func importRecordValues(record *models.ImportedRecord) []interface{} {
+	fields := map[string]interface{}{"line": record.Line, "type": record.Type}
+	switch {
+	case record.User != nil:
+		user := record.User
+		fields["nickname"], fields["fullname"], fields["about"], fields["email"] = user.Nickname, user.Fullname, user.About, user.Email
+	case record.Forum != nil:
+		forum := record.Forum
+		fields["title"], fields["user_"], fields["slug"] = forum.Title, forum.User, forum.Slug
+	case record.Thread != nil:
+		thread := record.Thread
+		var created *time.Time
+		if !thread.Created.IsZero() {
+			created = &thread.Created
+		}
+		fields["id"], fields["title"], fields["author"], fields["forum"] = thread.ID, thread.Title, thread.Author, thread.Forum
+		fields["message"], fields["slug"], fields["created"] = thread.Message, thread.Slug, created
+	case record.Post != nil:
+		post := record.Post
+		var created *time.Time
+		if parsed, errParse := time.Parse(time.RFC3339Nano, post.Created); errParse == nil {
+			created = &parsed
+		}
+		fields["id"], fields["parent"], fields["author"], fields["message"] = post.ID, post.Parent, post.Author, post.Message
+		fields["is_edited"], fields["thread"], fields["created"] = post.IsEdited, post.Thread, created
+	}
+
+	values := make([]interface{}, len(importRecordColumns))
+	for i, column := range importRecordColumns {
+		values[i] = fields[column]
+	}
+	return values
+}
+
+func runImportChecks(tx *pgx.Tx, result *models.ImportResult, recordType string, checks []importCheck) (err error) {
+	for _, check := range checks {
+		if _, err = runImportCheck(tx, result, recordType, check); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func runImportCheck(tx *pgx.Tx, result *models.ImportResult, recordType string, check importCheck) (removed bool, err error) {
+	resultRows, err := tx.Query(check.query)
+	if err != nil {
+		return
+	}
+	defer resultRows.Close()
+
+	for resultRows.Next() {
+		var line int64
+		if err = resultRows.Scan(&line); err != nil {
+			return
+		}
+		removed = true
+		result.Errors = append(result.Errors, models.ImportError{Line: line, Type: recordType, Message: check.message})
+	}
+	return removed, resultRows.Err()
+}
@@ -0,0 +1,130 @@
+package stores
+
+import (
+	"Technopark_DB_Project/app/models"
+	"Technopark_DB_Project/app/repositories"
+	"Technopark_DB_Project/pkg/mentions"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx"
+	_ "github.com/lib/pq"
+)
+
+type MentionStore struct {
+	db *pgx.ConnPool
+}
+
+func CreateMentionRepository(db *pgx.ConnPool) repositories.MentionRepository {
+	return &MentionStore{db: db}
+}
+
+type queryer interface {
+	Query(sql string, args ...interface{}) (*pgx.Rows, error)
+}
+
+// Only mentions of existing users are stored, nicknames are returned in the case they were registered with
+func insertMentions(db queryer, posts []*models.Post) (err error) {
+	postIDs := make([]int64, 0, len(posts))
+	nicknames := make([]string, 0, len(posts))
+	postsByID := make(map[int64]*models.Post, len(posts))
+	for _, post := range posts {
+		post.Mentions = nil
+		postsByID[post.ID] = post
+		for _, nickname := range mentions.Parse(post.Message) {
+			postIDs = append(postIDs, post.ID)
+			nicknames = append(nicknames, nickname)
+		}
+	}
+	if len(postIDs) == 0 {
+		return
+	}
+
+	resultRows, err := db.Query("INSERT INTO mentions (post, nickname) "+
+		"SELECT new_mentions.post, users.nickname FROM unnest($1::bigint[], $2::text[]) AS new_mentions (post, nickname) "+
+		"JOIN users ON users.nickname = new_mentions.nickname::citext "+
+		"ON CONFLICT DO NOTHING RETURNING post, nickname;", postIDs, nicknames)
+	if err != nil {
+		return
+	}
+	defer resultRows.Close()
+
+	for resultRows.Next() {
+		var postID int64
+		var nickname string
+		err = resultRows.Scan(&postID, &nickname)
+		if err != nil {
+			return
+		}
+		postsByID[postID].Mentions = append(postsByID[postID].Mentions, nickname)
+	}
+	return resultRows.Err()
+}
+
+func (mentionStore *MentionStore) GetByPosts(postIDs []int64) (mentions map[int64][]string, err error) {
+	mentions = make(map[int64][]string)
+	if len(postIDs) == 0 {
+		return
+	}
+
+	resultRows, err := mentionStore.db.Query("SELECT post, nickname FROM mentions WHERE post = ANY($1) ORDER BY post, nickname;", postIDs)
+	if err != nil {
+		return
+	}
+	defer resultRows.Close()
+
+	for resultRows.Next() {
+		var postID int64
+		var nickname string
+		err = resultRows.Scan(&postID, &nickname)
+		if err != nil {
+			return
+		}
+		mentions[postID] = append(mentions[postID], nickname)
+	}
+	return
+}
+
+func (mentionStore *MentionStore) GetPostsByUser(nickname string, limit int, since int64, desc bool) (posts *[]models.Post, err error) {
+	query := "SELECT posts.id, COALESCE(posts.parent, 0), posts.author, posts.message, posts.is_edited, posts.forum, posts.thread, posts.created, posts.votes " +
+		"FROM mentions JOIN posts ON posts.id = mentions.post WHERE mentions.nickname = $1"
+	args := []interface{}{nickname}
+
+	if since != -1 {
+		args = append(args, since)
+		if desc {
+			query += fmt.Sprintf(" AND mentions.post < $%d", len(args))
+		} else {
+			query += fmt.Sprintf(" AND mentions.post > $%d", len(args))
+		}
+	}
+	if desc {
+		query += " ORDER BY mentions.post DESC"
+	} else {
+		query += " ORDER BY mentions.post"
+	}
+	args = append(args, limit)
+	query += fmt.Sprintf(" LIMIT $%d;", len(args))
+
+	rows, err := mentionStore.db.Query(query, args...)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	posts = new([]models.Post)
+	for rows.Next() {
+		post := models.Post{}
+		postTime := time.Time{}
+
+		err = rows.Scan(&post.ID, &post.Parent, &post.Author, &post.Message, &post.IsEdited, &post.Forum, &post.Thread, &postTime, &post.Votes)
+		if err != nil {
+			return
+		}
+
+		post.Created = postTime.Format(time.RFC3339)
+		*posts = append(*posts, post)
+	}
+
+	return
+}
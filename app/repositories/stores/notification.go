@@ -0,0 +1,130 @@
+package stores
+
+import (
+	"Technopark_DB_Project/app/models"
+	"Technopark_DB_Project/app/repositories"
+	"fmt"
+
+	"github.com/jackc/pgx"
+	_ "github.com/lib/pq"
+)
+
+type NotificationStore struct {
+	db *pgx.ConnPool
+}
+
+func CreateNotificationRepository(db *pgx.ConnPool) repositories.NotificationRepository {
+	return &NotificationStore{db: db}
+}
+
+func (notificationStore *NotificationStore) GetByUser(nickname, kind string, limit int, since int64, unreadOnly, desc bool) (notifications *[]models.Notification, err error) {
+	var notificationsSlice []models.Notification
+
+	query := "SELECT id, kind, author, forum, thread, COALESCE(post, 0), is_read, created FROM notifications WHERE nickname = $1"
+	args := []interface{}{nickname}
+
+	if kind != "" {
+		args = append(args, kind)
+		query += fmt.Sprintf(" AND kind = $%d", len(args))
+	}
+	if unreadOnly {
+		query += " AND NOT is_read"
+	}
+	if since != -1 {
+		args = append(args, since)
+		if desc {
+			query += fmt.Sprintf(" AND id < $%d", len(args))
+		} else {
+			query += fmt.Sprintf(" AND id > $%d", len(args))
+		}
+	}
+	if desc {
+		query += " ORDER BY id DESC"
+	} else {
+		query += " ORDER BY id"
+	}
+	args = append(args, limit)
+	query += fmt.Sprintf(" LIMIT $%d;", len(args))
+
+	resultRows, err := notificationStore.db.Query(query, args...)
+	if err != nil {
+		return
+	}
+	defer resultRows.Close()
+
+	for resultRows.Next() {
+		notification := models.Notification{}
+		err = resultRows.Scan(&notification.ID, &notification.Kind, &notification.Author, &notification.Forum,
+			&notification.Thread, &notification.Post, &notification.IsRead, &notification.Created)
+		if err != nil {
+			return
+		}
+		notificationsSlice = append(notificationsSlice, notification)
+	}
+	return &notificationsSlice, nil
+}
+
+func (notificationStore *NotificationStore) MarkAsRead(nickname string, ids []int64) (err error) {
+	if len(ids) == 0 {
+		_, err = notificationStore.db.Exec("UPDATE notifications SET is_read = TRUE WHERE nickname = $1 AND NOT is_read;", nickname)
+		return
+	}
+	_, err = notificationStore.db.Exec("UPDATE notifications SET is_read = TRUE WHERE nickname = $1 AND id = ANY($2);", nickname, ids)
+	return
+}
+
+type execer interface {
+	Exec(sql string, arguments ...interface{}) (commandTag pgx.CommandTag, err error)
+}
+
+// insertThreadNotifications notifies the subscribers of the forum about a new thread except its author
+func insertThreadNotifications(db execer, thread *models.Thread) (err error) {
+	_, err = db.Exec("INSERT INTO notifications (nickname, kind, author, forum, thread) "+
+		"SELECT nickname, $1, $2, $3, $4 FROM forum_subscriptions WHERE forum = $3 AND nickname <> $2;",
+		models.NotificationThreadCreated, thread.Author, thread.Forum, thread.ID)
+	return
+}
+
+// insertPostNotifications notifies the subscribers of the thread about new posts except their own
+func insertPostNotifications(db execer, thread *models.Thread, posts []*models.Post) (err error) {
+	ids := make([]int64, 0, len(posts))
+	authors := make([]string, 0, len(posts))
+	for _, post := range posts {
+		ids = append(ids, post.ID)
+		authors = append(authors, post.Author)
+	}
+
+	_, err = db.Exec("INSERT INTO notifications (nickname, kind, author, forum, thread, post) "+
+		"SELECT thread_subscriptions.nickname, $1, new_posts.author, $2, $3, new_posts.id "+
+		"FROM thread_subscriptions, unnest($4::bigint[], $5::text[]) AS new_posts (id, author) "+
+		"WHERE thread_subscriptions.thread = $3 AND thread_subscriptions.nickname <> new_posts.author;",
+		models.NotificationPostCreated, thread.Forum, thread.ID, ids, authors)
+	return
+}
+
+// insertReplyNotifications notifies the authors of the posts replied to, the posts must be inserted already
+func insertReplyNotifications(db execer, thread *models.Thread, posts []*models.Post, notifyAncestors bool) (err error) {
+	ids := make([]int64, 0, len(posts))
+	authors := make([]string, 0, len(posts))
+	for _, post := range posts {
+		if post.Parent == 0 {
+			continue
+		}
+		ids = append(ids, post.ID)
+		authors = append(authors, post.Author)
+	}
+	if len(ids) == 0 {
+		return
+	}
+
+	// Direct parent is always notified, the rest of the path only if notifyAncestors is set
+	_, err = db.Exec("INSERT INTO notifications (nickname, kind, author, forum, thread, post) "+
+		"SELECT DISTINCT ancestors.author, $1::text, new_posts.author, $2::citext, $3::int, new_posts.id "+
+		"FROM unnest($4::bigint[], $5::text[]) AS new_posts (id, author) "+
+		"JOIN posts ON posts.id = new_posts.id "+
+		"JOIN posts AS ancestors ON ancestors.id = posts.parent OR "+
+		"($6 AND ancestors.id = ANY (posts.path) AND ancestors.id <> posts.id) "+
+		"WHERE ancestors.author <> new_posts.author;",
+		models.NotificationReply, thread.Forum, thread.ID, ids, authors, notifyAncestors)
+	return
+}
@@ -3,6 +3,7 @@ package stores
 import (
 	"Technopark_DB_Project/app/models"
 	"Technopark_DB_Project/app/repositories"
+	"fmt"
 	"time"
 
 	"github.com/jackc/pgx"
@@ -20,14 +21,148 @@ func CreatePostRepository(db *pgx.ConnPool) repositories.PostRepository {
 func (postStore *PostStore) GetByID(id int64) (post *models.Post, err error) {
 	post = &models.Post{}
 	postTime := time.Time{}
-	err = postStore.db.QueryRow("SELECT id, COALESCE(parent, 0), author, message, is_edited, forum, thread, created FROM posts "+
+	err = postStore.db.QueryRow("SELECT id, COALESCE(parent, 0), author, message, is_edited, forum, thread, created, votes FROM posts "+
 		"WHERE id = $1", id).
-		Scan(&post.ID, &post.Parent, &post.Author, &post.Message, &post.IsEdited, &post.Forum, &post.Thread, &postTime)
+		Scan(&post.ID, &post.Parent, &post.Author, &post.Message, &post.IsEdited, &post.Forum, &post.Thread, &postTime, &post.Votes)
 	post.Created = postTime.Format(time.RFC3339)
 	return
 }
 
+func (postStore *PostStore) GetByIDs(ids []int64) (posts *[]models.Post, err error) {
+	postsSlice := make([]models.Post, 0, len(ids))
+
+	resultRows, err := postStore.db.Query("SELECT id, COALESCE(parent, 0), author, message, is_edited, forum, thread, created, votes FROM posts "+
+		"WHERE id = ANY($1);", ids)
+	if err != nil {
+		return
+	}
+	defer resultRows.Close()
+
+	for resultRows.Next() {
+		post := models.Post{}
+		postTime := time.Time{}
+		err = resultRows.Scan(&post.ID, &post.Parent, &post.Author, &post.Message, &post.IsEdited, &post.Forum, &post.Thread, &postTime, &post.Votes)
+		if err != nil {
+			return
+		}
+		post.Created = postTime.Format(time.RFC3339)
+		postsSlice = append(postsSlice, post)
+	}
+	return &postsSlice, resultRows.Err()
+}
+
+// GetWithRelated fetches the post and the records it refers to in one query. The ones not asked for are nil,
+// so is parent of a root post.
+func (postStore *PostStore) GetWithRelated(id int64, withThread, withForum, withParent bool) (post *models.Post, thread *models.Thread,
+	forum *models.Forum, parent *models.Post, err error) {
+	post = &models.Post{}
+	postTime := time.Time{}
+	columns := "p.id, COALESCE(p.parent, 0), p.author, p.message, p.is_edited, p.forum, p.thread, p.created, p.votes"
+	destinations := []interface{}{&post.ID, &post.Parent, &post.Author, &post.Message, &post.IsEdited, &post.Forum, &post.Thread, &postTime, &post.Votes}
+	joins := ""
+
+	if withThread {
+		thread = &models.Thread{}
+		columns += ", t.id, t.title, t.author, t.forum, t.message, t.votes, t.slug, t.created"
+		destinations = append(destinations, &thread.ID, &thread.Title, &thread.Author, &thread.Forum, &thread.Message, &thread.Votes, &thread.Slug, &thread.Created)
+		joins += " JOIN threads t ON t.id = p.thread"
+	}
+	if withForum {
+		forum = &models.Forum{}
+		columns += ", f.title, f.user_, f.slug, f.posts, f.threads"
+		destinations = append(destinations, &forum.Title, &forum.User, &forum.Slug, &forum.Posts, &forum.Threads)
+		joins += " JOIN forums f ON f.slug = p.forum"
+	}
+	parentPost := &models.Post{}
+	parentTime := time.Time{}
+	if withParent {
+		// A root post has no parent row, the zero id tells it
+		columns += ", COALESCE(pp.id, 0), COALESCE(pp.parent, 0), COALESCE(pp.author, ''), COALESCE(pp.message, ''), " +
+			"COALESCE(pp.is_edited, FALSE), COALESCE(pp.forum, ''), COALESCE(pp.thread, 0), COALESCE(pp.created, p.created), COALESCE(pp.votes, 0)"
+		destinations = append(destinations, &parentPost.ID, &parentPost.Parent, &parentPost.Author, &parentPost.Message,
+			&parentPost.IsEdited, &parentPost.Forum, &parentPost.Thread, &parentTime, &parentPost.Votes)
+		joins += " LEFT JOIN posts pp ON pp.id = p.parent"
+	}
+
+	err = postStore.db.QueryRow("SELECT "+columns+" FROM posts p"+joins+" WHERE p.id = $1;", id).Scan(destinations...)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	post.Created = postTime.Format(time.RFC3339)
+	if parentPost.ID != 0 {
+		parentPost.Created = parentTime.Format(time.RFC3339)
+		parent = parentPost
+	}
+	return
+}
+
+// Update saves the message and replaces the mentions parsed from it in one transaction
 func (postStore *PostStore) Update(post *models.Post) (err error) {
-	_, err = postStore.db.Exec("UPDATE posts SET message = $1, is_edited = $2 WHERE id = $3;", post.Message, post.IsEdited, post.ID)
+	tx, err := postStore.db.Begin()
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec("UPDATE posts SET message = $1, is_edited = $2 WHERE id = $3;", post.Message, post.IsEdited, post.ID)
+	if err != nil {
+		return
+	}
+	_, err = tx.Exec("DELETE FROM mentions WHERE post = $1;", post.ID)
+	if err != nil {
+		return
+	}
+	err = insertMentions(tx, []*models.Post{post})
+	if err != nil {
+		return
+	}
+
+	return tx.Commit()
+}
+
+func (postStore *PostStore) GetByAuthor(nickname, forum string, limit int, since int64, desc bool) (posts *[]models.Post, err error) {
+	query := "SELECT id, COALESCE(parent, 0), author, message, is_edited, forum, thread, created, votes FROM posts WHERE author = $1"
+	args := []interface{}{nickname}
+
+	if forum != "" {
+		args = append(args, forum)
+		query += fmt.Sprintf(" AND forum = $%d", len(args))
+	}
+	if since != -1 {
+		args = append(args, since)
+		if desc {
+			query += fmt.Sprintf(" AND (created, id) < (SELECT created, id FROM posts WHERE id = $%d)", len(args))
+		} else {
+			query += fmt.Sprintf(" AND (created, id) > (SELECT created, id FROM posts WHERE id = $%d)", len(args))
+		}
+	}
+	if desc {
+		query += " ORDER BY created DESC, id DESC"
+	} else {
+		query += " ORDER BY created, id"
+	}
+	args = append(args, limit)
+	query += fmt.Sprintf(" LIMIT $%d;", len(args))
+
+	rows, err := postStore.db.Query(query, args...)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	posts = new([]models.Post)
+	for rows.Next() {
+		post := models.Post{}
+		postTime := time.Time{}
+
+		err = rows.Scan(&post.ID, &post.Parent, &post.Author, &post.Message, &post.IsEdited, &post.Forum, &post.Thread, &postTime, &post.Votes)
+		if err != nil {
+			return
+		}
+
+		post.Created = postTime.Format(time.RFC3339)
+		*posts = append(*posts, post)
+	}
+
 	return
 }
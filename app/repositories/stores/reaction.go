@@ -0,0 +1,113 @@
+package stores
+
+import (
+	"Technopark_DB_Project/app/models"
+	"Technopark_DB_Project/app/repositories"
+	"Technopark_DB_Project/pkg/errors"
+	"fmt"
+
+	"github.com/jackc/pgx"
+	_ "github.com/lib/pq"
+)
+
+type ReactionStore struct {
+	db *pgx.ConnPool
+}
+
+func CreateReactionRepository(db *pgx.ConnPool) repositories.ReactionRepository {
+	return &ReactionStore{db: db}
+}
+
+func (reactionStore *ReactionStore) Add(postID int64, reaction *models.Reaction) (err error) {
+	_, err = reactionStore.db.Exec("INSERT INTO post_reactions (nickname, post, reaction) "+
+		"VALUES ($1, $2, $3) ON CONFLICT DO NOTHING;",
+		reaction.Nickname, postID, reaction.Reaction)
+	return
+}
+
+// Delete returns ErrReactionNotFound when the user hasn't left the reaction, other errors are the database ones
+func (reactionStore *ReactionStore) Delete(postID int64, reaction *models.Reaction) (err error) {
+	commandTag, err := reactionStore.db.Exec("DELETE FROM post_reactions WHERE post = $1 AND nickname = $2 AND reaction = $3;",
+		postID, reaction.Nickname, reaction.Reaction)
+	if err != nil {
+		return
+	}
+	if commandTag.RowsAffected() == 0 {
+		err = errors.ErrReactionNotFound
+	}
+	return
+}
+
+// GetByPost pages the reactions by (nickname, reaction). Without sinceReaction every reaction of sinceNickname is skipped.
+func (reactionStore *ReactionStore) GetByPost(postID int64, limit int, sinceNickname, sinceReaction string, desc bool) (reactions *[]models.Reaction, err error) {
+	var reactionsSlice []models.Reaction
+
+	query := "SELECT nickname, reaction FROM post_reactions WHERE post = $1"
+	args := []interface{}{postID}
+
+	comparison := ">"
+	if desc {
+		comparison = "<"
+	}
+	if sinceNickname != "" {
+		args = append(args, sinceNickname)
+		if sinceReaction != "" {
+			args = append(args, sinceReaction)
+			query += fmt.Sprintf(" AND (nickname, reaction) %s ($%d, $%d)", comparison, len(args)-1, len(args))
+		} else {
+			query += fmt.Sprintf(" AND nickname %s $%d", comparison, len(args))
+		}
+	}
+	if desc {
+		query += " ORDER BY nickname DESC, reaction DESC"
+	} else {
+		query += " ORDER BY nickname, reaction"
+	}
+	args = append(args, limit)
+	query += fmt.Sprintf(" LIMIT $%d;", len(args))
+
+	resultRows, err := reactionStore.db.Query(query, args...)
+	if err != nil {
+		return
+	}
+	defer resultRows.Close()
+
+	for resultRows.Next() {
+		reaction := models.Reaction{}
+		err = resultRows.Scan(&reaction.Nickname, &reaction.Reaction)
+		if err != nil {
+			return
+		}
+		reactionsSlice = append(reactionsSlice, reaction)
+	}
+	return &reactionsSlice, resultRows.Err()
+}
+
+func (reactionStore *ReactionStore) GetCounts(postIDs []int64) (counts map[int64]map[string]int32, err error) {
+	counts = make(map[int64]map[string]int32)
+	if len(postIDs) == 0 {
+		return
+	}
+
+	resultRows, err := reactionStore.db.Query("SELECT post, reaction, count(*)::int FROM post_reactions "+
+		"WHERE post = ANY($1) GROUP BY post, reaction;", postIDs)
+	if err != nil {
+		return
+	}
+	defer resultRows.Close()
+
+	for resultRows.Next() {
+		var postID int64
+		var reaction string
+		var count int32
+		err = resultRows.Scan(&postID, &reaction, &count)
+		if err != nil {
+			return
+		}
+		if counts[postID] == nil {
+			counts[postID] = make(map[string]int32)
+		}
+		counts[postID][reaction] = count
+	}
+	return counts, resultRows.Err()
+}
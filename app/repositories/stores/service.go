@@ -17,7 +17,7 @@ func CreateServiceRepository(db *pgx.ConnPool) repositories.ServiceRepository {
 }
 
 func (serviceStore *ServiceStore) Clear() (err error) {
-	_, err = serviceStore.db.Exec("TRUNCATE TABLE forums, posts, threads, user_forum, users, votes CASCADE;")
+	_, err = serviceStore.db.Exec("TRUNCATE TABLE forums, forum_subscriptions, mentions, notifications, posts, post_reactions, post_votes, threads, thread_subscriptions, user_forum, users, votes, changes, webhooks, webhook_deliveries, webhook_outbox CASCADE;")
 	return
 }
 
@@ -30,3 +30,34 @@ func (serviceStore *ServiceStore) GetStatus() (status *models.Status, err error)
 		Scan(&status.User, &status.Forum, &status.Thread, &status.Post)
 	return
 }
+
+func (serviceStore *ServiceStore) GetChanges(after int64, limit int) (changes *[]models.Change, err error) {
+	var changesSlice []models.Change
+
+	// Changes of transactions still in progress may get lower ids than the already committed ones,
+	// so only changes older than the oldest running transaction are returned, ordered by transaction.
+	// Consumer never skips a change this way, but may see it again after a restart.
+	resultRows, err := serviceStore.db.Query("SELECT id, entity, operation, data::text, created FROM changes "+
+		"WHERE txid < txid_snapshot_xmin(txid_current_snapshot()) "+
+		"AND (txid, id) > (COALESCE((SELECT txid FROM changes WHERE id = $1), 0), $1) "+
+		"ORDER BY txid, id LIMIT $2;", after, limit)
+	if err != nil {
+		return
+	}
+	defer resultRows.Close()
+
+	for resultRows.Next() {
+		change := models.Change{}
+		var data string
+		err = resultRows.Scan(&change.ID, &change.Entity, &change.Operation, &data, &change.Created)
+		if err != nil {
+			return
+		}
+		change.Data = []byte(data)
+		changesSlice = append(changesSlice, change)
+	}
+	if err = resultRows.Err(); err != nil {
+		return
+	}
+	return &changesSlice, nil
+}
@@ -0,0 +1,59 @@
+package stores
+
+import (
+	"Technopark_DB_Project/app/repositories"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx"
+	_ "github.com/lib/pq"
+)
+
+// Filled by notify_new_post trigger with "<thread id>:<post id>" payload
+const newPostsChannel = "new_posts"
+
+type StreamStore struct {
+	db *pgx.ConnPool
+}
+
+func CreateStreamRepository(db *pgx.ConnPool) repositories.StreamRepository {
+	return &StreamStore{db: db}
+}
+
+func (streamStore *StreamStore) ListenPosts(ctx context.Context, handler func(threadID, postID int64)) (err error) {
+	conn, err := streamStore.db.Acquire()
+	if err != nil {
+		return
+	}
+	defer streamStore.db.Release(conn)
+
+	err = conn.Listen(newPostsChannel)
+	if err != nil {
+		return
+	}
+	defer conn.Unlisten(newPostsChannel)
+
+	for {
+		var notification *pgx.Notification
+		notification, err = conn.WaitForNotification(ctx)
+		if err != nil {
+			return
+		}
+
+		ids := strings.SplitN(notification.Payload, ":", 2)
+		if len(ids) != 2 {
+			fmt.Println("bad new post notification:", notification.Payload)
+			continue
+		}
+		threadID, errThread := strconv.ParseInt(ids[0], 10, 64)
+		postID, errPost := strconv.ParseInt(ids[1], 10, 64)
+		if errThread != nil || errPost != nil {
+			fmt.Println("bad new post notification:", notification.Payload)
+			continue
+		}
+
+		handler(threadID, postID)
+	}
+}
@@ -0,0 +1,52 @@
+package stores
+
+import (
+	"Technopark_DB_Project/app/repositories"
+
+	"github.com/jackc/pgx"
+	_ "github.com/lib/pq"
+)
+
+type SubscriptionStore struct {
+	db *pgx.ConnPool
+}
+
+func CreateSubscriptionRepository(db *pgx.ConnPool) repositories.SubscriptionRepository {
+	return &SubscriptionStore{db: db}
+}
+
+func (subscriptionStore *SubscriptionStore) SubscribeThread(threadID int64, nickname string) (err error) {
+	_, err = subscriptionStore.db.Exec("INSERT INTO thread_subscriptions (nickname, thread) "+
+		"VALUES ($1, $2) ON CONFLICT DO NOTHING;", nickname, threadID)
+	return
+}
+
+func (subscriptionStore *SubscriptionStore) UnsubscribeThread(threadID int64, nickname string) (err error) {
+	commandTag, err := subscriptionStore.db.Exec("DELETE FROM thread_subscriptions WHERE thread = $1 AND nickname = $2;",
+		threadID, nickname)
+	if err != nil {
+		return
+	}
+	if commandTag.RowsAffected() == 0 {
+		err = pgx.ErrNoRows
+	}
+	return
+}
+
+func (subscriptionStore *SubscriptionStore) SubscribeForum(slug string, nickname string) (err error) {
+	_, err = subscriptionStore.db.Exec("INSERT INTO forum_subscriptions (nickname, forum) "+
+		"VALUES ($1, $2) ON CONFLICT DO NOTHING;", nickname, slug)
+	return
+}
+
+func (subscriptionStore *SubscriptionStore) UnsubscribeForum(slug string, nickname string) (err error) {
+	commandTag, err := subscriptionStore.db.Exec("DELETE FROM forum_subscriptions WHERE forum = $1 AND nickname = $2;",
+		slug, nickname)
+	if err != nil {
+		return
+	}
+	if commandTag.RowsAffected() == 0 {
+		err = pgx.ErrNoRows
+	}
+	return
+}
@@ -19,12 +19,26 @@ func CreateThreadRepository(db *pgx.ConnPool) repositories.ThreadRepository {
 	return &ThreadStore{db: db}
 }
 
+// Create inserts the thread and notifies the subscribers of its forum in one transaction
 func (threadStore *ThreadStore) Create(thread *models.Thread) (err error) {
-	err = threadStore.db.QueryRow("INSERT INTO threads (title, author, forum, message, slug, created) "+
+	tx, err := threadStore.db.Begin()
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRow("INSERT INTO threads (title, author, forum, message, slug, created) "+
 		"VALUES ($1, $2, $3, $4, $5, $6) RETURNING id, created;",
 		thread.Title, thread.Author, thread.Forum, thread.Message, thread.Slug, thread.Created).
 		Scan(&thread.ID, &thread.Created)
-	return
+	if err != nil {
+		return
+	}
+	if err = insertThreadNotifications(tx, thread); err != nil {
+		return
+	}
+
+	return tx.Commit()
 }
 
 func (threadStore *ThreadStore) GetByID(id int64) (thread *models.Thread, err error) {
@@ -35,6 +49,27 @@ func (threadStore *ThreadStore) GetByID(id int64) (thread *models.Thread, err er
 	return
 }
 
+func (threadStore *ThreadStore) GetByIDs(ids []int64) (threads *[]models.Thread, err error) {
+	threadsSlice := make([]models.Thread, 0, len(ids))
+
+	resultRows, err := threadStore.db.Query("SELECT id, title, author, forum, message, votes, slug, created FROM threads "+
+		"WHERE id = ANY($1);", ids)
+	if err != nil {
+		return
+	}
+	defer resultRows.Close()
+
+	for resultRows.Next() {
+		thread := models.Thread{}
+		err = resultRows.Scan(&thread.ID, &thread.Title, &thread.Author, &thread.Forum, &thread.Message, &thread.Votes, &thread.Slug, &thread.Created)
+		if err != nil {
+			return
+		}
+		threadsSlice = append(threadsSlice, thread)
+	}
+	return &threadsSlice, resultRows.Err()
+}
+
 func (threadStore *ThreadStore) GetBySlug(slug string) (thread *models.Thread, err error) {
 	thread = &models.Thread{}
 	err = threadStore.db.QueryRow("SELECT id, title, author, forum, message, votes, slug, created FROM threads "+
@@ -51,6 +86,28 @@ func (threadStore *ThreadStore) GetBySlugOrID(slugOrID string) (thread *models.T
 	return
 }
 
+// GetWithForum fetches the thread by id, or by slug when id is 0, together with its forum in one query
+func (threadStore *ThreadStore) GetWithForum(id int64, slug string) (thread *models.Thread, forum *models.Forum, err error) {
+	thread = &models.Thread{}
+	forum = &models.Forum{}
+	query := "SELECT t.id, t.title, t.author, t.forum, t.message, t.votes, t.slug, t.created, f.title, f.user_, f.slug, f.posts, f.threads " +
+		"FROM threads t JOIN forums f ON f.slug = t.forum "
+	var key interface{} = id
+	if id != 0 {
+		query += "WHERE t.id = $1;"
+	} else {
+		query += "WHERE t.slug = $1;"
+		key = slug
+	}
+	err = threadStore.db.QueryRow(query, key).
+		Scan(&thread.ID, &thread.Title, &thread.Author, &thread.Forum, &thread.Message, &thread.Votes, &thread.Slug, &thread.Created,
+			&forum.Title, &forum.User, &forum.Slug, &forum.Posts, &forum.Threads)
+	if err != nil {
+		return nil, nil, err
+	}
+	return
+}
+
 func (threadStore *ThreadStore) GetVotes(id int64) (votesAmount int32, err error) {
 	err = threadStore.db.QueryRow("SELECT votes FROM threads WHERE id = $1;", id).Scan(&votesAmount)
 	return
@@ -62,7 +119,7 @@ func (threadStore *ThreadStore) Update(thread *models.Thread) (err error) {
 	return
 }
 
-func (threadStore *ThreadStore) createPartPosts(thread *models.Thread, posts *models.Posts, from, to int, created time.Time, createdFormatted string) (err error) {
+func createPartPosts(db queryer, thread *models.Thread, posts *models.Posts, from, to int, created time.Time, createdFormatted string) (err error) {
 	query := "INSERT INTO posts (parent, author, message, forum, thread, created) VALUES "
 	args := make([]interface{}, 0, 0)
 
@@ -87,7 +144,7 @@ func (threadStore *ThreadStore) createPartPosts(thread *models.Thread, posts *mo
 
 	for !isSuccess {
 
-		resultRows, err := threadStore.db.Query(query, args...)
+		resultRows, err := db.Query(query, args...)
 		if err != nil {
 			fmt.Println(err)
 			return errors.ErrParentPostNotExist
@@ -111,40 +168,68 @@ func (threadStore *ThreadStore) createPartPosts(thread *models.Thread, posts *mo
 	return
 }
 
-func (threadStore *ThreadStore) CreatePosts(thread *models.Thread, posts *models.Posts) (err error) {
+// CreatePosts inserts the posts with their mentions and the notifications about them in one transaction,
+// so either all of it is stored or nothing is
+func (threadStore *ThreadStore) CreatePosts(thread *models.Thread, posts *models.Posts, notifyReplyAncestors bool) (err error) {
 	created := time.Now()
 	createdFormatted := created.Format(time.RFC3339)
 
+	tx, err := threadStore.db.Begin()
+	if err != nil {
+		return
+	}
+	defer tx.Rollback()
+
 	parts := len(*posts) / 20
 	for i := 0; i < parts+1; i++ {
 		if i == parts {
 			if i*20 != len(*posts) {
-				err = threadStore.createPartPosts(thread, posts, i*20, len(*posts), created, createdFormatted)
+				err = createPartPosts(tx, thread, posts, i*20, len(*posts), created, createdFormatted)
 				if err != nil {
 					return err
 				}
 			}
 		} else {
-			err = threadStore.createPartPosts(thread, posts, i*20, i*20+20, created, createdFormatted)
+			err = createPartPosts(tx, thread, posts, i*20, i*20+20, created, createdFormatted)
 			if err != nil {
 				return err
 			}
 		}
 	}
 
-	return
+	postsPtrs := make([]*models.Post, 0, len(*posts))
+	for i := range *posts {
+		postsPtrs = append(postsPtrs, &(*posts)[i])
+	}
+	if err = insertMentions(tx, postsPtrs); err != nil {
+		return
+	}
+	if err = insertPostNotifications(tx, thread, postsPtrs); err != nil {
+		return
+	}
+	if err = insertReplyNotifications(tx, thread, postsPtrs, notifyReplyAncestors); err != nil {
+		return
+	}
+
+	return tx.Commit()
 }
 
 func (threadStore *ThreadStore) GetPostsTree(threadID int64, limit, since int, desc bool) (posts *[]models.Post, err error) {
-	var rows *pgx.Rows
+	rows, err := threadStore.queryPostsTree(threadID, limit, since, desc)
+	if err != nil {
+		return
+	}
+	return collectPosts(rows)
+}
 
+func (threadStore *ThreadStore) queryPostsTree(threadID int64, limit, since int, desc bool) (rows *pgx.Rows, err error) {
 	if since == -1 {
 		if desc {
-			query := "SELECT id, COALESCE(parent, 0), author, message, is_edited, forum, thread, created FROM posts " +
+			query := "SELECT id, COALESCE(parent, 0), author, message, is_edited, forum, thread, created, votes FROM posts " +
 				"WHERE thread = $1 ORDER BY path DESC LIMIT NULLIF($2, 0);"
 			rows, err = threadStore.db.Query(query, threadID, limit)
 		} else {
-			query := "SELECT id, COALESCE(parent, 0), author, message, is_edited, forum, thread, created FROM posts " +
+			query := "SELECT id, COALESCE(parent, 0), author, message, is_edited, forum, thread, created, votes FROM posts " +
 				"WHERE thread = $1 ORDER BY path LIMIT NULLIF($2, 0);"
 			rows, err = threadStore.db.Query(query, threadID, limit)
 		}
@@ -157,61 +242,48 @@ func (threadStore *ThreadStore) GetPostsTree(threadID int64, limit, since int, d
 		//}
 		//
 		//if desc {
-		//	query := "SELECT id, COALESCE(parent, 0), author, message, is_edited, forum, thread, created FROM posts " +
+		//	query := "SELECT id, COALESCE(parent, 0), author, message, is_edited, forum, thread, created, votes FROM posts " +
 		//		"WHERE thread = $1 AND path < $2 ORDER BY path DESC LIMIT NULLIF($3, 0);"
 		//	rows, err = threadStore.db.Query(query, threadID, path, limit)
 		//} else {
-		//	query := "SELECT id, COALESCE(parent, 0), author, message, is_edited, forum, thread, created FROM posts " +
+		//	query := "SELECT id, COALESCE(parent, 0), author, message, is_edited, forum, thread, created, votes FROM posts " +
 		//		"WHERE thread = $1 AND path > $2 ORDER BY path LIMIT NULLIF($3, 0);"
 		//	rows, err = threadStore.db.Query(query, threadID, path, limit)
 		//}
 
 		if desc {
-			query := "SELECT id, COALESCE(parent, 0), author, message, is_edited, forum, thread, created FROM posts " +
+			query := "SELECT id, COALESCE(parent, 0), author, message, is_edited, forum, thread, created, votes FROM posts " +
 				"WHERE thread = $1 AND path < (SELECT path FROM posts WHERE id = $2) ORDER BY path DESC LIMIT NULLIF($3, 0);"
 			rows, err = threadStore.db.Query(query, threadID, since, limit)
 		} else {
-			query := "SELECT id, COALESCE(parent, 0), author, message, is_edited, forum, thread, created FROM posts " +
+			query := "SELECT id, COALESCE(parent, 0), author, message, is_edited, forum, thread, created, votes FROM posts " +
 				"WHERE thread = $1 AND path > (SELECT path FROM posts WHERE id = $2) ORDER BY path LIMIT NULLIF($3, 0);"
 			rows, err = threadStore.db.Query(query, threadID, since, limit)
 		}
 	}
 
-	if err != nil {
-		return
-	}
-	defer rows.Close()
-
-	posts = new([]models.Post)
-	for rows.Next() {
-		post := models.Post{}
-		postTime := time.Time{}
-
-		err = rows.Scan(&post.ID, &post.Parent, &post.Author, &post.Message, &post.IsEdited, &post.Forum, &post.Thread, &postTime)
-		if err != nil {
-			return
-		}
-
-		post.Created = postTime.Format(time.RFC3339)
-		*posts = append(*posts, post)
-	}
-
 	return
 }
 
 func (threadStore *ThreadStore) GetPostsParentTree(threadID int64, limit, since int, desc bool) (posts *[]models.Post, err error) {
-	var rows *pgx.Rows
+	rows, err := threadStore.queryPostsParentTree(threadID, limit, since, desc)
+	if err != nil {
+		return
+	}
+	return collectPosts(rows)
+}
 
+func (threadStore *ThreadStore) queryPostsParentTree(threadID int64, limit, since int, desc bool) (rows *pgx.Rows, err error) {
 	if since == -1 {
 		if desc {
 			rows, err = threadStore.db.Query(`
-					SELECT id, COALESCE(parent, 0), author, message, is_edited, forum, thread, created FROM posts
+					SELECT id, COALESCE(parent, 0), author, message, is_edited, forum, thread, created, votes FROM posts
 					WHERE path[1] IN 
 						(SELECT id FROM posts WHERE thread = $1 AND parent IS NULL ORDER BY id DESC LIMIT $2)
 					ORDER BY path[1] DESC, path ASC, id ASC;`, threadID, limit)
 		} else {
 			rows, err = threadStore.db.Query(`
-					SELECT id, COALESCE(parent, 0), author, message, is_edited, forum, thread, created FROM posts 
+					SELECT id, COALESCE(parent, 0), author, message, is_edited, forum, thread, created, votes FROM posts 
 					WHERE path[1] IN 
 						(SELECT id FROM posts WHERE thread = $1 AND parent IS NULL ORDER BY id LIMIT $2) 
 					ORDER BY path;`, threadID, limit)
@@ -219,7 +291,7 @@ func (threadStore *ThreadStore) GetPostsParentTree(threadID int64, limit, since
 	} else {
 		if desc {
 			rows, err = threadStore.db.Query(`
-					SELECT id, COALESCE(parent, 0), author, message, is_edited, forum, thread, created FROM posts 
+					SELECT id, COALESCE(parent, 0), author, message, is_edited, forum, thread, created, votes FROM posts 
 					WHERE path[1] IN 
 						(SELECT id FROM posts WHERE thread = $1 AND parent IS NULL AND path[1] < 
  							(SELECT path[1] FROM posts WHERE id = $2) 
@@ -227,7 +299,7 @@ func (threadStore *ThreadStore) GetPostsParentTree(threadID int64, limit, since
 					ORDER BY path[1] DESC, path ASC, id ASC;`, threadID, since, limit)
 		} else {
 			rows, err = threadStore.db.Query(`
-					SELECT id, COALESCE(parent, 0), author, message, is_edited, forum, thread, created FROM posts 
+					SELECT id, COALESCE(parent, 0), author, message, is_edited, forum, thread, created, votes FROM posts 
 					WHERE path[1] IN 
 						(SELECT id FROM posts WHERE thread = $1 AND parent IS NULL AND path[1] > 
  							(SELECT path[1] FROM posts WHERE id = $2) 
@@ -235,66 +307,164 @@ func (threadStore *ThreadStore) GetPostsParentTree(threadID int64, limit, since
 					ORDER BY path;`, threadID, since, limit)
 		}
 	}
+	return
+}
+
+func (threadStore *ThreadStore) GetPostsFlat(threadID int64, limit, since int, desc bool) (posts *[]models.Post, err error) {
+	rows, err := threadStore.queryPostsFlat(threadID, limit, since, desc)
 	if err != nil {
-		return nil, err
+		return
 	}
-	defer rows.Close()
-
-	posts = new([]models.Post)
-	for rows.Next() {
-		post := models.Post{}
-		postTime := time.Time{}
+	return collectPosts(rows)
+}
 
-		err = rows.Scan(&post.ID, &post.Parent, &post.Author, &post.Message, &post.IsEdited, &post.Forum, &post.Thread, &postTime)
-		if err != nil {
-			return
+func (threadStore *ThreadStore) queryPostsFlat(threadID int64, limit, since int, desc bool) (rows *pgx.Rows, err error) {
+	if since == -1 {
+		if desc {
+			query := "SELECT id, COALESCE(parent, 0), author, message, is_edited, forum, thread, created, votes FROM posts WHERE thread = $1 ORDER BY id DESC LIMIT NULLIF($2, 0);"
+			rows, err = threadStore.db.Query(query, threadID, limit)
+		} else {
+			query := "SELECT id, COALESCE(parent, 0), author, message, is_edited, forum, thread, created, votes FROM posts WHERE thread = $1 ORDER BY id LIMIT NULLIF($2, 0);"
+			rows, err = threadStore.db.Query(query, threadID, limit)
+		}
+	} else {
+		if desc {
+			query := "SELECT id, COALESCE(parent, 0), author, message, is_edited, forum, thread, created, votes FROM posts WHERE thread = $1 AND id < $2 ORDER BY id DESC LIMIT NULLIF($3, 0);"
+			rows, err = threadStore.db.Query(query, threadID, since, limit)
+		} else {
+			query := "SELECT id, COALESCE(parent, 0), author, message, is_edited, forum, thread, created, votes FROM posts WHERE thread = $1 AND id > $2 ORDER BY id LIMIT NULLIF($3, 0);"
+			rows, err = threadStore.db.Query(query, threadID, since, limit)
 		}
-
-		post.Created = postTime.Format(time.RFC3339)
-		*posts = append(*posts, post)
 	}
-
 	return
 }
 
-func (threadStore *ThreadStore) GetPostsFlat(threadID int64, limit, since int, desc bool) (posts *[]models.Post, err error) {
-	var rows *pgx.Rows
+func (threadStore *ThreadStore) GetPostsTop(threadID int64, limit, since int, desc bool) (posts *[]models.Post, err error) {
+	rows, err := threadStore.queryPostsTop(threadID, limit, since, desc)
+	if err != nil {
+		return
+	}
+	return collectPosts(rows)
+}
 
+func (threadStore *ThreadStore) queryPostsTop(threadID int64, limit, since int, desc bool) (rows *pgx.Rows, err error) {
 	if since == -1 {
 		if desc {
-			query := "SELECT id, COALESCE(parent, 0), author, message, is_edited, forum, thread, created FROM posts WHERE thread = $1 ORDER BY id DESC LIMIT NULLIF($2, 0);"
+			query := "SELECT id, COALESCE(parent, 0), author, message, is_edited, forum, thread, created, votes FROM posts WHERE thread = $1 ORDER BY votes, id DESC LIMIT NULLIF($2, 0);"
 			rows, err = threadStore.db.Query(query, threadID, limit)
 		} else {
-			query := "SELECT id, COALESCE(parent, 0), author, message, is_edited, forum, thread, created FROM posts WHERE thread = $1 ORDER BY id LIMIT NULLIF($2, 0);"
+			query := "SELECT id, COALESCE(parent, 0), author, message, is_edited, forum, thread, created, votes FROM posts WHERE thread = $1 ORDER BY votes DESC, id LIMIT NULLIF($2, 0);"
 			rows, err = threadStore.db.Query(query, threadID, limit)
 		}
 	} else {
+		// The keyset is compared on the raw columns, so the scan stays on posts_thread_votes (thread, votes DESC, id)
 		if desc {
-			query := "SELECT id, COALESCE(parent, 0), author, message, is_edited, forum, thread, created FROM posts WHERE thread = $1 AND id < $2 ORDER BY id DESC LIMIT NULLIF($3, 0);"
+			query := "SELECT id, COALESCE(parent, 0), author, message, is_edited, forum, thread, created, votes FROM posts WHERE thread = $1 AND " +
+				"(votes > (SELECT votes FROM posts WHERE id = $2) OR (votes = (SELECT votes FROM posts WHERE id = $2) AND id < $2)) " +
+				"ORDER BY votes, id DESC LIMIT NULLIF($3, 0);"
 			rows, err = threadStore.db.Query(query, threadID, since, limit)
 		} else {
-			query := "SELECT id, COALESCE(parent, 0), author, message, is_edited, forum, thread, created FROM posts WHERE thread = $1 AND id > $2 ORDER BY id LIMIT NULLIF($3, 0);"
+			query := "SELECT id, COALESCE(parent, 0), author, message, is_edited, forum, thread, created, votes FROM posts WHERE thread = $1 AND " +
+				"(votes < (SELECT votes FROM posts WHERE id = $2) OR (votes = (SELECT votes FROM posts WHERE id = $2) AND id > $2)) " +
+				"ORDER BY votes DESC, id LIMIT NULLIF($3, 0);"
 			rows, err = threadStore.db.Query(query, threadID, since, limit)
 		}
 	}
+	return
+}
+
+func (threadStore *ThreadStore) IteratePosts(threadID int64, sort string, limit, since int, desc bool, onPost func(post *models.Post) error) (err error) {
+	var rows *pgx.Rows
+	switch sort {
+	case "tree":
+		rows, err = threadStore.queryPostsTree(threadID, limit, since, desc)
+	case "parent_tree":
+		rows, err = threadStore.queryPostsParentTree(threadID, limit, since, desc)
+	case "top":
+		rows, err = threadStore.queryPostsTop(threadID, limit, since, desc)
+	default:
+		rows, err = threadStore.queryPostsFlat(threadID, limit, since, desc)
+	}
 	if err != nil {
 		return
 	}
+	defer rows.Close()
+
+	for rows.Next() {
+		post := models.Post{}
+		if err = scanPost(rows, &post); err != nil {
+			return
+		}
+		if err = onPost(&post); err != nil {
+			return
+		}
+	}
+	return rows.Err()
+}
 
+func collectPosts(rows *pgx.Rows) (posts *[]models.Post, err error) {
 	defer rows.Close()
+
 	posts = new([]models.Post)
 	for rows.Next() {
 		post := models.Post{}
-		postTime := time.Time{}
-
-		err = rows.Scan(&post.ID, &post.Parent, &post.Author, &post.Message, &post.IsEdited, &post.Forum, &post.Thread, &postTime)
-		if err != nil {
+		if err = scanPost(rows, &post); err != nil {
 			return
 		}
-
-		post.Created = postTime.Format(time.RFC3339)
 		*posts = append(*posts, post)
 	}
+	return posts, rows.Err()
+}
 
+func scanPost(rows *pgx.Rows, post *models.Post) (err error) {
+	postTime := time.Time{}
+	err = rows.Scan(&post.ID, &post.Parent, &post.Author, &post.Message, &post.IsEdited, &post.Forum, &post.Thread, &postTime, &post.Votes)
+	if err != nil {
+		return
+	}
+	post.Created = postTime.Format(time.RFC3339)
 	return
 }
+
+func (threadStore *ThreadStore) GetByAuthor(nickname, forum string, limit int, since int64, desc bool) (threads *[]models.Thread, err error) {
+	var threadsSlice []models.Thread
+
+	query := "SELECT id, title, author, forum, message, votes, slug, created FROM threads WHERE author = $1"
+	args := []interface{}{nickname}
+
+	if forum != "" {
+		args = append(args, forum)
+		query += fmt.Sprintf(" AND forum = $%d", len(args))
+	}
+	if since != -1 {
+		args = append(args, since)
+		if desc {
+			query += fmt.Sprintf(" AND (created, id) < (SELECT created, id FROM threads WHERE id = $%d)", len(args))
+		} else {
+			query += fmt.Sprintf(" AND (created, id) > (SELECT created, id FROM threads WHERE id = $%d)", len(args))
+		}
+	}
+	if desc {
+		query += " ORDER BY created DESC, id DESC"
+	} else {
+		query += " ORDER BY created, id"
+	}
+	args = append(args, limit)
+	query += fmt.Sprintf(" LIMIT $%d;", len(args))
+
+	resultRows, err := threadStore.db.Query(query, args...)
+	if err != nil {
+		return
+	}
+	defer resultRows.Close()
+
+	for resultRows.Next() {
+		thread := models.Thread{}
+		err = resultRows.Scan(&thread.ID, &thread.Title, &thread.Author, &thread.Forum, &thread.Message, &thread.Votes, &thread.Slug, &thread.Created)
+		if err != nil {
+			return
+		}
+		threadsSlice = append(threadsSlice, thread)
+	}
+	return &threadsSlice, nil
+}
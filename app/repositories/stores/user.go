@@ -27,21 +27,43 @@ func (userStore *UserStore) Update(user *models.User) (err error) {
 		"fullname = COALESCE(NULLIF(TRIM($1), ''), fullname), "+
 		"about = COALESCE(NULLIF(TRIM($2), ''), about), "+
 		"email = COALESCE(NULLIF(TRIM($3), ''), email) "+
-		"WHERE nickname = $4 RETURNING fullname, about, email;",
-		user.Fullname, user.About, user.Email, user.Nickname).Scan(&user.Fullname, &user.About, &user.Email)
+		"WHERE nickname = $4 RETURNING fullname, about, email, reputation;",
+		user.Fullname, user.About, user.Email, user.Nickname).Scan(&user.Fullname, &user.About, &user.Email, &user.Reputation)
 }
 
 func (userStore *UserStore) GetByNickname(nickname string) (user *models.User, err error) {
 	user = new(models.User)
-	err = userStore.db.QueryRow("SELECT nickname, fullname, about, email FROM users "+
-		"WHERE nickname = $1;", nickname).Scan(&user.Nickname, &user.Fullname, &user.About, &user.Email)
+	err = userStore.db.QueryRow("SELECT nickname, fullname, about, email, reputation FROM users "+
+		"WHERE nickname = $1;", nickname).Scan(&user.Nickname, &user.Fullname, &user.About, &user.Email, &user.Reputation)
 	return
 }
 
+// Nicknames are compared as citext, so lookups are case-insensitive like GetByNickname
+func (userStore *UserStore) GetByNicknames(nicknames []string) (users *[]models.User, err error) {
+	usersSlice := make([]models.User, 0, len(nicknames))
+
+	resultRows, err := userStore.db.Query("SELECT nickname, fullname, about, email, reputation FROM users "+
+		"WHERE nickname = ANY($1::text[]::citext[]);", nicknames)
+	if err != nil {
+		return
+	}
+	defer resultRows.Close()
+
+	for resultRows.Next() {
+		user := models.User{}
+		err = resultRows.Scan(&user.Nickname, &user.Fullname, &user.About, &user.Email, &user.Reputation)
+		if err != nil {
+			return
+		}
+		usersSlice = append(usersSlice, user)
+	}
+	return &usersSlice, resultRows.Err()
+}
+
 func (userStore *UserStore) GetAllMatchedUsers(user *models.User) (users *[]models.User, err error) {
 	var usersSlice []models.User
 
-	resultRows, err := userStore.db.Query("SELECT nickname, fullname, about, email FROM users "+
+	resultRows, err := userStore.db.Query("SELECT nickname, fullname, about, email, reputation FROM users "+
 		"WHERE nickname = $1 OR email = $2;", user.Nickname, user.Email)
 	if err != nil {
 		return
@@ -50,7 +72,7 @@ func (userStore *UserStore) GetAllMatchedUsers(user *models.User) (users *[]mode
 
 	for resultRows.Next() {
 		user := models.User{}
-		err = resultRows.Scan(&user.Nickname, &user.Fullname, &user.About, &user.Email)
+		err = resultRows.Scan(&user.Nickname, &user.Fullname, &user.About, &user.Email, &user.Reputation)
 		if err != nil {
 			return
 		}
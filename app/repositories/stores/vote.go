@@ -3,6 +3,7 @@ package stores
 import (
 	"Technopark_DB_Project/app/models"
 	"Technopark_DB_Project/app/repositories"
+	"Technopark_DB_Project/pkg/errors"
 
 	"github.com/jackc/pgx"
 	_ "github.com/lib/pq"
@@ -22,3 +23,97 @@ func (voteStore *VoteStore) Vote(threadID int64, vote *models.Vote) (err error)
 		vote.Nickname, threadID, vote.Voice)
 	return
 }
+
+func (voteStore *VoteStore) VotePost(postID int64, vote *models.Vote) (err error) {
+	_, err = voteStore.db.Exec("INSERT INTO post_votes (nickname, post, voice) "+
+		"VALUES ($1, $2, $3) ON CONFLICT (nickname, post) DO UPDATE SET voice = EXCLUDED.voice;",
+		vote.Nickname, postID, vote.Voice)
+	return
+}
+
+// Delete returns ErrVoteNotFound when the user hasn't voted, other errors are the database ones
+func (voteStore *VoteStore) Delete(threadID int64, nickname string) (err error) {
+	commandTag, err := voteStore.db.Exec("DELETE FROM votes WHERE thread = $1 AND nickname = $2;", threadID, nickname)
+	if err != nil {
+		return
+	}
+	if commandTag.RowsAffected() == 0 {
+		err = errors.ErrVoteNotFound
+	}
+	return
+}
+
+func (voteStore *VoteStore) GetByThread(threadID int64, limit int, since string, desc bool) (votes *[]models.Vote, err error) {
+	var resultRows *pgx.Rows
+
+	query := "SELECT nickname, thread, voice FROM votes WHERE thread = $1"
+
+	if since != "" {
+		if desc {
+			query += " AND nickname < $2 ORDER BY nickname DESC"
+		} else {
+			query += " AND nickname > $2 ORDER BY nickname"
+		}
+		query += " LIMIT $3;"
+		resultRows, err = voteStore.db.Query(query, threadID, since, limit)
+	} else {
+		if desc {
+			query += " ORDER BY nickname DESC"
+		} else {
+			query += " ORDER BY nickname"
+		}
+		query += " LIMIT $2;"
+		resultRows, err = voteStore.db.Query(query, threadID, limit)
+	}
+
+	if err != nil {
+		return
+	}
+	defer resultRows.Close()
+
+	return scanVotes(resultRows)
+}
+
+func (voteStore *VoteStore) GetByUser(nickname string, limit int, since int64, desc bool) (votes *[]models.Vote, err error) {
+	var resultRows *pgx.Rows
+
+	query := "SELECT nickname, thread, voice FROM votes WHERE nickname = $1"
+
+	if since != -1 {
+		if desc {
+			query += " AND thread < $2 ORDER BY thread DESC"
+		} else {
+			query += " AND thread > $2 ORDER BY thread"
+		}
+		query += " LIMIT $3;"
+		resultRows, err = voteStore.db.Query(query, nickname, since, limit)
+	} else {
+		if desc {
+			query += " ORDER BY thread DESC"
+		} else {
+			query += " ORDER BY thread"
+		}
+		query += " LIMIT $2;"
+		resultRows, err = voteStore.db.Query(query, nickname, limit)
+	}
+
+	if err != nil {
+		return
+	}
+	defer resultRows.Close()
+
+	return scanVotes(resultRows)
+}
+
+func scanVotes(resultRows *pgx.Rows) (votes *[]models.Vote, err error) {
+	var votesSlice []models.Vote
+	for resultRows.Next() {
+		vote := models.Vote{}
+		err = resultRows.Scan(&vote.Nickname, &vote.Thread, &vote.Voice)
+		if err != nil {
+			return
+		}
+		votesSlice = append(votesSlice, vote)
+	}
+	return &votesSlice, resultRows.Err()
+}
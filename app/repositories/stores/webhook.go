@@ -0,0 +1,160 @@
+package stores
+
+import (
+	"Technopark_DB_Project/app/models"
+	"Technopark_DB_Project/app/repositories"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx"
+	_ "github.com/lib/pq"
+)
+
+type WebhookStore struct {
+	db *pgx.ConnPool
+}
+
+func CreateWebhookRepository(db *pgx.ConnPool) repositories.WebhookRepository {
+	return &WebhookStore{db: db}
+}
+
+func (webhookStore *WebhookStore) Create(webhook *models.Webhook) (err error) {
+	err = webhookStore.db.QueryRow("INSERT INTO webhooks (forum, url, secret, events) VALUES ($1, $2, $3, $4) "+
+		"RETURNING id, created;", webhook.Forum, webhook.URL, webhook.Secret, webhook.Events).
+		Scan(&webhook.ID, &webhook.Created)
+	return
+}
+
+func (webhookStore *WebhookStore) GetByID(id int64) (webhook *models.Webhook, err error) {
+	webhook = new(models.Webhook)
+	err = webhookStore.db.QueryRow("SELECT id, forum, url, secret, events, created FROM webhooks WHERE id = $1;", id).
+		Scan(&webhook.ID, &webhook.Forum, &webhook.URL, &webhook.Secret, &webhook.Events, &webhook.Created)
+	return
+}
+
+func (webhookStore *WebhookStore) GetByForum(slug string) (webhooks *[]models.Webhook, err error) {
+	var webhooksSlice []models.Webhook
+
+	resultRows, err := webhookStore.db.Query("SELECT id, forum, url, secret, events, created FROM webhooks "+
+		"WHERE forum = $1 ORDER BY id;", slug)
+	if err != nil {
+		return
+	}
+	defer resultRows.Close()
+
+	for resultRows.Next() {
+		webhook := models.Webhook{}
+		err = resultRows.Scan(&webhook.ID, &webhook.Forum, &webhook.URL, &webhook.Secret, &webhook.Events, &webhook.Created)
+		if err != nil {
+			return
+		}
+		webhooksSlice = append(webhooksSlice, webhook)
+	}
+	return &webhooksSlice, nil
+}
+
+func (webhookStore *WebhookStore) Delete(slug string, id int64) (err error) {
+	commandTag, err := webhookStore.db.Exec("DELETE FROM webhooks WHERE forum = $1 AND id = $2;", slug, id)
+	if err != nil {
+		return
+	}
+	if commandTag.RowsAffected() == 0 {
+		err = pgx.ErrNoRows
+	}
+	return
+}
+
+func (webhookStore *WebhookStore) GetDeliveries(id int64, limit int, since int64, desc bool) (deliveries *[]models.WebhookDelivery, err error) {
+	var deliveriesSlice []models.WebhookDelivery
+
+	query := "SELECT id, webhook, message, event, attempt, status_code, error, duration, created " +
+		"FROM webhook_deliveries WHERE webhook = $1"
+	args := []interface{}{id}
+
+	if since != -1 {
+		args = append(args, since)
+		if desc {
+			query += fmt.Sprintf(" AND id < $%d", len(args))
+		} else {
+			query += fmt.Sprintf(" AND id > $%d", len(args))
+		}
+	}
+	if desc {
+		query += " ORDER BY id DESC"
+	} else {
+		query += " ORDER BY id"
+	}
+	args = append(args, limit)
+	query += fmt.Sprintf(" LIMIT $%d;", len(args))
+
+	resultRows, err := webhookStore.db.Query(query, args...)
+	if err != nil {
+		return
+	}
+	defer resultRows.Close()
+
+	for resultRows.Next() {
+		delivery := models.WebhookDelivery{}
+		err = resultRows.Scan(&delivery.ID, &delivery.Webhook, &delivery.Message, &delivery.Event, &delivery.Attempt,
+			&delivery.StatusCode, &delivery.Error, &delivery.Duration, &delivery.Created)
+		if err != nil {
+			return
+		}
+		deliveriesSlice = append(deliveriesSlice, delivery)
+	}
+	return &deliveriesSlice, nil
+}
+
+func (webhookStore *WebhookStore) ClaimMessages(limit int, lease time.Duration) (messages *[]models.WebhookMessage, err error) {
+	var messagesSlice []models.WebhookMessage
+
+	// Claimed messages are hidden for the lease time, so a crashed worker only delays the delivery.
+	// SKIP LOCKED lets several API instances run workers side by side.
+	resultRows, err := webhookStore.db.Query("WITH claimed AS ("+
+		"UPDATE webhook_outbox SET attempts = attempts + 1, next_attempt = now() + $2::float8 * interval '1 second' "+
+		"WHERE id IN (SELECT id FROM webhook_outbox WHERE status = 'pending' AND next_attempt <= now() "+
+		"ORDER BY next_attempt, id LIMIT $1 FOR UPDATE SKIP LOCKED) "+
+		"RETURNING id, webhook, event, payload, attempts) "+
+		"SELECT claimed.id, claimed.webhook, webhooks.url, webhooks.secret, claimed.event, claimed.payload::text, claimed.attempts "+
+		"FROM claimed JOIN webhooks ON webhooks.id = claimed.webhook ORDER BY claimed.id;",
+		limit, lease.Seconds())
+	if err != nil {
+		return
+	}
+	defer resultRows.Close()
+
+	for resultRows.Next() {
+		message := models.WebhookMessage{}
+		var payload string
+		err = resultRows.Scan(&message.ID, &message.Webhook, &message.URL, &message.Secret, &message.Event,
+			&payload, &message.Attempts)
+		if err != nil {
+			return
+		}
+		message.Payload = []byte(payload)
+		messagesSlice = append(messagesSlice, message)
+	}
+	return &messagesSlice, nil
+}
+
+func (webhookStore *WebhookStore) LogDelivery(delivery *models.WebhookDelivery) (err error) {
+	err = webhookStore.db.QueryRow("INSERT INTO webhook_deliveries (webhook, message, event, attempt, status_code, error, duration) "+
+		"VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id, created;",
+		delivery.Webhook, delivery.Message, delivery.Event, delivery.Attempt, delivery.StatusCode, delivery.Error, delivery.Duration).
+		Scan(&delivery.ID, &delivery.Created)
+	return
+}
+
+func (webhookStore *WebhookStore) MarkDelivered(messageID int64) (err error) {
+	_, err = webhookStore.db.Exec("UPDATE webhook_outbox SET status = 'delivered' WHERE id = $1;", messageID)
+	return
+}
+
+func (webhookStore *WebhookStore) MarkFailed(messageID int64, nextAttempt time.Time, giveUp bool) (err error) {
+	if giveUp {
+		_, err = webhookStore.db.Exec("UPDATE webhook_outbox SET status = 'failed' WHERE id = $1;", messageID)
+		return
+	}
+	_, err = webhookStore.db.Exec("UPDATE webhook_outbox SET next_attempt = $2 WHERE id = $1;", messageID, nextAttempt)
+	return
+}
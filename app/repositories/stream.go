@@ -0,0 +1,7 @@
+package repositories
+
+import "context"
+
+type StreamRepository interface {
+	ListenPosts(ctx context.Context, handler func(threadID, postID int64)) (err error)
+}
@@ -0,0 +1,8 @@
+package repositories
+
+type SubscriptionRepository interface {
+	SubscribeThread(threadID int64, nickname string) (err error)
+	UnsubscribeThread(threadID int64, nickname string) (err error)
+	SubscribeForum(slug string, nickname string) (err error)
+	UnsubscribeForum(slug string, nickname string) (err error)
+}
@@ -5,12 +5,17 @@ import "Technopark_DB_Project/app/models"
 type ThreadRepository interface {
 	Create(thread *models.Thread) (err error)
 	GetByID(id int64) (thread *models.Thread, err error)
+	GetByIDs(ids []int64) (threads *[]models.Thread, err error)
 	GetBySlug(slug string) (thread *models.Thread, err error)
 	GetBySlugOrID(slugOrID string) (thread *models.Thread, err error)
+	GetWithForum(id int64, slug string) (thread *models.Thread, forum *models.Forum, err error)
+	GetByAuthor(nickname, forum string, limit int, since int64, desc bool) (threads *[]models.Thread, err error)
 	GetVotes(id int64) (votesAmount int32, err error)
 	Update(thread *models.Thread) (err error)
-	CreatePosts(thread *models.Thread, posts *models.Posts) (err error)
+	CreatePosts(thread *models.Thread, posts *models.Posts, notifyReplyAncestors bool) (err error)
 	GetPostsTree(threadID int64, limit, since int, desc bool) (posts *[]models.Post, err error)
 	GetPostsParentTree(threadID int64, limit, since int, desc bool) (posts *[]models.Post, err error)
 	GetPostsFlat(threadID int64, limit, since int, desc bool) (posts *[]models.Post, err error)
+	GetPostsTop(threadID int64, limit, since int, desc bool) (posts *[]models.Post, err error)
+	IteratePosts(threadID int64, sort string, limit, since int, desc bool, onPost func(post *models.Post) error) (err error)
 }
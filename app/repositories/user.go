@@ -6,5 +6,6 @@ type UserRepository interface {
 	Create(user *models.User) (err error)
 	Update(user *models.User) (err error)
 	GetByNickname(nickname string) (user *models.User, err error)
+	GetByNicknames(nicknames []string) (users *[]models.User, err error)
 	GetAllMatchedUsers(user *models.User) (users *[]models.User, err error)
 }
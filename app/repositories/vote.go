@@ -4,4 +4,8 @@ import "Technopark_DB_Project/app/models"
 
 type VoteRepository interface {
 	Vote(threadID int64, vote *models.Vote) (err error)
+	VotePost(postID int64, vote *models.Vote) (err error)
+	Delete(threadID int64, nickname string) (err error)
+	GetByThread(threadID int64, limit int, since string, desc bool) (votes *[]models.Vote, err error)
+	GetByUser(nickname string, limit int, since int64, desc bool) (votes *[]models.Vote, err error)
 }
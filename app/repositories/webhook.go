@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"Technopark_DB_Project/app/models"
+	"time"
+)
+
+type WebhookRepository interface {
+	Create(webhook *models.Webhook) (err error)
+	GetByID(id int64) (webhook *models.Webhook, err error)
+	GetByForum(slug string) (webhooks *[]models.Webhook, err error)
+	Delete(slug string, id int64) (err error)
+	GetDeliveries(id int64, limit int, since int64, desc bool) (deliveries *[]models.WebhookDelivery, err error)
+	ClaimMessages(limit int, lease time.Duration) (messages *[]models.WebhookMessage, err error)
+	LogDelivery(delivery *models.WebhookDelivery) (err error)
+	MarkDelivered(messageID int64) (err error)
+	MarkFailed(messageID int64, nextAttempt time.Time, giveUp bool) (err error)
+}
@@ -0,0 +1,91 @@
+package rpc
+
+import (
+	"Technopark_DB_Project/pkg/errors"
+	"context"
+	"fmt"
+	"net/http"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/runtime/protoiface"
+)
+
+var httpToCode = map[int]codes.Code{
+	http.StatusBadRequest:           codes.InvalidArgument,
+	http.StatusForbidden:            codes.PermissionDenied,
+	http.StatusNotFound:             codes.NotFound,
+	http.StatusConflict:             codes.AlreadyExists,
+	http.StatusUnsupportedMediaType: codes.InvalidArgument,
+	http.StatusNotImplemented:       codes.Unimplemented,
+	http.StatusInternalServerError:  codes.Internal,
+}
+
+// Errors whose HTTP code is ambiguous get their own status code
+var errorToCode = map[error]codes.Code{
+	errors.ErrParentPostFromOtherThread: codes.FailedPrecondition,
+}
+
+// toStatus converts pkg/errors errors into gRPC statuses, validation errors carry field violations
+func toStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, isStatus := status.FromError(err); isStatus {
+		return err
+	}
+
+	code, isFound := errorToCode[err]
+	if !isFound {
+		if code, isFound = httpToCode[errors.ResolveErrorToCode(err)]; !isFound {
+			code = codes.Unknown
+		}
+	}
+	errorStatus := status.New(code, err.Error())
+
+	if validationError, isValidationError := err.(*errors.ValidationError); isValidationError {
+		badRequest := new(errdetails.BadRequest)
+		for _, field := range validationError.Fields {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       field.Field,
+				Description: field.Message,
+			})
+		}
+		if detailedStatus, errDetails := errorStatus.WithDetails(badRequest); errDetails == nil {
+			errorStatus = detailedStatus
+		}
+	}
+	return errorStatus.Err()
+}
+
+// conflictStatus returns the conflict error with the existing data attached as a detail, like HTTP 409 bodies
+func conflictStatus(err error, existing protoiface.MessageV1) error {
+	errorStatus := status.New(codes.AlreadyExists, err.Error())
+	if detailedStatus, errDetails := errorStatus.WithDetails(existing); errDetails == nil {
+		errorStatus = detailedStatus
+	}
+	return errorStatus.Err()
+}
+
+func unaryErrorInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			fmt.Println(info.FullMethod, recovered)
+			err = toStatus(errors.ErrInternal)
+		}
+	}()
+	resp, err = handler(ctx, req)
+	return resp, toStatus(err)
+}
+
+func streamErrorInterceptor(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			fmt.Println(info.FullMethod, recovered)
+			err = toStatus(errors.ErrInternal)
+		}
+	}()
+	return toStatus(handler(srv, stream))
+}
@@ -0,0 +1,92 @@
+package rpc
+
+import (
+	"Technopark_DB_Project/app/models"
+	"Technopark_DB_Project/app/models/pb"
+	"Technopark_DB_Project/app/usecases"
+	"Technopark_DB_Project/pkg/errors"
+	"Technopark_DB_Project/pkg/validator"
+	"context"
+	"net/http"
+)
+
+type ForumServer struct {
+	pb.UnimplementedForumServiceServer
+	MaxLimit     int
+	ForumUseCase usecases.ForumUseCase
+}
+
+func (forumServer *ForumServer) Create(ctx context.Context, req *pb.Forum) (*pb.Forum, error) {
+	forum := new(models.Forum)
+	if err := pb.FromMessage(req, forum); err != nil {
+		return nil, errors.ErrBadRequest
+	}
+	if err := validator.ValidateForumData(forum); err != nil {
+		return nil, err
+	}
+
+	err := forumServer.ForumUseCase.CreateForum(forum)
+	if err != nil {
+		if errors.ResolveErrorToCode(err) == http.StatusConflict {
+			return nil, conflictStatus(err, pb.ToMessage(forum).(*pb.Forum))
+		}
+		return nil, err
+	}
+
+	return pb.ToMessage(forum).(*pb.Forum), nil
+}
+
+func (forumServer *ForumServer) Get(ctx context.Context, req *pb.GetForumRequest) (*pb.Forum, error) {
+	forum, err := forumServer.ForumUseCase.Get(req.GetSlug())
+	if err != nil {
+		return nil, err
+	}
+
+	return pb.ToMessage(forum).(*pb.Forum), nil
+}
+
+func (forumServer *ForumServer) CreateThread(ctx context.Context, req *pb.CreateThreadRequest) (*pb.Thread, error) {
+	thread := new(models.Thread)
+	if req.GetThread() != nil {
+		if err := pb.FromMessage(req.GetThread(), thread); err != nil {
+			return nil, errors.ErrBadRequest
+		}
+	}
+	thread.Forum = req.GetForum()
+	if err := validator.ValidateThreadData(thread, false); err != nil {
+		return nil, err
+	}
+
+	err := forumServer.ForumUseCase.CreateThread(thread)
+	if err != nil {
+		if errors.ResolveErrorToCode(err) == http.StatusConflict {
+			return nil, conflictStatus(err, pb.ToMessage(thread).(*pb.Thread))
+		}
+		return nil, err
+	}
+
+	return pb.ToMessage(thread).(*pb.Thread), nil
+}
+
+func (forumServer *ForumServer) GetUsers(ctx context.Context, req *pb.GetForumUsersRequest) (*pb.Users, error) {
+	limit, desc := pageParams(req.GetPage(), forumServer.MaxLimit)
+	sort := req.GetSort()
+	if sort == "" {
+		sort = "nickname"
+	}
+
+	users, err := forumServer.ForumUseCase.GetUsers(req.GetSlug(), limit, req.GetSince(), sort, desc)
+	if err != nil {
+		return nil, err
+	}
+
+	return pb.ToMessage(users).(*pb.Users), nil
+}
+
+func (forumServer *ForumServer) GetThreads(req *pb.GetForumThreadsRequest, stream pb.ForumService_GetThreadsServer) error {
+	limit, desc := pageParams(req.GetPage(), forumServer.MaxLimit)
+
+	return forumServer.ForumUseCase.IterateThreads(req.GetSlug(), limit, req.GetSince(), desc, func(thread *models.Thread) error {
+		return stream.Send(pb.ToMessage(thread).(*pb.Thread))
+	})
+}
@@ -0,0 +1,66 @@
+package rpc
+
+import (
+	"Technopark_DB_Project/app/models"
+	"Technopark_DB_Project/app/models/pb"
+	"Technopark_DB_Project/app/usecases"
+	"Technopark_DB_Project/pkg/expand"
+	"Technopark_DB_Project/pkg/validator"
+	"context"
+)
+
+type PostServer struct {
+	pb.UnimplementedPostServiceServer
+	PostUseCase usecases.PostUseCase
+}
+
+func (postServer *PostServer) Get(ctx context.Context, req *pb.GetPostRequest) (*pb.PostFull, error) {
+	paths, err := expand.FromAliases("related", req.GetRelated(), models.PostRelated)
+	if err != nil {
+		return nil, err
+	}
+	expansion, err := expand.Parse("related", paths, models.PostExpansions)
+	if err != nil {
+		return nil, err
+	}
+
+	postFull, err := postServer.PostUseCase.Get(req.GetId(), expansion)
+	if err != nil {
+		return nil, err
+	}
+
+	return pb.ToMessage(postFull).(*pb.PostFull), nil
+}
+
+func (postServer *PostServer) Update(ctx context.Context, req *pb.UpdatePostRequest) (*pb.Post, error) {
+	post := &models.Post{
+		ID:      req.GetId(),
+		Message: req.GetPost().GetMessage(),
+	}
+	if err := validator.ValidatePostData(post, true); err != nil {
+		return nil, err
+	}
+
+	if err := postServer.PostUseCase.Update(post); err != nil {
+		return nil, err
+	}
+
+	return pb.ToMessage(post).(*pb.Post), nil
+}
+
+func (postServer *PostServer) Vote(ctx context.Context, req *pb.VotePostRequest) (*pb.Post, error) {
+	vote := &models.Vote{
+		Nickname: req.GetVote().GetNickname(),
+		Voice:    req.GetVote().GetVoice(),
+	}
+	if err := validator.ValidateVoteData(vote); err != nil {
+		return nil, err
+	}
+
+	post, err := postServer.PostUseCase.Vote(req.GetId(), vote)
+	if err != nil {
+		return nil, err
+	}
+
+	return pb.ToMessage(post).(*pb.Post), nil
+}
@@ -0,0 +1,51 @@
+package rpc
+
+import (
+	"Technopark_DB_Project/app/models/pb"
+	"Technopark_DB_Project/app/usecases"
+
+	"google.golang.org/grpc"
+)
+
+const defaultLimit = 100
+
+// CreateServer registers gRPC counterparts of the HTTP handlers on top of the same usecases
+func CreateServer(maxLimit int, userUseCase usecases.UserUseCase, forumUseCase usecases.ForumUseCase,
+	threadUseCase usecases.ThreadUseCase, postUseCase usecases.PostUseCase, serviceUseCase usecases.ServiceUseCase) *grpc.Server {
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(unaryErrorInterceptor),
+		grpc.StreamInterceptor(streamErrorInterceptor),
+	)
+
+	pb.RegisterUserServiceServer(server, &UserServer{MaxLimit: maxLimit, UserUseCase: userUseCase})
+	pb.RegisterForumServiceServer(server, &ForumServer{MaxLimit: maxLimit, ForumUseCase: forumUseCase})
+	pb.RegisterThreadServiceServer(server, &ThreadServer{MaxLimit: maxLimit, ThreadUseCase: threadUseCase})
+	pb.RegisterPostServiceServer(server, &PostServer{PostUseCase: postUseCase})
+	pb.RegisterServiceServiceServer(server, &ServiceServer{ServiceUseCase: serviceUseCase})
+
+	return server
+}
+
+// pageParams applies the same defaults and limits as the query parameters of the HTTP API
+func pageParams(page *pb.ListRequest, maxLimit int) (limit int, desc bool) {
+	limit = int(page.GetLimit())
+	if limit == 0 {
+		limit = defaultLimit
+	}
+	return clampLimit(limit, maxLimit), page.GetDesc()
+}
+
+// sinceID maps an unset since to -1, meaning the start of the list
+func sinceID(since *int64) int64 {
+	if since == nil {
+		return -1
+	}
+	return *since
+}
+
+func clampLimit(limit, maxLimit int) int {
+	if limit <= 0 || limit > maxLimit {
+		return maxLimit
+	}
+	return limit
+}
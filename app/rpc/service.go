@@ -0,0 +1,29 @@
+package rpc
+
+import (
+	"Technopark_DB_Project/app/models/pb"
+	"Technopark_DB_Project/app/usecases"
+	"context"
+)
+
+type ServiceServer struct {
+	pb.UnimplementedServiceServiceServer
+	ServiceUseCase usecases.ServiceUseCase
+}
+
+func (serviceServer *ServiceServer) Clear(ctx context.Context, req *pb.Empty) (*pb.Empty, error) {
+	if err := serviceServer.ServiceUseCase.Clear(); err != nil {
+		return nil, err
+	}
+
+	return new(pb.Empty), nil
+}
+
+func (serviceServer *ServiceServer) GetStatus(ctx context.Context, req *pb.Empty) (*pb.Status, error) {
+	status, err := serviceServer.ServiceUseCase.GetStatus()
+	if err != nil {
+		return nil, err
+	}
+
+	return pb.ToMessage(status).(*pb.Status), nil
+}
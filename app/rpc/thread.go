@@ -0,0 +1,105 @@
+package rpc
+
+import (
+	"Technopark_DB_Project/app/models"
+	"Technopark_DB_Project/app/models/pb"
+	"Technopark_DB_Project/app/usecases"
+	"Technopark_DB_Project/pkg/validator"
+	"context"
+)
+
+type ThreadServer struct {
+	pb.UnimplementedThreadServiceServer
+	MaxLimit      int
+	ThreadUseCase usecases.ThreadUseCase
+}
+
+func (threadServer *ThreadServer) CreatePosts(ctx context.Context, req *pb.CreatePostsRequest) (*pb.Posts, error) {
+	posts := new(models.Posts)
+	if err := pb.FromMessage(&pb.Posts{Posts: req.GetPosts()}, posts); err != nil {
+		return nil, err
+	}
+	if err := validator.ValidatePostsData(posts); err != nil {
+		return nil, err
+	}
+
+	if err := threadServer.ThreadUseCase.CreatePosts(req.GetSlugOrId(), posts); err != nil {
+		return nil, err
+	}
+
+	return pb.ToMessage(posts).(*pb.Posts), nil
+}
+
+func (threadServer *ThreadServer) Get(ctx context.Context, req *pb.GetThreadRequest) (*pb.Thread, error) {
+	thread, err := threadServer.ThreadUseCase.Get(req.GetSlugOrId())
+	if err != nil {
+		return nil, err
+	}
+
+	return pb.ToMessage(thread).(*pb.Thread), nil
+}
+
+func (threadServer *ThreadServer) Update(ctx context.Context, req *pb.UpdateThreadRequest) (*pb.Thread, error) {
+	thread := &models.Thread{
+		Title:   req.GetThread().GetTitle(),
+		Message: req.GetThread().GetMessage(),
+	}
+	if err := validator.ValidateThreadData(thread, true); err != nil {
+		return nil, err
+	}
+
+	if err := threadServer.ThreadUseCase.Update(req.GetSlugOrId(), thread); err != nil {
+		return nil, err
+	}
+
+	return pb.ToMessage(thread).(*pb.Thread), nil
+}
+
+func (threadServer *ThreadServer) GetPosts(req *pb.GetThreadPostsRequest, stream pb.ThreadService_GetPostsServer) error {
+	limit, desc := pageParams(req.GetPage(), threadServer.MaxLimit)
+	sort := req.GetSort()
+	if sort == "" {
+		sort = "flat"
+	}
+
+	return threadServer.ThreadUseCase.IteratePosts(req.GetSlugOrId(), limit, int(sinceID(req.Since)), sort, desc, func(post *models.Post) error {
+		return stream.Send(pb.ToMessage(post).(*pb.Post))
+	})
+}
+
+func (threadServer *ThreadServer) Vote(ctx context.Context, req *pb.VoteRequest) (*pb.Thread, error) {
+	vote := &models.Vote{
+		Nickname: req.GetVote().GetNickname(),
+		Voice:    req.GetVote().GetVoice(),
+	}
+	if err := validator.ValidateVoteData(vote); err != nil {
+		return nil, err
+	}
+
+	thread, err := threadServer.ThreadUseCase.Vote(req.GetSlugOrId(), vote)
+	if err != nil {
+		return nil, err
+	}
+
+	return pb.ToMessage(thread).(*pb.Thread), nil
+}
+
+func (threadServer *ThreadServer) Unvote(ctx context.Context, req *pb.UnvoteRequest) (*pb.Thread, error) {
+	thread, err := threadServer.ThreadUseCase.Unvote(req.GetSlugOrId(), req.GetNickname())
+	if err != nil {
+		return nil, err
+	}
+
+	return pb.ToMessage(thread).(*pb.Thread), nil
+}
+
+func (threadServer *ThreadServer) GetVotes(ctx context.Context, req *pb.GetThreadVotesRequest) (*pb.Votes, error) {
+	limit, desc := pageParams(req.GetPage(), threadServer.MaxLimit)
+
+	votes, err := threadServer.ThreadUseCase.GetVotes(req.GetSlugOrId(), limit, req.GetSince(), desc)
+	if err != nil {
+		return nil, err
+	}
+
+	return pb.ToMessage(votes).(*pb.Votes), nil
+}
@@ -0,0 +1,110 @@
+package rpc
+
+import (
+	"Technopark_DB_Project/app/models"
+	"Technopark_DB_Project/app/models/pb"
+	"Technopark_DB_Project/app/usecases"
+	"Technopark_DB_Project/pkg/errors"
+	"Technopark_DB_Project/pkg/validator"
+	"context"
+	"net/http"
+)
+
+type UserServer struct {
+	pb.UnimplementedUserServiceServer
+	MaxLimit    int
+	UserUseCase usecases.UserUseCase
+}
+
+func (userServer *UserServer) Create(ctx context.Context, req *pb.CreateUserRequest) (*pb.User, error) {
+	user := &models.User{
+		Nickname: req.GetNickname(),
+		Fullname: req.GetUser().GetFullname(),
+		About:    req.GetUser().GetAbout(),
+		Email:    req.GetUser().GetEmail(),
+	}
+	if err := validator.ValidateUserData(user, false); err != nil {
+		return nil, err
+	}
+
+	users, err := userServer.UserUseCase.Create(user)
+	if err != nil {
+		if errors.ResolveErrorToCode(err) == http.StatusConflict {
+			return nil, conflictStatus(err, pb.ToMessage(users).(*pb.Users))
+		}
+		return nil, err
+	}
+
+	return pb.ToMessage(user).(*pb.User), nil
+}
+
+func (userServer *UserServer) Get(ctx context.Context, req *pb.GetUserRequest) (*pb.User, error) {
+	user, err := userServer.UserUseCase.Get(req.GetNickname())
+	if err != nil {
+		return nil, err
+	}
+
+	return pb.ToMessage(user).(*pb.User), nil
+}
+
+func (userServer *UserServer) Update(ctx context.Context, req *pb.UpdateUserRequest) (*pb.User, error) {
+	user := &models.User{
+		Nickname: req.GetNickname(),
+		Fullname: req.GetUser().GetFullname(),
+		About:    req.GetUser().GetAbout(),
+		Email:    req.GetUser().GetEmail(),
+	}
+	if err := validator.ValidateUserData(user, true); err != nil {
+		return nil, err
+	}
+
+	if err := userServer.UserUseCase.Update(user); err != nil {
+		return nil, err
+	}
+
+	return pb.ToMessage(user).(*pb.User), nil
+}
+
+func (userServer *UserServer) GetVotes(ctx context.Context, req *pb.GetUserListRequest) (*pb.Votes, error) {
+	limit, desc := pageParams(req.GetPage(), userServer.MaxLimit)
+
+	votes, err := userServer.UserUseCase.GetVotes(req.GetNickname(), limit, sinceID(req.Since), desc)
+	if err != nil {
+		return nil, err
+	}
+
+	return pb.ToMessage(votes).(*pb.Votes), nil
+}
+
+func (userServer *UserServer) GetPosts(ctx context.Context, req *pb.GetUserPostsRequest) (*pb.Posts, error) {
+	limit, desc := pageParams(req.GetPage(), userServer.MaxLimit)
+
+	posts, err := userServer.UserUseCase.GetPosts(req.GetNickname(), req.GetForum(), limit, sinceID(req.Since), desc)
+	if err != nil {
+		return nil, err
+	}
+
+	return pb.ToMessage(posts).(*pb.Posts), nil
+}
+
+func (userServer *UserServer) GetThreads(ctx context.Context, req *pb.GetUserThreadsRequest) (*pb.Threads, error) {
+	limit, desc := pageParams(req.GetPage(), userServer.MaxLimit)
+
+	threads, err := userServer.UserUseCase.GetThreads(req.GetNickname(), req.GetForum(), limit, sinceID(req.Since), desc)
+	if err != nil {
+		return nil, err
+	}
+
+	return pb.ToMessage(threads).(*pb.Threads), nil
+}
+
+func (userServer *UserServer) GetMentions(ctx context.Context, req *pb.GetUserListRequest) (*pb.Posts, error) {
+	limit, desc := pageParams(req.GetPage(), userServer.MaxLimit)
+
+	posts, err := userServer.UserUseCase.GetMentions(req.GetNickname(), limit, sinceID(req.Since), desc)
+	if err != nil {
+		return nil, err
+	}
+
+	return pb.ToMessage(posts).(*pb.Posts), nil
+}
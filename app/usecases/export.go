@@ -0,0 +1,7 @@
+package usecases
+
+import "io"
+
+type ExportUseCase interface {
+	ExportForum(slug, format string, writer io.Writer) (err error)
+}
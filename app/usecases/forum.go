@@ -1,11 +1,19 @@
 package usecases
 
-import "Technopark_DB_Project/app/models"
+import (
+	"Technopark_DB_Project/app/models"
+	"Technopark_DB_Project/pkg/expand"
+)
 
 type ForumUseCase interface {
 	CreateForum(forum *models.Forum) (err error)
 	Get(slug string) (forum *models.Forum, err error)
+	GetFull(slug string, expansion expand.Tree) (forumFull *models.ForumFull, err error)
+	GetBySlugs(slugs []string) (forums *models.Forums, err error)
 	CreateThread(thread *models.Thread) (err error)
-	GetUsers(slug string, limit int, since string, desc bool) (users *models.Users, err error)
+	GetUsers(slug string, limit int, since, sort string, desc bool) (users *models.Users, err error)
 	GetThreads(slug string, limit int, since string, desc bool) (threads *models.Threads, err error)
+	IterateThreads(slug string, limit int, since string, desc bool, onThread func(thread *models.Thread) error) (err error)
+	Subscribe(slug, nickname string) (subscription *models.Subscription, err error)
+	Unsubscribe(slug, nickname string) (err error)
 }
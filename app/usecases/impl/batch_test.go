@@ -0,0 +1,98 @@
+package impl
+
+import (
+	"Technopark_DB_Project/app/models"
+	"Technopark_DB_Project/app/repositories"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type threadRepositoryMock struct {
+	repositories.ThreadRepository
+	threads []models.Thread
+}
+
+func (mock *threadRepositoryMock) GetByIDs(ids []int64) (*[]models.Thread, error) {
+	threads := []models.Thread{}
+	for _, thread := range mock.threads {
+		for _, id := range ids {
+			if thread.ID == id {
+				threads = append(threads, thread)
+				break
+			}
+		}
+	}
+	return &threads, nil
+}
+
+type userRepositoryMock struct {
+	repositories.UserRepository
+	users []models.User
+}
+
+func (mock *userRepositoryMock) GetByNicknames(nicknames []string) (*[]models.User, error) {
+	users := []models.User{}
+	for _, user := range mock.users {
+		for _, nickname := range nicknames {
+			if strings.EqualFold(user.Nickname, nickname) {
+				users = append(users, user)
+				break
+			}
+		}
+	}
+	return &users, nil
+}
+
+func TestThreadGetBatch(t *testing.T) {
+	threadUseCase := &ThreadUseCaseImpl{threadRepository: &threadRepositoryMock{
+		threads: []models.Thread{{ID: 1}, {ID: 2}, {ID: 3}},
+	}}
+
+	tests := []struct {
+		name         string
+		ids          []int64
+		wantIDs      []int64
+		wantNotFound []int64
+	}{
+		{name: "request order", ids: []int64{3, 1, 2}, wantIDs: []int64{3, 1, 2}, wantNotFound: []int64{}},
+		{name: "duplicates", ids: []int64{2, 2, 1, 2}, wantIDs: []int64{2, 1}, wantNotFound: []int64{}},
+		{name: "missing", ids: []int64{4, 1, 5, 4}, wantIDs: []int64{1}, wantNotFound: []int64{4, 5}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			batch, err := threadUseCase.GetBatch(test.ids)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ids := []int64{}
+			for _, thread := range batch.Threads {
+				ids = append(ids, thread.ID)
+			}
+			if !reflect.DeepEqual(ids, test.wantIDs) || !reflect.DeepEqual(batch.NotFound, test.wantNotFound) {
+				t.Fatalf("got %v and not found %v, want %v and %v", ids, batch.NotFound, test.wantIDs, test.wantNotFound)
+			}
+		})
+	}
+}
+
+func TestUserGetBatch(t *testing.T) {
+	userUseCase := &UserUseCaseImpl{userRepository: &userRepositoryMock{
+		users: []models.User{{Nickname: "Alice"}, {Nickname: "bob"}},
+	}}
+
+	batch, err := userUseCase.GetBatch([]string{"BOB", "ghost", "alice", "Bob", "Ghost"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	nicknames := []string{}
+	for _, user := range batch.Users {
+		nicknames = append(nicknames, user.Nickname)
+	}
+	if want := []string{"bob", "Alice"}; !reflect.DeepEqual(nicknames, want) {
+		t.Errorf("users = %v, want %v", nicknames, want)
+	}
+	if want := []string{"ghost"}; !reflect.DeepEqual(batch.NotFound, want) {
+		t.Errorf("not found = %v, want %v", batch.NotFound, want)
+	}
+}
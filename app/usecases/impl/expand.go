@@ -0,0 +1,73 @@
+package impl
+
+import (
+	"Technopark_DB_Project/app/models"
+	"Technopark_DB_Project/app/repositories"
+	"Technopark_DB_Project/pkg/errors"
+	"Technopark_DB_Project/pkg/expand"
+	"strings"
+)
+
+// userLookup collects the users referred to by expanded data, so they are fetched in one query
+// however many paths ask for them
+type userLookup struct {
+	nicknames []string
+	targets   map[string][]**models.User
+}
+
+func newUserLookup() *userLookup {
+	return &userLookup{targets: make(map[string][]**models.User)}
+}
+
+func (lookup *userLookup) Add(nickname string, target **models.User) {
+	key := strings.ToLower(nickname)
+	if _, isFound := lookup.targets[key]; !isFound {
+		lookup.nicknames = append(lookup.nicknames, nickname)
+	}
+	lookup.targets[key] = append(lookup.targets[key], target)
+}
+
+func (lookup *userLookup) Resolve(userRepository repositories.UserRepository) (err error) {
+	if len(lookup.nicknames) == 0 {
+		return
+	}
+	users, err := userRepository.GetByNicknames(lookup.nicknames)
+	if err != nil {
+		return
+	}
+	for i := range *users {
+		for _, target := range lookup.targets[strings.ToLower((*users)[i].Nickname)] {
+			user := (*users)[i]
+			*target = &user
+		}
+	}
+	if len(*users) < len(lookup.nicknames) {
+		err = errors.ErrUserNotFound
+	}
+	return
+}
+
+func newForumFull(forum *models.Forum, expansion expand.Tree, users *userLookup) *models.ForumFull {
+	forumFull := &models.ForumFull{Forum: *forum}
+	if expansion.Has("user") {
+		forumFull.Expanded = &models.ForumExpanded{}
+		users.Add(forum.User, &forumFull.Expanded.User)
+	}
+	return forumFull
+}
+
+// newThreadFull expects forum to be fetched if the expansion has it
+func newThreadFull(thread *models.Thread, forum *models.Forum, expansion expand.Tree, users *userLookup) *models.ThreadFull {
+	threadFull := &models.ThreadFull{Thread: *thread}
+	if len(expansion) == 0 {
+		return threadFull
+	}
+	threadFull.Expanded = &models.ThreadExpanded{}
+	if expansion.Has("author") {
+		users.Add(thread.Author, &threadFull.Expanded.Author)
+	}
+	if expansion.Has("forum") {
+		threadFull.Expanded.Forum = newForumFull(forum, expansion.Sub("forum"), users)
+	}
+	return threadFull
+}
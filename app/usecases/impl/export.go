@@ -0,0 +1,41 @@
+package impl
+
+import (
+	"Technopark_DB_Project/app/repositories"
+	"Technopark_DB_Project/app/usecases"
+	"Technopark_DB_Project/pkg/archive"
+	"Technopark_DB_Project/pkg/errors"
+	"io"
+)
+
+type ExportUseCaseImpl struct {
+	forumRepository  repositories.ForumRepository
+	exportRepository repositories.ExportRepository
+}
+
+func CreateExportUseCase(forumRepository repositories.ForumRepository, exportRepository repositories.ExportRepository) usecases.ExportUseCase {
+	return &ExportUseCaseImpl{forumRepository: forumRepository, exportRepository: exportRepository}
+}
+
+func (exportUseCase *ExportUseCaseImpl) ExportForum(slug, format string, writer io.Writer) (err error) {
+	archiveWriter, err := archive.NewWriter(format, writer)
+	if err != nil {
+		return
+	}
+
+	forum, err := exportUseCase.forumRepository.GetBySlug(slug)
+	if err != nil {
+		err = errors.ErrForumNotExist
+		return
+	}
+
+	if err = archiveWriter.WriteForum(forum); err != nil {
+		return
+	}
+	err = exportUseCase.exportRepository.ExportForum(forum.Slug, archiveWriter.WriteThread, archiveWriter.WritePost)
+	if err != nil {
+		return
+	}
+
+	return archiveWriter.Close()
+}
@@ -5,16 +5,32 @@ import (
 	"Technopark_DB_Project/app/repositories"
 	"Technopark_DB_Project/app/usecases"
 	"Technopark_DB_Project/pkg/errors"
+	"Technopark_DB_Project/pkg/expand"
 )
 
 type ForumUseCaseImpl struct {
-	forumRepository  repositories.ForumRepository
-	threadRepository repositories.ThreadRepository
-	userRepository   repositories.UserRepository
+	forumRepository        repositories.ForumRepository
+	threadRepository       repositories.ThreadRepository
+	userRepository         repositories.UserRepository
+	subscriptionRepository repositories.SubscriptionRepository
+
+	liveUseCase usecases.LiveUseCase
 }
 
-func CreateForumUseCase(forumRepository repositories.ForumRepository, threadRepository repositories.ThreadRepository, userRepository repositories.UserRepository) usecases.ForumUseCase {
-	return &ForumUseCaseImpl{forumRepository: forumRepository, threadRepository: threadRepository, userRepository: userRepository}
+func CreateForumUseCase(
+	forumRepository repositories.ForumRepository,
+	threadRepository repositories.ThreadRepository,
+	userRepository repositories.UserRepository,
+	subscriptionRepository repositories.SubscriptionRepository,
+	liveUseCase usecases.LiveUseCase,
+) usecases.ForumUseCase {
+	return &ForumUseCaseImpl{
+		forumRepository:        forumRepository,
+		threadRepository:       threadRepository,
+		userRepository:         userRepository,
+		subscriptionRepository: subscriptionRepository,
+		liveUseCase:            liveUseCase,
+	}
 }
 
 func (forumUseCase *ForumUseCaseImpl) CreateForum(forum *models.Forum) (err error) {
@@ -44,6 +60,32 @@ func (forumUseCase *ForumUseCaseImpl) Get(slug string) (forum *models.Forum, err
 	return
 }
 
+// GetFull fetches the forum with the data of expansion
+func (forumUseCase *ForumUseCaseImpl) GetFull(slug string, expansion expand.Tree) (forumFull *models.ForumFull, err error) {
+	forum, err := forumUseCase.Get(slug)
+	if err != nil {
+		return
+	}
+
+	users := newUserLookup()
+	forumFull = newForumFull(forum, expansion, users)
+	if err = users.Resolve(forumUseCase.userRepository); err != nil {
+		forumFull = nil
+	}
+	return
+}
+
+// GetBySlugs returns the forums found, missing slugs are just left out
+func (forumUseCase *ForumUseCaseImpl) GetBySlugs(slugs []string) (forums *models.Forums, err error) {
+	forumsSlice, err := forumUseCase.forumRepository.GetBySlugs(slugs)
+	if err != nil {
+		return
+	}
+	forums = new(models.Forums)
+	*forums = *forumsSlice
+	return
+}
+
 func (forumUseCase *ForumUseCaseImpl) CreateThread(thread *models.Thread) (err error) {
 	forum, err := forumUseCase.forumRepository.GetBySlug(thread.Forum)
 	if err != nil {
@@ -66,17 +108,29 @@ func (forumUseCase *ForumUseCaseImpl) CreateThread(thread *models.Thread) (err e
 
 	thread.Forum = forum.Slug
 	err = forumUseCase.threadRepository.Create(thread)
+	if err != nil {
+		return
+	}
+
+	threadCopy := *thread
+	forumUseCase.liveUseCase.Publish(models.Event{Type: models.EventThreadCreated, Forum: thread.Forum, Thread: &threadCopy})
 	return
 }
 
-func (forumUseCase *ForumUseCaseImpl) GetUsers(slug string, limit int, since string, desc bool) (users *models.Users, err error) {
+func (forumUseCase *ForumUseCaseImpl) GetUsers(slug string, limit int, since, sort string, desc bool) (users *models.Users, err error) {
 	_, err = forumUseCase.forumRepository.GetBySlug(slug)
 	if err != nil {
 		err = errors.ErrForumNotExist
 		return
 	}
 
-	usersSlice, err := forumUseCase.forumRepository.GetUsers(slug, limit, since, desc)
+	var usersSlice *[]models.User
+	switch sort {
+	case "reputation":
+		usersSlice, err = forumUseCase.forumRepository.GetUsersByReputation(slug, limit, since, desc)
+	default:
+		usersSlice, err = forumUseCase.forumRepository.GetUsers(slug, limit, since, desc)
+	}
 	if err != nil {
 		return
 	}
@@ -110,3 +164,44 @@ func (forumUseCase *ForumUseCaseImpl) GetThreads(slug string, limit int, since s
 
 	return
 }
+
+func (forumUseCase *ForumUseCaseImpl) IterateThreads(slug string, limit int, since string, desc bool, onThread func(thread *models.Thread) error) (err error) {
+	forum, err := forumUseCase.forumRepository.GetBySlug(slug)
+	if err != nil {
+		err = errors.ErrForumNotExist
+		return
+	}
+
+	return forumUseCase.forumRepository.IterateThreads(forum.Slug, limit, since, desc, onThread)
+}
+
+func (forumUseCase *ForumUseCaseImpl) Subscribe(slug, nickname string) (subscription *models.Subscription, err error) {
+	forum, err := forumUseCase.forumRepository.GetBySlug(slug)
+	if err != nil {
+		err = errors.ErrForumNotExist
+		return
+	}
+
+	err = forumUseCase.subscriptionRepository.SubscribeForum(forum.Slug, nickname)
+	if err != nil {
+		err = errors.ErrUserNotFound
+		return
+	}
+
+	subscription = &models.Subscription{Nickname: nickname, Forum: forum.Slug}
+	return
+}
+
+func (forumUseCase *ForumUseCaseImpl) Unsubscribe(slug, nickname string) (err error) {
+	forum, err := forumUseCase.forumRepository.GetBySlug(slug)
+	if err != nil {
+		err = errors.ErrForumNotExist
+		return
+	}
+
+	err = forumUseCase.subscriptionRepository.UnsubscribeForum(forum.Slug, nickname)
+	if err != nil {
+		err = errors.ErrSubscriptionNotFound
+	}
+	return
+}
@@ -0,0 +1,80 @@
+package impl
+
+import (
+	"Technopark_DB_Project/app/models"
+	"Technopark_DB_Project/app/repositories"
+	"Technopark_DB_Project/app/usecases"
+	"Technopark_DB_Project/pkg/errors"
+	"context"
+	"strings"
+	"sync"
+)
+
+type LiveUseCaseImpl struct {
+	forumRepository repositories.ForumRepository
+
+	bufferSize int
+
+	mutex       sync.Mutex
+	subscribers map[string]map[chan models.Event]bool
+}
+
+func CreateLiveUseCase(forumRepository repositories.ForumRepository, bufferSize int) usecases.LiveUseCase {
+	return &LiveUseCaseImpl{
+		forumRepository: forumRepository,
+		bufferSize:      bufferSize,
+		subscribers:     make(map[string]map[chan models.Event]bool),
+	}
+}
+
+func (liveUseCase *LiveUseCaseImpl) Publish(event models.Event) {
+	key := strings.ToLower(event.Forum)
+
+	liveUseCase.mutex.Lock()
+	defer liveUseCase.mutex.Unlock()
+	for subscriber := range liveUseCase.subscribers[key] {
+		select {
+		case subscriber <- event:
+		default:
+			// Slow consumer is disconnected instead of blocking the publisher
+			liveUseCase.remove(key, subscriber)
+		}
+	}
+}
+
+func (liveUseCase *LiveUseCaseImpl) Subscribe(ctx context.Context, slug string) (events <-chan models.Event, err error) {
+	forum, err := liveUseCase.forumRepository.GetBySlug(slug)
+	if err != nil {
+		err = errors.ErrForumNotExist
+		return
+	}
+	key := strings.ToLower(forum.Slug)
+
+	subscriber := make(chan models.Event, liveUseCase.bufferSize)
+	liveUseCase.mutex.Lock()
+	if liveUseCase.subscribers[key] == nil {
+		liveUseCase.subscribers[key] = make(map[chan models.Event]bool)
+	}
+	liveUseCase.subscribers[key][subscriber] = true
+	liveUseCase.mutex.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		liveUseCase.mutex.Lock()
+		defer liveUseCase.mutex.Unlock()
+		liveUseCase.remove(key, subscriber)
+	}()
+
+	return subscriber, nil
+}
+
+func (liveUseCase *LiveUseCaseImpl) remove(key string, subscriber chan models.Event) {
+	if !liveUseCase.subscribers[key][subscriber] {
+		return
+	}
+	delete(liveUseCase.subscribers[key], subscriber)
+	if len(liveUseCase.subscribers[key]) == 0 {
+		delete(liveUseCase.subscribers, key)
+	}
+	close(subscriber)
+}
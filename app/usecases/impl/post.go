@@ -5,13 +5,21 @@ import (
 	"Technopark_DB_Project/app/repositories"
 	"Technopark_DB_Project/app/usecases"
 	"Technopark_DB_Project/pkg/errors"
+	"Technopark_DB_Project/pkg/expand"
 )
 
 type PostUseCaseImpl struct {
-	postRepository   repositories.PostRepository
-	userRepository   repositories.UserRepository
-	threadRepository repositories.ThreadRepository
-	forumRepository  repositories.ForumRepository
+	postRepository     repositories.PostRepository
+	userRepository     repositories.UserRepository
+	threadRepository   repositories.ThreadRepository
+	forumRepository    repositories.ForumRepository
+	voteRepository     repositories.VoteRepository
+	reactionRepository repositories.ReactionRepository
+	mentionRepository  repositories.MentionRepository
+
+	liveUseCase usecases.LiveUseCase
+
+	allowedReactions map[string]bool
 }
 
 func CreatePostUseCase(
@@ -19,47 +27,87 @@ func CreatePostUseCase(
 	userRepository repositories.UserRepository,
 	threadRepository repositories.ThreadRepository,
 	forumRepository repositories.ForumRepository,
+	voteRepository repositories.VoteRepository,
+	reactionRepository repositories.ReactionRepository,
+	mentionRepository repositories.MentionRepository,
+	liveUseCase usecases.LiveUseCase,
+	allowedReactions []string,
 ) usecases.PostUseCase {
+	allowedReactionsSet := make(map[string]bool, len(allowedReactions))
+	for _, reaction := range allowedReactions {
+		allowedReactionsSet[reaction] = true
+	}
+
 	return &PostUseCaseImpl{
-		postRepository:   postRepository,
-		userRepository:   userRepository,
-		threadRepository: threadRepository,
-		forumRepository:  forumRepository,
+		postRepository:     postRepository,
+		userRepository:     userRepository,
+		threadRepository:   threadRepository,
+		forumRepository:    forumRepository,
+		voteRepository:     voteRepository,
+		reactionRepository: reactionRepository,
+		mentionRepository:  mentionRepository,
+		liveUseCase:        liveUseCase,
+		allowedReactions:   allowedReactionsSet,
 	}
 }
 
-func (postUseCase *PostUseCaseImpl) Get(postID int64, relatedData *[]string) (postFull *models.PostFull, err error) {
-	postFull = new(models.PostFull)
-	var post *models.Post
-	post, err = postUseCase.postRepository.GetByID(postID)
+// Get fetches the post with the data of expansion. The thread, forum and parent are joined to the post,
+// then all the users they refer to are fetched in one query.
+func (postUseCase *PostUseCaseImpl) Get(postID int64, expansion expand.Tree) (postFull *models.PostFull, err error) {
+	withForum := expansion.Has("forum") || expansion.Sub("thread").Has("forum")
+	withParent := expansion.Has("parent")
+	post, thread, forum, parent, err := postUseCase.postRepository.GetWithRelated(postID, expansion.Has("thread"), withForum, withParent)
 	if err != nil {
 		err = errors.ErrPostNotFound
+		return
+	}
+
+	users := newUserLookup()
+	postFull = &models.PostFull{Post: post}
+	if expansion.Has("author") {
+		users.Add(post.Author, &postFull.Author)
+	}
+	if thread != nil {
+		postFull.Thread = newThreadFull(thread, forum, expansion.Sub("thread"), users)
+	}
+	if expansion.Has("forum") {
+		postFull.Forum = newForumFull(forum, expansion.Sub("forum"), users)
+	}
+	if parent != nil {
+		postFull.Parent = &models.PostFull{Post: parent}
+		if expansion.Sub("parent").Has("author") {
+			users.Add(parent.Author, &postFull.Parent.Author)
+		}
 	}
-	postFull.Post = post
-
-	for _, data := range *relatedData {
-		switch data {
-		case "user":
-			var author *models.User
-			author, err = postUseCase.userRepository.GetByNickname(postFull.Post.Author)
-			if err != nil {
-				err = errors.ErrUserNotFound
-			}
-			postFull.Author = author
-		case "forum":
-			var forum *models.Forum
-			forum, err = postUseCase.forumRepository.GetBySlug(postFull.Post.Forum)
-			if err != nil {
-				err = errors.ErrForumNotExist
-			}
-			postFull.Forum = forum
-		case "thread":
-			var thread *models.Thread
-			thread, err = postUseCase.threadRepository.GetByID(postFull.Post.Thread)
-			if err != nil {
-				err = errors.ErrThreadNotFound
-			}
-			postFull.Thread = thread
+	if err = users.Resolve(postUseCase.userRepository); err != nil {
+		postFull = nil
+	}
+	return
+}
+
+// GetBatch looks the posts up in one query, they are returned in the order of the request without duplicates
+func (postUseCase *PostUseCaseImpl) GetBatch(ids []int64) (batch *models.PostsBatch, err error) {
+	postsSlice, err := postUseCase.postRepository.GetByIDs(ids)
+	if err != nil {
+		return
+	}
+	postsByID := make(map[int64]*models.Post, len(*postsSlice))
+	for i := range *postsSlice {
+		postsByID[(*postsSlice)[i].ID] = &(*postsSlice)[i]
+	}
+
+	batch = &models.PostsBatch{Posts: make(models.Posts, 0, len(*postsSlice)), NotFound: []int64{}}
+	isSeen := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		if isSeen[id] {
+			continue
+		}
+		isSeen[id] = true
+
+		if post, isFound := postsByID[id]; isFound {
+			batch.Posts = append(batch.Posts, *post)
+		} else {
+			batch.NotFound = append(batch.NotFound, id)
 		}
 	}
 	return
@@ -72,19 +120,112 @@ func (postUseCase *PostUseCaseImpl) Update(post *models.Post) (err error) {
 		return
 	}
 
-	if post.Message != "" {
-		if oldPost.Message != post.Message {
-			oldPost.IsEdited = true
-		}
+	if post.Message != "" && oldPost.Message != post.Message {
+		oldPost.IsEdited = true
 		oldPost.Message = post.Message
 
 		err = postUseCase.postRepository.Update(oldPost)
 		if err != nil {
 			return
 		}
+
+		postCopy := *oldPost
+		postUseCase.liveUseCase.Publish(models.Event{Type: models.EventPostUpdated, Forum: oldPost.Forum, Post: &postCopy})
+	} else {
+		var mentions map[int64][]string
+		mentions, err = postUseCase.mentionRepository.GetByPosts([]int64{oldPost.ID})
+		if err != nil {
+			return
+		}
+		oldPost.Mentions = mentions[oldPost.ID]
 	}
 
 	*post = *oldPost
 
 	return
 }
+
+func (postUseCase *PostUseCaseImpl) Vote(postID int64, vote *models.Vote) (post *models.Post, err error) {
+	post, err = postUseCase.postRepository.GetByID(postID)
+	if err != nil {
+		err = errors.ErrPostNotFound
+		return
+	}
+
+	err = postUseCase.voteRepository.VotePost(post.ID, vote)
+	if err != nil {
+		err = errors.ErrUserNotFound
+		return
+	}
+
+	post, err = postUseCase.postRepository.GetByID(post.ID)
+	return
+}
+
+func (postUseCase *PostUseCaseImpl) AddReaction(postID int64, reaction *models.Reaction) (post *models.Post, err error) {
+	if !postUseCase.allowedReactions[reaction.Reaction] {
+		err = errors.ErrReactionNotAllowed
+		return
+	}
+
+	post, err = postUseCase.postRepository.GetByID(postID)
+	if err != nil {
+		err = errors.ErrPostNotFound
+		return
+	}
+
+	err = postUseCase.reactionRepository.Add(post.ID, reaction)
+	if err != nil {
+		err = errors.ErrUserNotFound
+		return
+	}
+
+	err = postUseCase.fillReactions(post)
+	return
+}
+
+func (postUseCase *PostUseCaseImpl) RemoveReaction(postID int64, reaction *models.Reaction) (post *models.Post, err error) {
+	post, err = postUseCase.postRepository.GetByID(postID)
+	if err != nil {
+		err = errors.ErrPostNotFound
+		return
+	}
+
+	err = postUseCase.reactionRepository.Delete(post.ID, reaction)
+	if err != nil {
+		return
+	}
+
+	err = postUseCase.fillReactions(post)
+	return
+}
+
+func (postUseCase *PostUseCaseImpl) GetReactions(postID int64, limit int, sinceNickname, sinceReaction string, desc bool) (reactions *models.Reactions, err error) {
+	post, err := postUseCase.postRepository.GetByID(postID)
+	if err != nil {
+		err = errors.ErrPostNotFound
+		return
+	}
+
+	reactionsSlice, err := postUseCase.reactionRepository.GetByPost(post.ID, limit, sinceNickname, sinceReaction, desc)
+	if err != nil {
+		return
+	}
+	reactions = new(models.Reactions)
+	if len(*reactionsSlice) == 0 {
+		*reactions = []models.Reaction{}
+	} else {
+		*reactions = *reactionsSlice
+	}
+
+	return
+}
+
+func (postUseCase *PostUseCaseImpl) fillReactions(post *models.Post) (err error) {
+	counts, err := postUseCase.reactionRepository.GetCounts([]int64{post.ID})
+	if err != nil {
+		return
+	}
+	post.Reactions = counts[post.ID]
+	return
+}
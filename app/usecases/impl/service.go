@@ -4,14 +4,23 @@ import (
 	"Technopark_DB_Project/app/models"
 	"Technopark_DB_Project/app/repositories"
 	"Technopark_DB_Project/app/usecases"
+	"Technopark_DB_Project/pkg/errors"
+	"Technopark_DB_Project/pkg/validator"
+	"bufio"
+	"bytes"
+	"io"
+	"sort"
 )
 
+const importMaxLineSize = 16 << 20
+
 type ServiceUseCaseImpl struct {
 	serviceRepository repositories.ServiceRepository
+	importRepository  repositories.ImportRepository
 }
 
-func CreateServiceUseCase(serviceRepository repositories.ServiceRepository) usecases.ServiceUseCase {
-	return &ServiceUseCaseImpl{serviceRepository: serviceRepository}
+func CreateServiceUseCase(serviceRepository repositories.ServiceRepository, importRepository repositories.ImportRepository) usecases.ServiceUseCase {
+	return &ServiceUseCaseImpl{serviceRepository: serviceRepository, importRepository: importRepository}
 }
 
 func (serviceUseCase *ServiceUseCaseImpl) Clear() (err error) {
@@ -21,3 +30,122 @@ func (serviceUseCase *ServiceUseCaseImpl) Clear() (err error) {
 func (serviceUseCase *ServiceUseCaseImpl) GetStatus() (status *models.Status, err error) {
 	return serviceUseCase.serviceRepository.GetStatus()
 }
+
+func (serviceUseCase *ServiceUseCaseImpl) GetChanges(after int64, limit int) (changes *models.Changes, err error) {
+	changesSlice, err := serviceUseCase.serviceRepository.GetChanges(after, limit)
+	if err != nil {
+		return
+	}
+	changes = new(models.Changes)
+	if len(*changesSlice) == 0 {
+		*changes = []models.Change{}
+	} else {
+		*changes = *changesSlice
+	}
+
+	return
+}
+
+// Import streams the records into the repository as they are read. Invalid ones are reported along with
+// the lines the repository rejects, a broken source fails the whole import.
+func (serviceUseCase *ServiceUseCaseImpl) Import(reader io.Reader) (result *models.ImportResult, err error) {
+	decoder := newImportDecoder(reader)
+	result, err = serviceUseCase.importRepository.Import(decoder.Next)
+	if decoder.err != nil {
+		return nil, errors.ErrBadRequest
+	}
+	if err != nil {
+		return
+	}
+	result.Errors = append(result.Errors, decoder.errors...)
+	sort.SliceStable(result.Errors, func(i, j int) bool {
+		return result.Errors[i].Line < result.Errors[j].Line
+	})
+
+	return
+}
+
+// importDecoder reads NDJSON records one line at a time, the invalid ones are kept in errors and skipped
+type importDecoder struct {
+	scanner *bufio.Scanner
+	line    int64
+	errors  []models.ImportError
+	err     error
+}
+
+func newImportDecoder(reader io.Reader) *importDecoder {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64<<10), importMaxLineSize)
+	return &importDecoder{scanner: scanner}
+}
+
+// Next returns the next valid record, io.EOF after the last one
+func (decoder *importDecoder) Next() (record *models.ImportedRecord, err error) {
+	for decoder.scanner.Scan() {
+		decoder.line++
+		data := bytes.TrimSpace(decoder.scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+
+		if record, err = decodeImportRecord(decoder.line, data); err != nil {
+			decoder.errors = append(decoder.errors, makeImportError(decoder.line, recordType(record), err))
+			continue
+		}
+		return record, nil
+	}
+	if decoder.err = decoder.scanner.Err(); decoder.err != nil {
+		return nil, decoder.err
+	}
+	return nil, io.EOF
+}
+
+// decodeImportRecord returns the record with just the type set along with an error if it is invalid
+func decodeImportRecord(line int64, data []byte) (record *models.ImportedRecord, err error) {
+	raw := models.ImportRecord{}
+	if err = raw.UnmarshalJSON(data); err != nil {
+		return nil, errors.ErrBadRequest
+	}
+
+	record = &models.ImportedRecord{Line: line, Type: raw.Type}
+	switch raw.Type {
+	case models.ImportUser:
+		record.User = new(models.User)
+		if err = record.User.UnmarshalJSON(raw.Data); err == nil {
+			err = validator.ValidateUserData(record.User, false)
+		}
+	case models.ImportForum:
+		record.Forum = new(models.Forum)
+		if err = record.Forum.UnmarshalJSON(raw.Data); err == nil {
+			err = validator.ValidateForumData(record.Forum)
+		}
+	case models.ImportThread:
+		record.Thread = new(models.Thread)
+		if err = record.Thread.UnmarshalJSON(raw.Data); err == nil {
+			err = validator.ValidateImportedThreadData(record.Thread)
+		}
+	case models.ImportPost:
+		record.Post = new(models.Post)
+		if err = record.Post.UnmarshalJSON(raw.Data); err == nil {
+			err = validator.ValidateImportedPostData(record.Post)
+		}
+	default:
+		err = errors.ErrBadInputData
+	}
+	return
+}
+
+func recordType(record *models.ImportedRecord) string {
+	if record == nil {
+		return ""
+	}
+	return record.Type
+}
+
+func makeImportError(line int64, recordType string, err error) models.ImportError {
+	importError := models.ImportError{Line: line, Type: recordType, Message: err.Error()}
+	if validationError, isValidationError := err.(*errors.ValidationError); isValidationError {
+		importError.Fields = validationError.Fields
+	}
+	return importError
+}
@@ -0,0 +1,122 @@
+package impl
+
+import (
+	"Technopark_DB_Project/app/models"
+	"Technopark_DB_Project/pkg/errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type importRepositoryMock struct {
+	records []models.ImportedRecord
+}
+
+func (mock *importRepositoryMock) Import(next func() (*models.ImportedRecord, error)) (*models.ImportResult, error) {
+	for {
+		record, err := next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		mock.records = append(mock.records, *record)
+	}
+	return &models.ImportResult{Errors: []models.ImportError{}}, nil
+}
+
+// lineReader hands out the source one line per Read, so a record is passed on before the rest is read
+type lineReader struct {
+	lines []string
+	read  int
+}
+
+func (reader *lineReader) Read(buffer []byte) (int, error) {
+	if reader.read == len(reader.lines) {
+		return 0, io.EOF
+	}
+	n := copy(buffer, reader.lines[reader.read]+"\n")
+	reader.read++
+	return n, nil
+}
+
+func TestImportDecoderStreams(t *testing.T) {
+	reader := &lineReader{lines: []string{
+		`{"type": "user", "data": {"nickname": "alice", "fullname": "Alice", "email": "alice@example.com"}}`,
+		`{"type": "forum", "data": {"title": "Forum", "user": "alice", "slug": "forum"}}`,
+	}}
+	decoder := newImportDecoder(reader)
+
+	record, err := decoder.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if record.Type != models.ImportUser || record.User.Nickname != "alice" || record.Line != 1 {
+		t.Fatalf("first record = %+v", record)
+	}
+	if reader.read != 1 {
+		t.Fatalf("%d lines read for the first record, want 1", reader.read)
+	}
+	if record, err = decoder.Next(); err != nil || record.Forum == nil || record.Line != 2 {
+		t.Fatalf("second record = %+v, err = %v", record, err)
+	}
+	if _, err = decoder.Next(); err != io.EOF {
+		t.Fatalf("err = %v, want io.EOF", err)
+	}
+}
+
+func TestImportReportsInvalidRecords(t *testing.T) {
+	source := strings.Join([]string{
+		`{"type": "user", "data": {"nickname": "alice", "fullname": "Alice", "email": "alice@example.com"}}`,
+		``,
+		`not json`,
+		`{"type": "comment", "data": {}}`,
+		`{"type": "user", "data": {"nickname": "bad nickname", "fullname": "Bad", "email": "bad@example.com"}}`,
+		`{"type": "forum", "data": {"title": "Forum", "user": "alice", "slug": "forum"}}`,
+	}, "\n")
+	repository := &importRepositoryMock{}
+	serviceUseCase := &ServiceUseCaseImpl{importRepository: repository}
+
+	result, err := serviceUseCase.Import(strings.NewReader(source))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines := []int64{}
+	for _, record := range repository.records {
+		lines = append(lines, record.Line)
+	}
+	if want := []int64{1, 6}; !reflect.DeepEqual(lines, want) {
+		t.Errorf("imported lines = %v, want %v", lines, want)
+	}
+
+	tests := []struct {
+		line       int64
+		recordType string
+		message    string
+	}{
+		{line: 3, message: errors.ErrBadRequest.Error()},
+		{line: 4, recordType: "comment", message: errors.ErrBadInputData.Error()},
+		{line: 5, recordType: models.ImportUser, message: errors.ErrBadInputData.Error()},
+	}
+	if len(result.Errors) != len(tests) {
+		t.Fatalf("errors = %+v, want %d", result.Errors, len(tests))
+	}
+	for i, test := range tests {
+		got := result.Errors[i]
+		if got.Line != test.line || got.Type != test.recordType || got.Message != test.message {
+			t.Errorf("errors[%d] = %+v, want line %d, type %q, message %q", i, got, test.line, test.recordType, test.message)
+		}
+	}
+}
+
+func TestImportBrokenSource(t *testing.T) {
+	source := strings.Repeat("x", importMaxLineSize+1)
+	serviceUseCase := &ServiceUseCaseImpl{importRepository: &importRepositoryMock{}}
+
+	if _, err := serviceUseCase.Import(strings.NewReader(source)); err != errors.ErrBadRequest {
+		t.Fatalf("err = %v, want %v", err, errors.ErrBadRequest)
+	}
+}
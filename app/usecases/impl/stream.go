@@ -0,0 +1,170 @@
+package impl
+
+import (
+	"Technopark_DB_Project/app/models"
+	"Technopark_DB_Project/app/repositories"
+	"Technopark_DB_Project/app/usecases"
+	"Technopark_DB_Project/pkg/errors"
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	streamBufferSize     = 64
+	streamReconnectDelay = time.Second
+)
+
+type StreamUseCaseImpl struct {
+	threadRepository repositories.ThreadRepository
+	postRepository   repositories.PostRepository
+	streamRepository repositories.StreamRepository
+
+	mutex       sync.Mutex
+	subscribers map[int64]map[chan models.Post]bool
+}
+
+func CreateStreamUseCase(
+	threadRepository repositories.ThreadRepository,
+	postRepository repositories.PostRepository,
+	streamRepository repositories.StreamRepository,
+) usecases.StreamUseCase {
+	return &StreamUseCaseImpl{
+		threadRepository: threadRepository,
+		postRepository:   postRepository,
+		streamRepository: streamRepository,
+		subscribers:      make(map[int64]map[chan models.Post]bool),
+	}
+}
+
+func (streamUseCase *StreamUseCaseImpl) Run(ctx context.Context) (err error) {
+	for {
+		err = streamUseCase.streamRepository.ListenPosts(ctx, streamUseCase.publish)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		fmt.Println(err)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(streamReconnectDelay):
+		}
+	}
+}
+
+func (streamUseCase *StreamUseCaseImpl) StreamPosts(ctx context.Context, slugOrID string, lastPostID int64) (posts <-chan models.Post, err error) {
+	id, errConv := strconv.Atoi(slugOrID)
+	var thread *models.Thread
+	if errConv != nil {
+		thread, err = streamUseCase.threadRepository.GetBySlug(slugOrID)
+	} else {
+		thread, err = streamUseCase.threadRepository.GetByID(int64(id))
+	}
+
+	if err != nil {
+		err = errors.ErrThreadNotFound
+		return
+	}
+
+	// Subscribe before reading the backlog so that nothing is lost in between
+	live := make(chan models.Post, streamBufferSize)
+	streamUseCase.subscribe(thread.ID, live)
+
+	missed := new([]models.Post)
+	if lastPostID != -1 {
+		missed, err = streamUseCase.threadRepository.GetPostsFlat(thread.ID, 0, int(lastPostID), false)
+		if err != nil {
+			streamUseCase.unsubscribe(thread.ID, live)
+			return
+		}
+	}
+
+	out := make(chan models.Post)
+	go func() {
+		defer close(out)
+		defer streamUseCase.unsubscribe(thread.ID, live)
+
+		lastSentID := lastPostID
+		for _, post := range *missed {
+			select {
+			case out <- post:
+				lastSentID = post.ID
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		for {
+			select {
+			case post, ok := <-live:
+				if !ok {
+					return
+				}
+				if post.ID <= lastSentID {
+					continue
+				}
+				select {
+				case out <- post:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+func (streamUseCase *StreamUseCaseImpl) publish(threadID, postID int64) {
+	streamUseCase.mutex.Lock()
+	subscribersAmount := len(streamUseCase.subscribers[threadID])
+	streamUseCase.mutex.Unlock()
+	if subscribersAmount == 0 {
+		return
+	}
+
+	post, err := streamUseCase.postRepository.GetByID(postID)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	streamUseCase.mutex.Lock()
+	defer streamUseCase.mutex.Unlock()
+	for subscriber := range streamUseCase.subscribers[threadID] {
+		select {
+		case subscriber <- *post:
+		default:
+			// Slow subscriber is dropped, client resumes with Last-Event-ID
+			delete(streamUseCase.subscribers[threadID], subscriber)
+			close(subscriber)
+		}
+	}
+}
+
+func (streamUseCase *StreamUseCaseImpl) subscribe(threadID int64, subscriber chan models.Post) {
+	streamUseCase.mutex.Lock()
+	defer streamUseCase.mutex.Unlock()
+	if streamUseCase.subscribers[threadID] == nil {
+		streamUseCase.subscribers[threadID] = make(map[chan models.Post]bool)
+	}
+	streamUseCase.subscribers[threadID][subscriber] = true
+}
+
+func (streamUseCase *StreamUseCaseImpl) unsubscribe(threadID int64, subscriber chan models.Post) {
+	streamUseCase.mutex.Lock()
+	defer streamUseCase.mutex.Unlock()
+	if !streamUseCase.subscribers[threadID][subscriber] {
+		return
+	}
+	delete(streamUseCase.subscribers[threadID], subscriber)
+	if len(streamUseCase.subscribers[threadID]) == 0 {
+		delete(streamUseCase.subscribers, threadID)
+	}
+	close(subscriber)
+}
@@ -5,14 +5,24 @@ import (
 	"Technopark_DB_Project/app/repositories"
 	"Technopark_DB_Project/app/usecases"
 	"Technopark_DB_Project/pkg/errors"
+	"Technopark_DB_Project/pkg/expand"
 	"strconv"
 )
 
+const postsDetailsBatchSize = 256
+
 type ThreadUseCaseImpl struct {
-	threadRepository repositories.ThreadRepository
-	voteRepository   repositories.VoteRepository
-	postRepository   repositories.PostRepository
-	userRepository   repositories.UserRepository
+	threadRepository       repositories.ThreadRepository
+	voteRepository         repositories.VoteRepository
+	postRepository         repositories.PostRepository
+	userRepository         repositories.UserRepository
+	reactionRepository     repositories.ReactionRepository
+	mentionRepository      repositories.MentionRepository
+	subscriptionRepository repositories.SubscriptionRepository
+
+	liveUseCase usecases.LiveUseCase
+
+	notifyReplyAncestors bool
 }
 
 func CreateThreadUseCase(
@@ -20,8 +30,23 @@ func CreateThreadUseCase(
 	voteRepository repositories.VoteRepository,
 	postRepository repositories.PostRepository,
 	userRepository repositories.UserRepository,
+	reactionRepository repositories.ReactionRepository,
+	mentionRepository repositories.MentionRepository,
+	subscriptionRepository repositories.SubscriptionRepository,
+	liveUseCase usecases.LiveUseCase,
+	notifyReplyAncestors bool,
 ) usecases.ThreadUseCase {
-	return &ThreadUseCaseImpl{threadRepository: threadRepository, voteRepository: voteRepository, postRepository: postRepository, userRepository: userRepository}
+	return &ThreadUseCaseImpl{
+		threadRepository:       threadRepository,
+		voteRepository:         voteRepository,
+		postRepository:         postRepository,
+		userRepository:         userRepository,
+		reactionRepository:     reactionRepository,
+		mentionRepository:      mentionRepository,
+		subscriptionRepository: subscriptionRepository,
+		liveUseCase:            liveUseCase,
+		notifyReplyAncestors:   notifyReplyAncestors,
+	}
 }
 
 func (threadUseCase *ThreadUseCaseImpl) CreatePosts(slugOrID string, posts *models.Posts) (err error) {
@@ -56,7 +81,16 @@ func (threadUseCase *ThreadUseCaseImpl) CreatePosts(slugOrID string, posts *mode
 		return
 	}
 
-	err = threadUseCase.threadRepository.CreatePosts(thread, posts)
+	// Mentions and notifications are written in the same transaction as the posts
+	err = threadUseCase.threadRepository.CreatePosts(thread, posts, threadUseCase.notifyReplyAncestors)
+	if err != nil {
+		return
+	}
+
+	for _, post := range *posts {
+		post := post
+		threadUseCase.liveUseCase.Publish(models.Event{Type: models.EventPostCreated, Forum: thread.Forum, Post: &post})
+	}
 	return
 }
 
@@ -74,6 +108,72 @@ func (threadUseCase *ThreadUseCaseImpl) Get(slugOrID string) (thread *models.Thr
 	return
 }
 
+// GetFull fetches the thread with the data of expansion, the forum comes in the same query and the users in one more
+func (threadUseCase *ThreadUseCaseImpl) GetFull(slugOrID string, expansion expand.Tree) (threadFull *models.ThreadFull, err error) {
+	var thread *models.Thread
+	var forum *models.Forum
+	if expansion.Has("forum") {
+		// The forum comes with the thread in one query
+		id, errConv := strconv.Atoi(slugOrID)
+		if errConv != nil {
+			id = 0
+		}
+		thread, forum, err = threadUseCase.threadRepository.GetWithForum(int64(id), slugOrID)
+		if err != nil {
+			err = errors.ErrThreadNotFound
+			return
+		}
+	} else if thread, err = threadUseCase.Get(slugOrID); err != nil {
+		return
+	}
+
+	users := newUserLookup()
+	threadFull = newThreadFull(thread, forum, expansion, users)
+	if err = users.Resolve(threadUseCase.userRepository); err != nil {
+		threadFull = nil
+	}
+	return
+}
+
+// GetByIDs returns the threads found, missing ids are just left out
+func (threadUseCase *ThreadUseCaseImpl) GetByIDs(ids []int64) (threads *models.Threads, err error) {
+	threadsSlice, err := threadUseCase.threadRepository.GetByIDs(ids)
+	if err != nil {
+		return
+	}
+	threads = new(models.Threads)
+	*threads = *threadsSlice
+	return
+}
+
+// GetBatch is GetByIDs with the threads in the order of the request without duplicates and the missing ids reported
+func (threadUseCase *ThreadUseCaseImpl) GetBatch(ids []int64) (batch *models.ThreadsBatch, err error) {
+	threads, err := threadUseCase.GetByIDs(ids)
+	if err != nil {
+		return
+	}
+	threadsByID := make(map[int64]*models.Thread, len(*threads))
+	for i := range *threads {
+		threadsByID[(*threads)[i].ID] = &(*threads)[i]
+	}
+
+	batch = &models.ThreadsBatch{Threads: make(models.Threads, 0, len(*threads)), NotFound: []int64{}}
+	isSeen := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		if isSeen[id] {
+			continue
+		}
+		isSeen[id] = true
+
+		if thread, isFound := threadsByID[id]; isFound {
+			batch.Threads = append(batch.Threads, *thread)
+		} else {
+			batch.NotFound = append(batch.NotFound, id)
+		}
+	}
+	return
+}
+
 func (threadUseCase *ThreadUseCaseImpl) Update(slugOrID string, thread *models.Thread) (err error) {
 	id, errConv := strconv.Atoi(slugOrID)
 	var oldThread *models.Thread
@@ -125,12 +225,20 @@ func (threadUseCase *ThreadUseCaseImpl) GetPosts(slugOrID string, limit, since i
 		postsSlice, err = threadUseCase.threadRepository.GetPostsTree(thread.ID, limit, since, desc)
 	case "parent_tree":
 		postsSlice, err = threadUseCase.threadRepository.GetPostsParentTree(thread.ID, limit, since, desc)
+	case "top":
+		postsSlice, err = threadUseCase.threadRepository.GetPostsTop(thread.ID, limit, since, desc)
 	default:
 		postsSlice, err = threadUseCase.threadRepository.GetPostsFlat(thread.ID, limit, since, desc)
 	}
 	if err != nil {
 		return
 	}
+
+	err = threadUseCase.fillPostsDetails(*postsSlice)
+	if err != nil {
+		return
+	}
+
 	posts = new(models.Posts)
 	if len(*postsSlice) == 0 {
 		*posts = []models.Post{}
@@ -141,6 +249,72 @@ func (threadUseCase *ThreadUseCaseImpl) GetPosts(slugOrID string, limit, since i
 	return
 }
 
+func (threadUseCase *ThreadUseCaseImpl) IteratePosts(slugOrID string, limit, since int, sort string, desc bool, onPost func(post *models.Post) error) (err error) {
+	id, errConv := strconv.Atoi(slugOrID)
+	var thread *models.Thread
+	if errConv != nil {
+		thread, err = threadUseCase.threadRepository.GetBySlug(slugOrID)
+	} else {
+		thread, err = threadUseCase.threadRepository.GetByID(int64(id))
+	}
+
+	if err != nil {
+		err = errors.ErrThreadNotFound
+		return
+	}
+
+	// Reactions and mentions are loaded per batch, so only one batch of posts is kept in memory
+	batch := make([]models.Post, 0, postsDetailsBatchSize)
+	flush := func() (err error) {
+		if err = threadUseCase.fillPostsDetails(batch); err != nil {
+			return
+		}
+		for i := range batch {
+			if err = onPost(&batch[i]); err != nil {
+				return
+			}
+		}
+		batch = batch[:0]
+		return
+	}
+
+	err = threadUseCase.threadRepository.IteratePosts(thread.ID, sort, limit, since, desc, func(post *models.Post) error {
+		batch = append(batch, *post)
+		if len(batch) < postsDetailsBatchSize {
+			return nil
+		}
+		return flush()
+	})
+	if err != nil {
+		return
+	}
+	return flush()
+}
+
+func (threadUseCase *ThreadUseCaseImpl) fillPostsDetails(posts []models.Post) (err error) {
+	if len(posts) == 0 {
+		return
+	}
+
+	postIDs := make([]int64, 0, len(posts))
+	for _, post := range posts {
+		postIDs = append(postIDs, post.ID)
+	}
+	reactionCounts, err := threadUseCase.reactionRepository.GetCounts(postIDs)
+	if err != nil {
+		return
+	}
+	mentions, err := threadUseCase.mentionRepository.GetByPosts(postIDs)
+	if err != nil {
+		return
+	}
+	for i := range posts {
+		posts[i].Reactions = reactionCounts[posts[i].ID]
+		posts[i].Mentions = mentions[posts[i].ID]
+	}
+	return
+}
+
 func (threadUseCase *ThreadUseCaseImpl) Vote(slugOrID string, vote *models.Vote) (thread *models.Thread, err error) {
 	id, errConv := strconv.Atoi(slugOrID)
 
@@ -161,6 +335,12 @@ func (threadUseCase *ThreadUseCaseImpl) Vote(slugOrID string, vote *models.Vote)
 		return
 	}
 	thread.Votes, err = threadUseCase.threadRepository.GetVotes(thread.ID)
+	if err != nil {
+		return
+	}
+
+	threadCopy, voteCopy := *thread, *vote
+	threadUseCase.liveUseCase.Publish(models.Event{Type: models.EventVoteCast, Forum: thread.Forum, Thread: &threadCopy, Vote: &voteCopy})
 	//if threadUseCase.voteRepository.IsVoted(thread.ID, vote) {
 	//	err = threadUseCase.voteRepository.Update(thread, vote)
 	//} else {
@@ -169,3 +349,99 @@ func (threadUseCase *ThreadUseCaseImpl) Vote(slugOrID string, vote *models.Vote)
 
 	return
 }
+
+func (threadUseCase *ThreadUseCaseImpl) Unvote(slugOrID string, nickname string) (thread *models.Thread, err error) {
+	id, errConv := strconv.Atoi(slugOrID)
+
+	if errConv != nil {
+		thread, err = threadUseCase.threadRepository.GetBySlug(slugOrID)
+	} else {
+		thread, err = threadUseCase.threadRepository.GetByID(int64(id))
+	}
+
+	if err != nil {
+		err = errors.ErrThreadNotFound
+		return
+	}
+
+	err = threadUseCase.voteRepository.Delete(thread.ID, nickname)
+	if err != nil {
+		return
+	}
+	thread.Votes, err = threadUseCase.threadRepository.GetVotes(thread.ID)
+
+	return
+}
+
+func (threadUseCase *ThreadUseCaseImpl) GetVotes(slugOrID string, limit int, since string, desc bool) (votes *models.Votes, err error) {
+	id, errConv := strconv.Atoi(slugOrID)
+	var thread *models.Thread
+	if errConv != nil {
+		thread, err = threadUseCase.threadRepository.GetBySlug(slugOrID)
+	} else {
+		thread, err = threadUseCase.threadRepository.GetByID(int64(id))
+	}
+
+	if err != nil {
+		err = errors.ErrThreadNotFound
+		return
+	}
+
+	votesSlice, err := threadUseCase.voteRepository.GetByThread(thread.ID, limit, since, desc)
+	if err != nil {
+		return
+	}
+	votes = new(models.Votes)
+	if len(*votesSlice) == 0 {
+		*votes = []models.Vote{}
+	} else {
+		*votes = *votesSlice
+	}
+
+	return
+}
+
+func (threadUseCase *ThreadUseCaseImpl) Subscribe(slugOrID, nickname string) (subscription *models.Subscription, err error) {
+	id, errConv := strconv.Atoi(slugOrID)
+	var thread *models.Thread
+	if errConv != nil {
+		thread, err = threadUseCase.threadRepository.GetBySlug(slugOrID)
+	} else {
+		thread, err = threadUseCase.threadRepository.GetByID(int64(id))
+	}
+
+	if err != nil {
+		err = errors.ErrThreadNotFound
+		return
+	}
+
+	err = threadUseCase.subscriptionRepository.SubscribeThread(thread.ID, nickname)
+	if err != nil {
+		err = errors.ErrUserNotFound
+		return
+	}
+
+	subscription = &models.Subscription{Nickname: nickname, Forum: thread.Forum, Thread: thread.ID}
+	return
+}
+
+func (threadUseCase *ThreadUseCaseImpl) Unsubscribe(slugOrID, nickname string) (err error) {
+	id, errConv := strconv.Atoi(slugOrID)
+	var thread *models.Thread
+	if errConv != nil {
+		thread, err = threadUseCase.threadRepository.GetBySlug(slugOrID)
+	} else {
+		thread, err = threadUseCase.threadRepository.GetByID(int64(id))
+	}
+
+	if err != nil {
+		err = errors.ErrThreadNotFound
+		return
+	}
+
+	err = threadUseCase.subscriptionRepository.UnsubscribeThread(thread.ID, nickname)
+	if err != nil {
+		err = errors.ErrSubscriptionNotFound
+	}
+	return
+}
@@ -5,14 +5,37 @@ import (
 	"Technopark_DB_Project/app/repositories"
 	"Technopark_DB_Project/app/usecases"
 	"Technopark_DB_Project/pkg/errors"
+	"strings"
 )
 
 type UserUseCaseImpl struct {
-	userRepository repositories.UserRepository
+	userRepository         repositories.UserRepository
+	voteRepository         repositories.VoteRepository
+	postRepository         repositories.PostRepository
+	threadRepository       repositories.ThreadRepository
+	forumRepository        repositories.ForumRepository
+	notificationRepository repositories.NotificationRepository
+	mentionRepository      repositories.MentionRepository
 }
 
-func CreateUserUseCase(userRepository repositories.UserRepository) usecases.UserUseCase {
-	return &UserUseCaseImpl{userRepository: userRepository}
+func CreateUserUseCase(
+	userRepository repositories.UserRepository,
+	voteRepository repositories.VoteRepository,
+	postRepository repositories.PostRepository,
+	threadRepository repositories.ThreadRepository,
+	forumRepository repositories.ForumRepository,
+	notificationRepository repositories.NotificationRepository,
+	mentionRepository repositories.MentionRepository,
+) usecases.UserUseCase {
+	return &UserUseCaseImpl{
+		userRepository:         userRepository,
+		voteRepository:         voteRepository,
+		postRepository:         postRepository,
+		threadRepository:       threadRepository,
+		forumRepository:        forumRepository,
+		notificationRepository: notificationRepository,
+		mentionRepository:      mentionRepository,
+	}
 }
 
 func (userUseCase *UserUseCaseImpl) Create(user *models.User) (users *models.Users, err error) {
@@ -39,6 +62,47 @@ func (userUseCase *UserUseCaseImpl) Get(nickname string) (user *models.User, err
 	return
 }
 
+// GetByNicknames returns the users found, missing nicknames are just left out
+func (userUseCase *UserUseCaseImpl) GetByNicknames(nicknames []string) (users *models.Users, err error) {
+	usersSlice, err := userUseCase.userRepository.GetByNicknames(nicknames)
+	if err != nil {
+		return
+	}
+	users = new(models.Users)
+	*users = *usersSlice
+	return
+}
+
+// GetBatch is GetByNicknames with the users in the order of the request without duplicates and the missing ones reported.
+// Nicknames are case insensitive, so the ones missing are reported as they were requested.
+func (userUseCase *UserUseCaseImpl) GetBatch(nicknames []string) (batch *models.UsersBatch, err error) {
+	users, err := userUseCase.GetByNicknames(nicknames)
+	if err != nil {
+		return
+	}
+	usersByNickname := make(map[string]*models.User, len(*users))
+	for i := range *users {
+		usersByNickname[strings.ToLower((*users)[i].Nickname)] = &(*users)[i]
+	}
+
+	batch = &models.UsersBatch{Users: make(models.Users, 0, len(*users)), NotFound: []string{}}
+	isSeen := make(map[string]bool, len(nicknames))
+	for _, nickname := range nicknames {
+		key := strings.ToLower(nickname)
+		if isSeen[key] {
+			continue
+		}
+		isSeen[key] = true
+
+		if user, isFound := usersByNickname[key]; isFound {
+			batch.Users = append(batch.Users, *user)
+		} else {
+			batch.NotFound = append(batch.NotFound, nickname)
+		}
+	}
+	return
+}
+
 func (userUseCase *UserUseCaseImpl) Update(user *models.User) (err error) {
 	oldUser, err := userUseCase.userRepository.GetByNickname(user.Nickname)
 	if oldUser.Nickname == "" {
@@ -52,3 +116,139 @@ func (userUseCase *UserUseCaseImpl) Update(user *models.User) (err error) {
 	}
 	return
 }
+
+func (userUseCase *UserUseCaseImpl) GetVotes(nickname string, limit int, since int64, desc bool) (votes *models.Votes, err error) {
+	user, err := userUseCase.userRepository.GetByNickname(nickname)
+	if err != nil {
+		err = errors.ErrUserNotFound
+		return
+	}
+
+	votesSlice, err := userUseCase.voteRepository.GetByUser(user.Nickname, limit, since, desc)
+	if err != nil {
+		return
+	}
+	votes = new(models.Votes)
+	if len(*votesSlice) == 0 {
+		*votes = []models.Vote{}
+	} else {
+		*votes = *votesSlice
+	}
+
+	return
+}
+
+func (userUseCase *UserUseCaseImpl) GetPosts(nickname, forum string, limit int, since int64, desc bool) (posts *models.Posts, err error) {
+	user, err := userUseCase.userRepository.GetByNickname(nickname)
+	if err != nil {
+		err = errors.ErrUserNotFound
+		return
+	}
+
+	postsSlice, err := userUseCase.postRepository.GetByAuthor(user.Nickname, forum, limit, since, desc)
+	if err != nil {
+		return
+	}
+	posts = new(models.Posts)
+	if len(*postsSlice) == 0 {
+		*posts = []models.Post{}
+	} else {
+		*posts = *postsSlice
+	}
+
+	return
+}
+
+func (userUseCase *UserUseCaseImpl) GetThreads(nickname, forum string, limit int, since int64, desc bool) (threads *models.Threads, err error) {
+	user, err := userUseCase.userRepository.GetByNickname(nickname)
+	if err != nil {
+		err = errors.ErrUserNotFound
+		return
+	}
+
+	threadsSlice, err := userUseCase.threadRepository.GetByAuthor(user.Nickname, forum, limit, since, desc)
+	if err != nil {
+		return
+	}
+	threads = new(models.Threads)
+	if len(*threadsSlice) == 0 {
+		*threads = []models.Thread{}
+	} else {
+		*threads = *threadsSlice
+	}
+
+	return
+}
+
+func (userUseCase *UserUseCaseImpl) GetForums(nickname string, limit int, since string, desc bool) (forums *models.UserForums, err error) {
+	user, err := userUseCase.userRepository.GetByNickname(nickname)
+	if err != nil {
+		err = errors.ErrUserNotFound
+		return
+	}
+
+	forumsSlice, err := userUseCase.forumRepository.GetByUser(user.Nickname, limit, since, desc)
+	if err != nil {
+		return
+	}
+	forums = new(models.UserForums)
+	if len(*forumsSlice) == 0 {
+		*forums = []models.UserForum{}
+	} else {
+		*forums = *forumsSlice
+	}
+
+	return
+}
+
+func (userUseCase *UserUseCaseImpl) GetNotifications(nickname, kind string, limit int, since int64, unreadOnly, desc bool) (notifications *models.Notifications, err error) {
+	user, err := userUseCase.userRepository.GetByNickname(nickname)
+	if err != nil {
+		err = errors.ErrUserNotFound
+		return
+	}
+
+	notificationsSlice, err := userUseCase.notificationRepository.GetByUser(user.Nickname, kind, limit, since, unreadOnly, desc)
+	if err != nil {
+		return
+	}
+	notifications = new(models.Notifications)
+	if len(*notificationsSlice) == 0 {
+		*notifications = []models.Notification{}
+	} else {
+		*notifications = *notificationsSlice
+	}
+
+	return
+}
+
+func (userUseCase *UserUseCaseImpl) ReadNotifications(nickname string, ids []int64) (err error) {
+	user, err := userUseCase.userRepository.GetByNickname(nickname)
+	if err != nil {
+		err = errors.ErrUserNotFound
+		return
+	}
+
+	return userUseCase.notificationRepository.MarkAsRead(user.Nickname, ids)
+}
+
+func (userUseCase *UserUseCaseImpl) GetMentions(nickname string, limit int, since int64, desc bool) (posts *models.Posts, err error) {
+	user, err := userUseCase.userRepository.GetByNickname(nickname)
+	if err != nil {
+		err = errors.ErrUserNotFound
+		return
+	}
+
+	postsSlice, err := userUseCase.mentionRepository.GetPostsByUser(user.Nickname, limit, since, desc)
+	if err != nil {
+		return
+	}
+	posts = new(models.Posts)
+	if len(*postsSlice) == 0 {
+		*posts = []models.Post{}
+	} else {
+		*posts = *postsSlice
+	}
+
+	return
+}
@@ -0,0 +1,324 @@
+package impl
+
+import (
+	"Technopark_DB_Project/app/models"
+	"Technopark_DB_Project/app/repositories"
+	"Technopark_DB_Project/app/usecases"
+	"Technopark_DB_Project/pkg/errors"
+	"Technopark_DB_Project/pkg/signature"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+const (
+	webhookPollInterval   = time.Second
+	webhookBatchSize      = 32
+	webhookBackoffBase    = time.Second
+	webhookBackoffMax     = time.Hour
+	webhookSecretLength   = 32
+	webhookMaxResponseLen = 64 << 10
+)
+
+type WebhookUseCaseImpl struct {
+	webhookRepository repositories.WebhookRepository
+	forumRepository   repositories.ForumRepository
+
+	client      *http.Client
+	maxAttempts int32
+
+	allowPrivateTargets bool
+}
+
+// CreateWebhookUseCase makes a usecase that refuses webhooks on private, loopback and link-local addresses
+// unless allowPrivateTargets is set. Addresses are checked on creation and again on every connection,
+// so a host resolving to another address later or a redirect doesn't get around the check.
+func CreateWebhookUseCase(
+	webhookRepository repositories.WebhookRepository,
+	forumRepository repositories.ForumRepository,
+	timeout time.Duration,
+	maxAttempts int32,
+	allowPrivateTargets bool,
+) usecases.WebhookUseCase {
+	client := &http.Client{Timeout: timeout}
+	if !allowPrivateTargets {
+		// A proxy would connect on the server's behalf, so requests go directly
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.Proxy = nil
+		transport.DialContext = (&net.Dialer{Timeout: timeout, Control: controlWebhookTarget}).DialContext
+		client.Transport = transport
+	}
+
+	return &WebhookUseCaseImpl{
+		webhookRepository:   webhookRepository,
+		forumRepository:     forumRepository,
+		client:              client,
+		maxAttempts:         maxAttempts,
+		allowPrivateTargets: allowPrivateTargets,
+	}
+}
+
+func (webhookUseCase *WebhookUseCaseImpl) Run(ctx context.Context) (err error) {
+	// Lease outlives the request timeout, so a message is never sent twice by a healthy worker
+	lease := 2 * webhookUseCase.client.Timeout
+
+	ticker := time.NewTicker(webhookPollInterval)
+	defer ticker.Stop()
+
+	for {
+		messages, errClaim := webhookUseCase.webhookRepository.ClaimMessages(webhookBatchSize, lease)
+		if errClaim != nil {
+			fmt.Println(errClaim)
+		} else {
+			wg := sync.WaitGroup{}
+			for _, message := range *messages {
+				wg.Add(1)
+				go func(message models.WebhookMessage) {
+					defer wg.Done()
+					webhookUseCase.deliver(ctx, message)
+				}(message)
+			}
+			wg.Wait()
+
+			// Full batch means there may be more due messages, so poll again right away
+			if len(*messages) == webhookBatchSize && ctx.Err() == nil {
+				continue
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (webhookUseCase *WebhookUseCaseImpl) Create(slug string, webhook *models.Webhook) (err error) {
+	forum, err := webhookUseCase.getOwnedForum(slug, webhook.Nickname)
+	if err != nil {
+		return
+	}
+	if err = webhookUseCase.checkTarget(webhook.URL); err != nil {
+		return
+	}
+
+	if webhook.Secret == "" {
+		webhook.Secret, err = generateWebhookSecret()
+		if err != nil {
+			return
+		}
+	}
+	webhook.Forum = forum.Slug
+	webhook.Nickname = forum.User
+	err = webhookUseCase.webhookRepository.Create(webhook)
+	return
+}
+
+func (webhookUseCase *WebhookUseCaseImpl) GetByForum(slug, nickname string) (webhooks *models.Webhooks, err error) {
+	forum, err := webhookUseCase.getOwnedForum(slug, nickname)
+	if err != nil {
+		return
+	}
+
+	webhooksSlice, err := webhookUseCase.webhookRepository.GetByForum(forum.Slug)
+	if err != nil {
+		return
+	}
+	webhooks = new(models.Webhooks)
+	*webhooks = []models.Webhook{}
+	for _, webhook := range *webhooksSlice {
+		// Secret is shown only once, on creation
+		webhook.Secret = ""
+		*webhooks = append(*webhooks, webhook)
+	}
+
+	return
+}
+
+func (webhookUseCase *WebhookUseCaseImpl) Delete(slug string, id int64, nickname string) (err error) {
+	forum, err := webhookUseCase.getOwnedForum(slug, nickname)
+	if err != nil {
+		return
+	}
+
+	err = webhookUseCase.webhookRepository.Delete(forum.Slug, id)
+	if err != nil {
+		err = errors.ErrWebhookNotFound
+	}
+	return
+}
+
+func (webhookUseCase *WebhookUseCaseImpl) GetDeliveries(slug string, id int64, nickname string, limit int, since int64, desc bool) (deliveries *models.WebhookDeliveries, err error) {
+	forum, err := webhookUseCase.getOwnedForum(slug, nickname)
+	if err != nil {
+		return
+	}
+
+	webhook, err := webhookUseCase.webhookRepository.GetByID(id)
+	if err != nil || !strings.EqualFold(webhook.Forum, forum.Slug) {
+		err = errors.ErrWebhookNotFound
+		return
+	}
+
+	deliveriesSlice, err := webhookUseCase.webhookRepository.GetDeliveries(webhook.ID, limit, since, desc)
+	if err != nil {
+		return
+	}
+	deliveries = new(models.WebhookDeliveries)
+	if len(*deliveriesSlice) == 0 {
+		*deliveries = []models.WebhookDelivery{}
+	} else {
+		*deliveries = *deliveriesSlice
+	}
+
+	return
+}
+
+// getOwnedForum only compares nickname with the forum owner. The API has no authentication, so the nickname
+// is taken on the caller's word like everywhere else and anyone knowing the owner can manage the webhooks.
+func (webhookUseCase *WebhookUseCaseImpl) getOwnedForum(slug, nickname string) (forum *models.Forum, err error) {
+	forum, err = webhookUseCase.forumRepository.GetBySlug(slug)
+	if err != nil {
+		err = errors.ErrForumNotExist
+		return
+	}
+	if !strings.EqualFold(forum.User, nickname) {
+		err = errors.ErrWebhookForbidden
+	}
+	return
+}
+
+// checkTarget resolves the host of rawURL and rejects it if any of its addresses is not allowed
+func (webhookUseCase *WebhookUseCaseImpl) checkTarget(rawURL string) (err error) {
+	validationError := new(errors.ValidationError)
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || parsedURL.Host == "" {
+		validationError.Add("url", "must be an absolute http or https URL")
+		return validationError
+	}
+	if webhookUseCase.allowPrivateTargets {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), webhookUseCase.client.Timeout)
+	defer cancel()
+	addresses, err := net.DefaultResolver.LookupIPAddr(ctx, parsedURL.Hostname())
+	if err != nil {
+		validationError.Add("url", "host can't be resolved")
+		return validationError
+	}
+	for _, address := range addresses {
+		if isPrivateAddress(address.IP) {
+			validationError.Add("url", "must not point to a private, loopback or link-local address")
+			break
+		}
+	}
+	return validationError.OrNil()
+}
+
+func (webhookUseCase *WebhookUseCaseImpl) deliver(ctx context.Context, message models.WebhookMessage) {
+	delivery := &models.WebhookDelivery{
+		Webhook: message.Webhook,
+		Message: message.ID,
+		Event:   message.Event,
+		Attempt: message.Attempts,
+	}
+
+	start := time.Now()
+	statusCode, err := webhookUseCase.send(ctx, message)
+	delivery.Duration = time.Since(start).Milliseconds()
+	delivery.StatusCode = int32(statusCode)
+	if err != nil {
+		delivery.Error = err.Error()
+	}
+	if errLog := webhookUseCase.webhookRepository.LogDelivery(delivery); errLog != nil {
+		fmt.Println(errLog)
+	}
+
+	var errMark error
+	if err == nil {
+		errMark = webhookUseCase.webhookRepository.MarkDelivered(message.ID)
+	} else {
+		giveUp := message.Attempts >= webhookUseCase.maxAttempts
+		errMark = webhookUseCase.webhookRepository.MarkFailed(message.ID, time.Now().Add(webhookBackoff(message.Attempts)), giveUp)
+	}
+	if errMark != nil {
+		fmt.Println(errMark)
+	}
+}
+
+func (webhookUseCase *WebhookUseCaseImpl) send(ctx context.Context, message models.WebhookMessage) (statusCode int, err error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, message.URL, bytes.NewReader(message.Payload))
+	if err != nil {
+		return
+	}
+	request.Header.Set("Content-Type", "application/json; charset=utf-8")
+	request.Header.Set("X-Webhook-Event", message.Event)
+	request.Header.Set("X-Webhook-Delivery", strconv.FormatInt(message.ID, 10))
+	request.Header.Set("X-Webhook-Attempt", strconv.Itoa(int(message.Attempts)))
+	request.Header.Set("X-Webhook-Signature", signature.Sign(message.Secret, message.Payload))
+
+	response, err := webhookUseCase.client.Do(request)
+	if err != nil {
+		return
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, webhookMaxResponseLen))
+
+	statusCode = response.StatusCode
+	if statusCode < http.StatusOK || statusCode >= http.StatusMultipleChoices {
+		err = fmt.Errorf("unexpected response status %d", statusCode)
+	}
+	return
+}
+
+func webhookBackoff(attempts int32) time.Duration {
+	if attempts < 1 {
+		attempts = 1
+	}
+	if attempts > 32 {
+		return webhookBackoffMax
+	}
+	backoff := webhookBackoffBase << (attempts - 1)
+	if backoff <= 0 || backoff > webhookBackoffMax {
+		return webhookBackoffMax
+	}
+	return backoff
+}
+
+// controlWebhookTarget runs right before connecting, when the address is already resolved
+func controlWebhookTarget(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip == nil || isPrivateAddress(ip) {
+		return fmt.Errorf("webhook target %s is not allowed", host)
+	}
+	return nil
+}
+
+func isPrivateAddress(ip net.IP) bool {
+	return ip.IsPrivate() || ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsUnspecified()
+}
+
+func generateWebhookSecret() (secret string, err error) {
+	buffer := make([]byte, webhookSecretLength)
+	if _, err = rand.Read(buffer); err != nil {
+		return
+	}
+	return hex.EncodeToString(buffer), nil
+}
@@ -0,0 +1,275 @@
+package impl
+
+import (
+	"Technopark_DB_Project/app/models"
+	"Technopark_DB_Project/app/repositories"
+	"Technopark_DB_Project/pkg/errors"
+	"Technopark_DB_Project/pkg/signature"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type webhookRepositoryMock struct {
+	repositories.WebhookRepository
+
+	mutex      sync.Mutex
+	created    []models.Webhook
+	deliveries []models.WebhookDelivery
+	delivered  []int64
+	failed     []webhookFailure
+}
+
+type webhookFailure struct {
+	messageID   int64
+	nextAttempt time.Time
+	giveUp      bool
+}
+
+func (mock *webhookRepositoryMock) Create(webhook *models.Webhook) error {
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+	mock.created = append(mock.created, *webhook)
+	return nil
+}
+
+func (mock *webhookRepositoryMock) LogDelivery(delivery *models.WebhookDelivery) error {
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+	mock.deliveries = append(mock.deliveries, *delivery)
+	return nil
+}
+
+func (mock *webhookRepositoryMock) MarkDelivered(messageID int64) error {
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+	mock.delivered = append(mock.delivered, messageID)
+	return nil
+}
+
+func (mock *webhookRepositoryMock) MarkFailed(messageID int64, nextAttempt time.Time, giveUp bool) error {
+	mock.mutex.Lock()
+	defer mock.mutex.Unlock()
+	mock.failed = append(mock.failed, webhookFailure{messageID: messageID, nextAttempt: nextAttempt, giveUp: giveUp})
+	return nil
+}
+
+type forumRepositoryMock struct {
+	repositories.ForumRepository
+	forum models.Forum
+}
+
+func (mock *forumRepositoryMock) GetBySlug(slug string) (*models.Forum, error) {
+	if !strings.EqualFold(slug, mock.forum.Slug) {
+		return nil, errors.ErrForumNotExist
+	}
+	forum := mock.forum
+	return &forum, nil
+}
+
+func newWebhookUseCase(repository *webhookRepositoryMock, allowPrivateTargets bool) *WebhookUseCaseImpl {
+	forumRepository := &forumRepositoryMock{forum: models.Forum{Slug: "forum", User: "owner"}}
+	return CreateWebhookUseCase(repository, forumRepository, time.Second, 3, allowPrivateTargets).(*WebhookUseCaseImpl)
+}
+
+func TestWebhookDeliverySigned(t *testing.T) {
+	payload := []byte(`{"event":"post.created","data":{"id":1}}`)
+	message := models.WebhookMessage{ID: 7, Webhook: 3, Event: models.EventPostCreated, Payload: payload, Secret: "secret", Attempts: 1}
+
+	received := make(chan *http.Request, 1)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if !signature.Verify("secret", body, r.Header.Get("X-Webhook-Signature")) {
+			t.Errorf("signature %q doesn't match the body", r.Header.Get("X-Webhook-Signature"))
+		}
+		if string(body) != string(payload) {
+			t.Errorf("body = %s, want %s", body, payload)
+		}
+		received <- r
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+	message.URL = receiver.URL
+
+	repository := &webhookRepositoryMock{}
+	newWebhookUseCase(repository, true).deliver(context.Background(), message)
+
+	request := <-received
+	for header, want := range map[string]string{
+		"X-Webhook-Event":    models.EventPostCreated,
+		"X-Webhook-Delivery": "7",
+		"X-Webhook-Attempt":  "1",
+		"Content-Type":       "application/json; charset=utf-8",
+	} {
+		if got := request.Header.Get(header); got != want {
+			t.Errorf("%s = %q, want %q", header, got, want)
+		}
+	}
+	if len(repository.delivered) != 1 || repository.delivered[0] != 7 || len(repository.failed) != 0 {
+		t.Fatalf("delivered = %v, failed = %v, want message 7 delivered", repository.delivered, repository.failed)
+	}
+	if len(repository.deliveries) != 1 || repository.deliveries[0].StatusCode != http.StatusNoContent || repository.deliveries[0].Error != "" {
+		t.Fatalf("logged deliveries = %+v", repository.deliveries)
+	}
+}
+
+func TestWebhookDeliveryRetry(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer receiver.Close()
+
+	tests := []struct {
+		name       string
+		attempts   int32
+		wantGiveUp bool
+	}{
+		{name: "first attempt", attempts: 1},
+		{name: "attempt before the last", attempts: 2},
+		{name: "last attempt", attempts: 3, wantGiveUp: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			repository := &webhookRepositoryMock{}
+			message := models.WebhookMessage{ID: 1, URL: receiver.URL, Event: models.EventVoteCast, Payload: []byte(`{}`), Attempts: test.attempts}
+
+			before := time.Now()
+			newWebhookUseCase(repository, true).deliver(context.Background(), message)
+
+			if len(repository.delivered) != 0 || len(repository.failed) != 1 {
+				t.Fatalf("delivered = %v, failed = %v, want one failure", repository.delivered, repository.failed)
+			}
+			failure := repository.failed[0]
+			if failure.giveUp != test.wantGiveUp {
+				t.Errorf("giveUp = %v, want %v", failure.giveUp, test.wantGiveUp)
+			}
+			if backoff := failure.nextAttempt.Sub(before); backoff < webhookBackoff(test.attempts) {
+				t.Errorf("next attempt in %v, want at least %v", backoff, webhookBackoff(test.attempts))
+			}
+			delivery := repository.deliveries[0]
+			if delivery.StatusCode != http.StatusInternalServerError || !strings.Contains(delivery.Error, "500") {
+				t.Errorf("logged delivery = %+v", delivery)
+			}
+		})
+	}
+}
+
+func TestWebhookBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int32
+		want     time.Duration
+	}{
+		{attempts: 0, want: webhookBackoffBase},
+		{attempts: 1, want: webhookBackoffBase},
+		{attempts: 2, want: 2 * webhookBackoffBase},
+		{attempts: 5, want: 16 * webhookBackoffBase},
+		{attempts: 20, want: webhookBackoffMax},
+		{attempts: 100, want: webhookBackoffMax},
+	}
+	for _, test := range tests {
+		if got := webhookBackoff(test.attempts); got != test.want {
+			t.Errorf("webhookBackoff(%d) = %v, want %v", test.attempts, got, test.want)
+		}
+	}
+}
+
+func TestWebhookPrivateTargetRefusedOnDelivery(t *testing.T) {
+	isReached := false
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		isReached = true
+	}))
+	defer receiver.Close()
+
+	repository := &webhookRepositoryMock{}
+	message := models.WebhookMessage{ID: 1, URL: receiver.URL, Event: models.EventVoteCast, Payload: []byte(`{}`), Attempts: 1}
+	newWebhookUseCase(repository, false).deliver(context.Background(), message)
+
+	if isReached {
+		t.Fatal("loopback receiver was reached")
+	}
+	if len(repository.failed) != 1 || !strings.Contains(repository.deliveries[0].Error, "not allowed") {
+		t.Fatalf("failed = %v, deliveries = %+v, want a refused delivery", repository.failed, repository.deliveries)
+	}
+}
+
+func TestWebhookCreateChecksTarget(t *testing.T) {
+	tests := []struct {
+		name                string
+		url                 string
+		allowPrivateTargets bool
+		wantErr             bool
+	}{
+		{name: "loopback", url: "http://127.0.0.1:8080/hook", wantErr: true},
+		{name: "loopback v6", url: "http://[::1]/hook", wantErr: true},
+		{name: "private", url: "https://10.1.2.3/hook", wantErr: true},
+		{name: "link-local", url: "http://169.254.169.254/latest/meta-data", wantErr: true},
+		{name: "unspecified", url: "http://0.0.0.0/hook", wantErr: true},
+		{name: "localhost", url: "http://localhost/hook", wantErr: true},
+		{name: "not http", url: "ftp://93.184.216.34/hook", wantErr: true},
+		{name: "relative", url: "/hook", wantErr: true},
+		{name: "public", url: "https://93.184.216.34/hook"},
+		{name: "loopback allowed", url: "http://127.0.0.1:8080/hook", allowPrivateTargets: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			repository := &webhookRepositoryMock{}
+			webhook := &models.Webhook{Nickname: "owner", URL: test.url, Events: []string{models.EventPostCreated}}
+
+			err := newWebhookUseCase(repository, test.allowPrivateTargets).Create("forum", webhook)
+			if test.wantErr {
+				if _, isValidationError := err.(*errors.ValidationError); !isValidationError {
+					t.Fatalf("err = %v, want a validation error", err)
+				}
+				if len(repository.created) != 0 {
+					t.Fatal("webhook was created")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(repository.created) != 1 || webhook.Secret == "" {
+				t.Fatalf("created = %+v, want one webhook with a secret", repository.created)
+			}
+		})
+	}
+}
+
+func TestWebhookCreateNotOwner(t *testing.T) {
+	repository := &webhookRepositoryMock{}
+	webhook := &models.Webhook{Nickname: "someone", URL: "https://93.184.216.34/hook", Events: []string{models.EventPostCreated}}
+
+	if err := newWebhookUseCase(repository, false).Create("forum", webhook); err != errors.ErrWebhookForbidden {
+		t.Fatalf("err = %v, want %v", err, errors.ErrWebhookForbidden)
+	}
+}
+
+func TestIsPrivateAddress(t *testing.T) {
+	tests := []struct {
+		address string
+		want    bool
+	}{
+		{"127.0.0.1", true},
+		{"::1", true},
+		{"10.0.0.1", true},
+		{"172.16.5.4", true},
+		{"192.168.1.1", true},
+		{"fd00::1", true},
+		{"169.254.169.254", true},
+		{"fe80::1", true},
+		{"0.0.0.0", true},
+		{"93.184.216.34", false},
+		{"2606:2800:220:1:248:1893:25c8:1946", false},
+	}
+	for _, test := range tests {
+		if got := isPrivateAddress(net.ParseIP(test.address)); got != test.want {
+			t.Errorf("isPrivateAddress(%s) = %v, want %v", test.address, got, test.want)
+		}
+	}
+}
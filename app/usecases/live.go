@@ -0,0 +1,11 @@
+package usecases
+
+import (
+	"Technopark_DB_Project/app/models"
+	"context"
+)
+
+type LiveUseCase interface {
+	Publish(event models.Event)
+	Subscribe(ctx context.Context, slug string) (events <-chan models.Event, err error)
+}
@@ -1,8 +1,16 @@
 package usecases
 
-import "Technopark_DB_Project/app/models"
+import (
+	"Technopark_DB_Project/app/models"
+	"Technopark_DB_Project/pkg/expand"
+)
 
 type PostUseCase interface {
-	Get(postID int64, relatedData *[]string) (postFull *models.PostFull, err error)
+	Get(postID int64, expansion expand.Tree) (postFull *models.PostFull, err error)
+	GetBatch(ids []int64) (batch *models.PostsBatch, err error)
 	Update(post *models.Post) (err error)
+	Vote(postID int64, vote *models.Vote) (post *models.Post, err error)
+	AddReaction(postID int64, reaction *models.Reaction) (post *models.Post, err error)
+	RemoveReaction(postID int64, reaction *models.Reaction) (post *models.Post, err error)
+	GetReactions(postID int64, limit int, sinceNickname, sinceReaction string, desc bool) (reactions *models.Reactions, err error)
 }
@@ -1,8 +1,13 @@
 package usecases
 
-import "Technopark_DB_Project/app/models"
+import (
+	"Technopark_DB_Project/app/models"
+	"io"
+)
 
 type ServiceUseCase interface {
 	Clear() (err error)
 	GetStatus() (status *models.Status, err error)
+	GetChanges(after int64, limit int) (changes *models.Changes, err error)
+	Import(reader io.Reader) (result *models.ImportResult, err error)
 }
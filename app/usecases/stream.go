@@ -0,0 +1,11 @@
+package usecases
+
+import (
+	"Technopark_DB_Project/app/models"
+	"context"
+)
+
+type StreamUseCase interface {
+	Run(ctx context.Context) (err error)
+	StreamPosts(ctx context.Context, slugOrID string, lastPostID int64) (posts <-chan models.Post, err error)
+}
@@ -1,11 +1,22 @@
 package usecases
 
-import "Technopark_DB_Project/app/models"
+import (
+	"Technopark_DB_Project/app/models"
+	"Technopark_DB_Project/pkg/expand"
+)
 
 type ThreadUseCase interface {
 	CreatePosts(slugOrID string, posts *models.Posts) (err error)
 	Get(slugOrID string) (thread *models.Thread, err error)
+	GetFull(slugOrID string, expansion expand.Tree) (threadFull *models.ThreadFull, err error)
+	GetByIDs(ids []int64) (threads *models.Threads, err error)
+	GetBatch(ids []int64) (batch *models.ThreadsBatch, err error)
 	Update(slugOrID string, thread *models.Thread) (err error)
 	GetPosts(slugOrID string, limit, since int, sort string, desc bool) (posts *models.Posts, err error)
+	IteratePosts(slugOrID string, limit, since int, sort string, desc bool, onPost func(post *models.Post) error) (err error)
 	Vote(slugOrID string, vote *models.Vote) (thread *models.Thread, err error)
+	Unvote(slugOrID string, nickname string) (thread *models.Thread, err error)
+	GetVotes(slugOrID string, limit int, since string, desc bool) (votes *models.Votes, err error)
+	Subscribe(slugOrID, nickname string) (subscription *models.Subscription, err error)
+	Unsubscribe(slugOrID, nickname string) (err error)
 }
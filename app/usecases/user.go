@@ -7,5 +7,14 @@ import (
 type UserUseCase interface {
 	Create(user *models.User) (users *models.Users, err error)
 	Get(nickname string) (user *models.User, err error)
+	GetByNicknames(nicknames []string) (users *models.Users, err error)
+	GetBatch(nicknames []string) (batch *models.UsersBatch, err error)
 	Update(user *models.User) (err error)
+	GetVotes(nickname string, limit int, since int64, desc bool) (votes *models.Votes, err error)
+	GetPosts(nickname, forum string, limit int, since int64, desc bool) (posts *models.Posts, err error)
+	GetThreads(nickname, forum string, limit int, since int64, desc bool) (threads *models.Threads, err error)
+	GetForums(nickname string, limit int, since string, desc bool) (forums *models.UserForums, err error)
+	GetNotifications(nickname, kind string, limit int, since int64, unreadOnly, desc bool) (notifications *models.Notifications, err error)
+	ReadNotifications(nickname string, ids []int64) (err error)
+	GetMentions(nickname string, limit int, since int64, desc bool) (posts *models.Posts, err error)
 }
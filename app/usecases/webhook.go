@@ -0,0 +1,14 @@
+package usecases
+
+import (
+	"Technopark_DB_Project/app/models"
+	"context"
+)
+
+type WebhookUseCase interface {
+	Run(ctx context.Context) (err error)
+	Create(slug string, webhook *models.Webhook) (err error)
+	GetByForum(slug, nickname string) (webhooks *models.Webhooks, err error)
+	Delete(slug string, id int64, nickname string) (err error)
+	GetDeliveries(slug string, id int64, nickname string, limit int, since int64, desc bool) (deliveries *models.WebhookDeliveries, err error)
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"Technopark_DB_Project/app/repositories/stores"
+	"Technopark_DB_Project/app/usecases/impl"
+	"Technopark_DB_Project/pkg/archive"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/jackc/pgx"
+)
+
+// runExport writes the same archive as GET /forum/:slug/export, straight from the database
+func runExport(args []string) (err error) {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	slug := flags.String("forum", "", "slug of the forum to export")
+	format := flags.String("format", archive.FormatNDJSON, "archive format: ndjson, csv or html")
+	output := flags.String("out", "", "output file, standard output if empty")
+	if err = flags.Parse(args); err != nil {
+		return
+	}
+	if *slug == "" {
+		return fmt.Errorf("forum slug is required")
+	}
+
+	settings := InitSettings()
+	conn, err := pgx.ParseConnectionString(settings.PostgresDsn)
+	if err != nil {
+		return
+	}
+	postgresConnection, err := pgx.NewConnPool(pgx.ConnPoolConfig{
+		ConnConfig:     conn,
+		MaxConnections: 2,
+	})
+	if err != nil {
+		return
+	}
+	defer postgresConnection.Close()
+
+	exportUseCase := impl.CreateExportUseCase(stores.CreateForumRepository(postgresConnection),
+		stores.CreateExportRepository(postgresConnection))
+
+	var writer io.Writer = os.Stdout
+	if *output != "" {
+		file, errCreate := os.Create(*output)
+		if errCreate != nil {
+			return errCreate
+		}
+		defer func() {
+			if errClose := file.Close(); err == nil {
+				err = errClose
+			}
+		}()
+		writer = file
+	}
+
+	return exportUseCase.ExportForum(*slug, *format, writer)
+}
@@ -1,10 +1,16 @@
 package main
 
 import (
+	"Technopark_DB_Project/app/gql"
 	"Technopark_DB_Project/app/handlers"
+	"Technopark_DB_Project/app/handlers/v2"
 	"Technopark_DB_Project/app/repositories/stores"
+	"Technopark_DB_Project/app/rpc"
 	"Technopark_DB_Project/app/usecases/impl"
+	"context"
 	"fmt"
+	"log"
+	"net"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
@@ -49,25 +55,110 @@ func (server *Server) Run() {
 	serviceRepo := stores.CreateServiceRepository(postgresConnection)
 	threadRepo := stores.CreateThreadRepository(postgresConnection)
 	voteRepo := stores.CreateVoteRepository(postgresConnection)
+	reactionRepo := stores.CreateReactionRepository(postgresConnection)
+	subscriptionRepo := stores.CreateSubscriptionRepository(postgresConnection)
+	notificationRepo := stores.CreateNotificationRepository(postgresConnection)
+	mentionRepo := stores.CreateMentionRepository(postgresConnection)
+	streamRepo := stores.CreateStreamRepository(postgresConnection)
+	webhookRepo := stores.CreateWebhookRepository(postgresConnection)
+	importRepo := stores.CreateImportRepository(postgresConnection)
+	exportRepo := stores.CreateExportRepository(postgresConnection)
 
 	// UseCases
-	userUseCase := impl.CreateUserUseCase(userRepo)
-	forumUseCase := impl.CreateForumUseCase(forumRepo, threadRepo, userRepo)
-	postUseCase := impl.CreatePostUseCase(postRepo, userRepo, threadRepo, forumRepo)
-	serviceUseCase := impl.CreateServiceUseCase(serviceRepo)
-	threadUseCase := impl.CreateThreadUseCase(threadRepo, voteRepo, postRepo, userRepo)
+	liveUseCase := impl.CreateLiveUseCase(forumRepo, server.settings.LiveBufferSize)
+	userUseCase := impl.CreateUserUseCase(userRepo, voteRepo, postRepo, threadRepo, forumRepo, notificationRepo, mentionRepo)
+	forumUseCase := impl.CreateForumUseCase(forumRepo, threadRepo, userRepo, subscriptionRepo, liveUseCase)
+	postUseCase := impl.CreatePostUseCase(postRepo, userRepo, threadRepo, forumRepo, voteRepo, reactionRepo, mentionRepo, liveUseCase, server.settings.Reactions)
+	serviceUseCase := impl.CreateServiceUseCase(serviceRepo, importRepo)
+	streamUseCase := impl.CreateStreamUseCase(threadRepo, postRepo, streamRepo)
+	exportUseCase := impl.CreateExportUseCase(forumRepo, exportRepo)
+	webhookUseCase := impl.CreateWebhookUseCase(webhookRepo, forumRepo, server.settings.WebhookTimeout, server.settings.WebhookMaxAttempts,
+		server.settings.WebhookAllowPrivateTargets)
+	threadUseCase := impl.CreateThreadUseCase(threadRepo, voteRepo, postRepo, userRepo, reactionRepo, mentionRepo, subscriptionRepo, liveUseCase, server.settings.NotifyReplyAncestors)
+
+	go func() {
+		if err := streamUseCase.Run(context.Background()); err != nil {
+			fmt.Println(err)
+		}
+	}()
+	go func() {
+		if err := webhookUseCase.Run(context.Background()); err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	// gRPC
+	grpcServer := rpc.CreateServer(server.settings.MaxListLimit, userUseCase, forumUseCase, threadUseCase, postUseCase, serviceUseCase)
+	go func() {
+		listener, err := net.Listen("tcp", server.settings.GRPCServerAddress)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		if err := grpcServer.Serve(listener); err != nil {
+			fmt.Println(err)
+		}
+	}()
+	defer grpcServer.Stop()
+
+	// GraphQL
+	graphQLExecutor, err := gql.CreateExecutor(server.settings.GraphQLMaxDepth, server.settings.GraphQLMaxComplexity, server.settings.MaxListLimit,
+		userUseCase, forumUseCase, threadUseCase, postUseCase, serviceUseCase)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	// Unversioned routes are kept for existing clients, /v1 is the same API under its version
+	v1RootURLs := []string{server.settings.RootURL, server.settings.RootURL + server.settings.V1URL}
+
+	// OpenAPI document, requests are validated against it
+	openAPIDocument := handlers.NewOpenAPIDocument(v1RootURLs, server.settings.UserURL, server.settings.ForumURL,
+		server.settings.PostURL, server.settings.ThreadURL, server.settings.ServiceURL, server.settings.GraphQLURL)
 
 	// Middlewares
 	router.Use(gin.Recovery())
 	router.Use(cors.New(server.settings.CorsConfig))
 
 	// Handlers
-	rootGroup := router.Group(server.settings.RootURL)
-	handlers.CreateUserHandler(rootGroup, server.settings.UserURL, userUseCase)
-	handlers.CreateForumHandler(rootGroup, server.settings.ForumURL, forumUseCase)
-	handlers.CreatePostHandler(rootGroup, server.settings.PostURL, postUseCase)
-	handlers.CreateServiceHandler(rootGroup, server.settings.ServiceURL, serviceUseCase)
-	handlers.CreateThreadHandler(rootGroup, server.settings.ThreadURL, threadUseCase)
+	for _, rootURL := range v1RootURLs {
+		rootGroup := router.Group(rootURL)
+		rootGroup.Use(handlers.CreateValidationMiddleware(rootURL, openAPIDocument, server.settings.StrictValidationOperations))
+		handlers.CreateUserHandler(rootGroup, server.settings.UserURL, server.settings.MaxBatchSize, userUseCase)
+		handlers.CreateForumHandler(rootGroup, server.settings.ForumURL, server.settings.MaxListLimit, server.settings.Origins, forumUseCase, liveUseCase)
+		handlers.CreatePostHandler(rootGroup, server.settings.PostURL, server.settings.MaxBatchSize, postUseCase)
+		handlers.CreateServiceHandler(rootGroup, server.settings.ServiceURL, server.settings.MaxListLimit, serviceUseCase)
+		handlers.CreateThreadHandler(rootGroup, server.settings.ThreadURL, server.settings.MaxListLimit, server.settings.MaxBatchSize, threadUseCase, streamUseCase)
+		handlers.CreateWebhookHandler(rootGroup, server.settings.ForumURL, webhookUseCase)
+		handlers.CreateExportHandler(rootGroup, server.settings.ForumURL, exportUseCase)
+		handlers.CreateGraphQLHandler(rootGroup, server.settings.GraphQLURL, graphQLExecutor)
+		if err = handlers.CreateOpenAPIHandler(rootGroup, server.settings.OpenAPIURL, openAPIDocument); err != nil {
+			log.Fatalf("can't serve the OpenAPI document: %v", err)
+		}
+	}
+
+	// API v2, envelope responses and cursor pagination over the same usecases
+	v2URL := server.settings.RootURL + server.settings.V2URL
+	v2Group := router.Group(v2URL)
+	v2.CreateUserHandler(v2Group, server.settings.UserURL, server.settings.MaxListLimit, userUseCase)
+	v2.CreateForumHandler(v2Group, server.settings.ForumURL, server.settings.MaxListLimit, forumUseCase)
+	v2.CreatePostHandler(v2Group, server.settings.PostURL, server.settings.MaxListLimit, postUseCase)
+	v2.CreateServiceHandler(v2Group, server.settings.ServiceURL, serviceUseCase)
+	v2.CreateThreadHandler(v2Group, server.settings.ThreadURL, server.settings.MaxListLimit, threadUseCase)
+	// v2 handlers validate requests themselves, the document only describes them
+	v2Document := v2.NewOpenAPIDocument(v2URL, server.settings.UserURL, server.settings.ForumURL,
+		server.settings.PostURL, server.settings.ThreadURL, server.settings.ServiceURL)
+	if err = handlers.CreateOpenAPIHandler(v2Group, server.settings.OpenAPIURL, v2Document); err != nil {
+		log.Fatalf("can't serve the API v2 OpenAPI document: %v", err)
+	}
+
+	// Every route has to be described before the server starts
+	if err = handlers.CheckOpenAPIRoutes(router.Routes(), v1RootURLs, handlers.DocumentedHandlers, openAPIDocument); err != nil {
+		log.Fatalf("OpenAPI document is out of date: %v", err)
+	}
+	if err = handlers.CheckOpenAPIRoutes(router.Routes(), []string{v2URL}, v2.DocumentedHandlers, v2Document); err != nil {
+		log.Fatalf("API v2 OpenAPI document is out of date: %v", err)
+	}
 
 	err = router.Run(server.settings.ServerAddress)
 	if err != nil {
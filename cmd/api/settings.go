@@ -2,19 +2,45 @@ package main
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/gin-contrib/cors"
 )
 
 type Settings struct {
 	RootURL    string
+	V1URL      string
+	V2URL      string
 	ForumURL   string
 	PostURL    string
 	ThreadURL  string
 	UserURL    string
 	ServiceURL string
+	GraphQLURL string
+	OpenAPIURL string
 
-	ServerAddress string
+	ServerAddress     string
+	GRPCServerAddress string
+
+	MaxListLimit int
+	MaxBatchSize int
+
+	GraphQLMaxDepth      int
+	GraphQLMaxComplexity int
+
+	// StrictValidationOperations are OpenAPI operation ids rejecting unknown body fields and query parameters
+	StrictValidationOperations []string
+
+	Reactions []string
+
+	NotifyReplyAncestors bool
+
+	LiveBufferSize int
+
+	WebhookTimeout     time.Duration
+	WebhookMaxAttempts int32
+	// Lets webhooks reach private, loopback and link-local addresses, for development only
+	WebhookAllowPrivateTargets bool
 
 	Origins        []string
 	AllowedMethods []string
@@ -33,13 +59,39 @@ type Settings struct {
 func InitSettings() (settings Settings) {
 	settings = Settings{
 		RootURL:    "/api",
+		V1URL:      "/v1",
+		V2URL:      "/v2",
 		ForumURL:   "/forum",
 		PostURL:    "/post",
 		ThreadURL:  "/thread",
 		UserURL:    "/user",
 		ServiceURL: "/service",
+		GraphQLURL: "/graphql",
+		OpenAPIURL: "/openapi.json",
+
+		ServerAddress:     ":5000",
+		GRPCServerAddress: ":5001",
+
+		MaxListLimit: 10000,
+		MaxBatchSize: 100,
 
-		ServerAddress: ":5000",
+		GraphQLMaxDepth:      12,
+		GraphQLMaxComplexity: 5000,
+
+		StrictValidationOperations: []string{
+			"postVote",
+			"threadVote",
+		},
+
+		Reactions: []string{
+			"thumbs_up",
+			"thumbs_down",
+			"heart",
+			"laugh",
+			"surprised",
+			"sad",
+			"angry",
+		},
 
 		Origins: []string{
 			"http://localhost:5000",
@@ -52,6 +104,15 @@ func InitSettings() (settings Settings) {
 			"OPTIONS",
 		},
 
+		NotifyReplyAncestors: false,
+
+		LiveBufferSize: 256,
+
+		WebhookTimeout:     10 * time.Second,
+		WebhookMaxAttempts: 8,
+
+		WebhookAllowPrivateTargets: false,
+
 		dbPort:     "5432",
 		dbUser:     "anton",
 		dbPassword: "db_password",
@@ -6,7 +6,8 @@ CREATE UNLOGGED TABLE IF NOT EXISTS users
     nickname citext COLLATE "ucs_basic" NOT NULL UNIQUE PRIMARY KEY,
     fullname text                       NOT NULL,
     about    text,
-    email    citext                     NOT NULL UNIQUE
+    email    citext                     NOT NULL UNIQUE,
+    reputation int                      DEFAULT 0
 );
 
 CREATE UNLOGGED TABLE IF NOT EXISTS forums
@@ -40,7 +41,8 @@ CREATE UNLOGGED TABLE IF NOT EXISTS posts
     forum     citext                NOT NULL REFERENCES forums (slug),
     thread    int                   NOT NULL REFERENCES threads (id),
     created   timestamp with time zone DEFAULT now(),
-    path      bigint[]              DEFAULT ARRAY []::INTEGER[]
+    path      bigint[]              DEFAULT ARRAY []::INTEGER[],
+    votes     int                   DEFAULT 0
 );
 
 CREATE UNLOGGED TABLE IF NOT EXISTS votes
@@ -51,14 +53,120 @@ CREATE UNLOGGED TABLE IF NOT EXISTS votes
     constraint user_thread_key unique (nickname, thread)
 );
 
+CREATE UNLOGGED TABLE IF NOT EXISTS post_votes
+(
+    nickname  citext NOT NULL REFERENCES users (nickname),
+    post      int    NOT NULL REFERENCES posts (id),
+    voice     int    NOT NULL,
+    constraint user_post_key unique (nickname, post)
+);
+
+CREATE UNLOGGED TABLE IF NOT EXISTS post_reactions
+(
+    nickname  citext NOT NULL REFERENCES users (nickname),
+    post      int    NOT NULL REFERENCES posts (id),
+    reaction  text   NOT NULL,
+    constraint user_post_reaction_key unique (post, nickname, reaction)
+);
+
+CREATE UNLOGGED TABLE IF NOT EXISTS mentions
+(
+    post     int    NOT NULL REFERENCES posts (id),
+    nickname citext NOT NULL REFERENCES users (nickname),
+    constraint post_mention_key unique (post, nickname)
+);
+
 CREATE UNLOGGED TABLE IF NOT EXISTS user_forum
 (
-    nickname citext COLLATE "ucs_basic" NOT NULL REFERENCES users (nickname),
-    forum    citext NOT NULL REFERENCES forums (slug),
+    nickname      citext COLLATE "ucs_basic" NOT NULL REFERENCES users (nickname),
+    forum         citext NOT NULL REFERENCES forums (slug),
+    posts         bigint DEFAULT 0,
+    threads       int    DEFAULT 0,
+    last_activity timestamp with time zone DEFAULT now(),
     constraint user_forum_key unique (nickname, forum)
 );
 
+CREATE UNLOGGED TABLE IF NOT EXISTS thread_subscriptions
+(
+    nickname citext NOT NULL REFERENCES users (nickname),
+    thread   int    NOT NULL REFERENCES threads (id),
+    constraint user_thread_subscription_key unique (thread, nickname)
+);
+
+CREATE UNLOGGED TABLE IF NOT EXISTS forum_subscriptions
+(
+    nickname citext NOT NULL REFERENCES users (nickname),
+    forum    citext NOT NULL REFERENCES forums (slug),
+    constraint user_forum_subscription_key unique (forum, nickname)
+);
+
+CREATE UNLOGGED TABLE IF NOT EXISTS notifications
+(
+    id       bigserial NOT NULL PRIMARY KEY,
+    nickname citext    NOT NULL REFERENCES users (nickname),
+    kind     text      NOT NULL,
+    author   citext    NOT NULL REFERENCES users (nickname),
+    forum    citext    NOT NULL REFERENCES forums (slug),
+    thread   int       NOT NULL REFERENCES threads (id),
+    post     int       REFERENCES posts (id),
+    is_read  bool      DEFAULT FALSE,
+    created  timestamp with time zone DEFAULT now()
+);
+
+CREATE UNLOGGED TABLE IF NOT EXISTS webhooks
+(
+    id      bigserial NOT NULL PRIMARY KEY,
+    forum   citext    NOT NULL REFERENCES forums (slug),
+    url     text      NOT NULL,
+    secret  text      NOT NULL,
+    events  text[]    NOT NULL,
+    created timestamp with time zone DEFAULT now()
+);
+
+CREATE UNLOGGED TABLE IF NOT EXISTS webhook_outbox
+(
+    id           bigserial NOT NULL PRIMARY KEY,
+    webhook      bigint    NOT NULL REFERENCES webhooks (id) ON DELETE CASCADE,
+    event        text      NOT NULL,
+    payload      json      NOT NULL,
+    status       text      DEFAULT 'pending',
+    attempts     int       DEFAULT 0,
+    next_attempt timestamp with time zone DEFAULT now(),
+    created      timestamp with time zone DEFAULT now()
+);
+
+CREATE UNLOGGED TABLE IF NOT EXISTS webhook_deliveries
+(
+    id          bigserial NOT NULL PRIMARY KEY,
+    webhook     bigint    NOT NULL REFERENCES webhooks (id) ON DELETE CASCADE,
+    message     bigint    NOT NULL REFERENCES webhook_outbox (id) ON DELETE CASCADE,
+    event       text      NOT NULL,
+    attempt     int       NOT NULL,
+    status_code int       DEFAULT 0,
+    error       text      DEFAULT '',
+    duration    bigint    DEFAULT 0,
+    created     timestamp with time zone DEFAULT now()
+);
+
+CREATE UNLOGGED TABLE IF NOT EXISTS changes
+(
+    id        bigserial NOT NULL PRIMARY KEY,
+    txid      bigint    NOT NULL DEFAULT txid_current(),
+    entity    text      NOT NULL,
+    operation text      NOT NULL,
+    data      json      NOT NULL,
+    created   timestamp with time zone DEFAULT now()
+);
+
 -- TRIGGERS AND PROCEDURES
+-- Set by the bulk import for its transaction, rows it inserts don't fire webhooks and notifications.
+-- The change feed still captures them, it promises every change.
+CREATE OR REPLACE FUNCTION is_importing()
+    RETURNS BOOLEAN AS
+$$
+SELECT COALESCE(current_setting('forum.importing', true), '') = 'on';
+$$ LANGUAGE sql STABLE;
+
 CREATE OR REPLACE FUNCTION insert_votes_proc()
     RETURNS TRIGGER AS
 $$
@@ -95,6 +203,104 @@ CREATE TRIGGER update_votes
     EXECUTE PROCEDURE update_votes_proc();
 
 
+CREATE OR REPLACE FUNCTION delete_votes_proc()
+    RETURNS TRIGGER AS
+$$
+BEGIN
+UPDATE threads
+SET votes = threads.votes - OLD.voice
+WHERE id = OLD.thread;
+RETURN OLD;
+END;
+$$ language plpgsql;
+
+CREATE TRIGGER delete_votes
+    AFTER DELETE
+    ON votes
+    FOR EACH ROW
+    EXECUTE PROCEDURE delete_votes_proc();
+
+
+CREATE OR REPLACE FUNCTION insert_post_votes_proc()
+    RETURNS TRIGGER AS
+$$
+BEGIN
+UPDATE posts
+SET votes = posts.votes + NEW.voice
+WHERE id = NEW.post;
+RETURN NEW;
+END;
+$$ language plpgsql;
+
+CREATE TRIGGER insert_post_votes
+    AFTER INSERT
+    ON post_votes
+    FOR EACH ROW
+    EXECUTE PROCEDURE insert_post_votes_proc();
+
+
+CREATE OR REPLACE FUNCTION update_post_votes_proc()
+    RETURNS TRIGGER AS
+$$
+BEGIN
+UPDATE posts
+SET votes = posts.votes + NEW.voice - OLD.voice
+WHERE id = NEW.post;
+RETURN NEW;
+END;
+$$ language plpgsql;
+
+CREATE TRIGGER update_post_votes
+    AFTER UPDATE
+    ON post_votes
+    FOR EACH ROW
+    EXECUTE PROCEDURE update_post_votes_proc();
+
+
+CREATE OR REPLACE FUNCTION delete_post_votes_proc()
+    RETURNS TRIGGER AS
+$$
+BEGIN
+UPDATE posts
+SET votes = posts.votes - OLD.voice
+WHERE id = OLD.post;
+RETURN OLD;
+END;
+$$ language plpgsql;
+
+CREATE TRIGGER delete_post_votes
+    AFTER DELETE
+    ON post_votes
+    FOR EACH ROW
+    EXECUTE PROCEDURE delete_post_votes_proc();
+
+
+CREATE OR REPLACE FUNCTION update_reputation_proc()
+    RETURNS TRIGGER AS
+$$
+BEGIN
+UPDATE users
+SET reputation = users.reputation + NEW.votes - OLD.votes
+WHERE nickname = NEW.author;
+RETURN NEW;
+END;
+$$ language plpgsql;
+
+CREATE TRIGGER update_thread_reputation
+    AFTER UPDATE OF votes
+    ON threads
+    FOR EACH ROW
+    WHEN (NEW.votes IS DISTINCT FROM OLD.votes)
+    EXECUTE PROCEDURE update_reputation_proc();
+
+CREATE TRIGGER update_post_reputation
+    AFTER UPDATE OF votes
+    ON posts
+    FOR EACH ROW
+    WHEN (NEW.votes IS DISTINCT FROM OLD.votes)
+    EXECUTE PROCEDURE update_reputation_proc();
+
+
 CREATE OR REPLACE FUNCTION insert_post_before_proc()
     RETURNS TRIGGER AS
 $$
@@ -131,6 +337,25 @@ CREATE TRIGGER insert_post_after
     EXECUTE PROCEDURE insert_post_after_proc();
 
 
+CREATE OR REPLACE FUNCTION notify_new_post_proc()
+    RETURNS TRIGGER AS
+$$
+BEGIN
+IF is_importing() THEN
+    RETURN NEW;
+END IF;
+PERFORM pg_notify('new_posts', NEW.thread || ':' || NEW.id);
+RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER notify_new_post
+    AFTER INSERT
+    ON posts
+    FOR EACH ROW
+    EXECUTE PROCEDURE notify_new_post_proc();
+
+
 CREATE OR REPLACE FUNCTION insert_threads_proc()
     RETURNS TRIGGER AS
 $$
@@ -153,9 +378,19 @@ CREATE OR REPLACE FUNCTION add_user()
     RETURNS TRIGGER AS
 $$
 BEGIN
-INSERT INTO user_forum (nickname, forum)
-VALUES (NEW.author, NEW.forum)
-    ON CONFLICT do nothing;
+IF TG_TABLE_NAME = 'posts' THEN
+    INSERT INTO user_forum (nickname, forum, posts, last_activity)
+    VALUES (NEW.author, NEW.forum, 1, NEW.created)
+        ON CONFLICT (nickname, forum) DO UPDATE
+        SET posts = user_forum.posts + 1,
+            last_activity = GREATEST(user_forum.last_activity, EXCLUDED.last_activity);
+ELSE
+    INSERT INTO user_forum (nickname, forum, threads, last_activity)
+    VALUES (NEW.author, NEW.forum, 1, NEW.created)
+        ON CONFLICT (nickname, forum) DO UPDATE
+        SET threads = user_forum.threads + 1,
+            last_activity = GREATEST(user_forum.last_activity, EXCLUDED.last_activity);
+END IF;
 RETURN NEW;
 END;
 $$ LANGUAGE plpgsql;
@@ -172,8 +407,156 @@ CREATE TRIGGER insert_new_post
     FOR EACH ROW
     EXECUTE PROCEDURE add_user();
 
+-- Webhook outbox is filled by triggers, so events are written in the same transaction as the change
+CREATE OR REPLACE FUNCTION enqueue_webhooks(forum_ citext, event_ text, payload_ json)
+    RETURNS VOID AS
+$$
+BEGIN
+IF is_importing() THEN
+    RETURN;
+END IF;
+INSERT INTO webhook_outbox (webhook, event, payload)
+SELECT id, event_, payload_
+FROM webhooks
+WHERE forum = forum_ AND event_ = ANY (events);
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION webhook_thread_proc()
+    RETURNS TRIGGER AS
+$$
+BEGIN
+PERFORM enqueue_webhooks(NEW.forum, 'thread.created', json_build_object(
+    'type', 'thread.created',
+    'forum', NEW.forum,
+    'thread', json_strip_nulls(row_to_json(NEW))));
+RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER webhook_thread
+    AFTER INSERT
+    ON threads
+    FOR EACH ROW
+    EXECUTE PROCEDURE webhook_thread_proc();
+
+CREATE OR REPLACE FUNCTION webhook_post_proc()
+    RETURNS TRIGGER AS
+$$
+DECLARE
+    event_ text := CASE WHEN TG_OP = 'INSERT' THEN 'post.created' ELSE 'post.updated' END;
+BEGIN
+PERFORM enqueue_webhooks(NEW.forum, event_, json_build_object(
+    'type', event_,
+    'forum', NEW.forum,
+    'post', json_build_object(
+        'id', NEW.id,
+        'parent', COALESCE(NEW.parent, 0),
+        'author', NEW.author,
+        'message', NEW.message,
+        'isEdited', NEW.is_edited,
+        'forum', NEW.forum,
+        'thread', NEW.thread,
+        'created', NEW.created,
+        'votes', NEW.votes)));
+RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER webhook_post_insert
+    AFTER INSERT
+    ON posts
+    FOR EACH ROW
+    EXECUTE PROCEDURE webhook_post_proc();
+
+CREATE TRIGGER webhook_post_update
+    AFTER UPDATE OF message
+    ON posts
+    FOR EACH ROW
+    WHEN (OLD.message IS DISTINCT FROM NEW.message)
+    EXECUTE PROCEDURE webhook_post_proc();
+
+CREATE OR REPLACE FUNCTION webhook_vote_proc()
+    RETURNS TRIGGER AS
+$$
+DECLARE
+    thread_ threads;
+BEGIN
+IF TG_OP = 'UPDATE' AND OLD.voice = NEW.voice THEN
+    RETURN NEW;
+END IF;
+-- Runs after insert_votes/update_votes, so the thread already has the new score
+SELECT * INTO thread_ FROM threads WHERE id = NEW.thread;
+PERFORM enqueue_webhooks(thread_.forum, 'vote.cast', json_build_object(
+    'type', 'vote.cast',
+    'forum', thread_.forum,
+    'thread', json_strip_nulls(row_to_json(thread_)),
+    'vote', json_build_object('nickname', NEW.nickname, 'voice', NEW.voice, 'thread', NEW.thread)));
+RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER webhook_vote
+    AFTER INSERT OR UPDATE
+    ON votes
+    FOR EACH ROW
+    EXECUTE PROCEDURE webhook_vote_proc();
+
+-- Change feed is written by triggers in the same transaction as the mutation.
+-- Counter columns (forum posts/threads, thread and post votes, reputation) are derived
+-- from other captured changes, so their updates are not captured. Bulk imported rows are captured as inserts.
+CREATE OR REPLACE FUNCTION capture_change_proc()
+    RETURNS TRIGGER AS
+$$
+BEGIN
+IF TG_OP = 'DELETE' THEN
+    INSERT INTO changes (entity, operation, data) VALUES (TG_TABLE_NAME, 'delete', row_to_json(OLD));
+    RETURN OLD;
+END IF;
+INSERT INTO changes (entity, operation, data) VALUES (TG_TABLE_NAME, lower(TG_OP), row_to_json(NEW));
+RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER capture_users
+    AFTER INSERT OR DELETE OR UPDATE OF nickname, fullname, about, email
+    ON users
+    FOR EACH ROW
+    EXECUTE PROCEDURE capture_change_proc();
+
+CREATE TRIGGER capture_forums
+    AFTER INSERT OR DELETE OR UPDATE OF title, user_, slug
+    ON forums
+    FOR EACH ROW
+    EXECUTE PROCEDURE capture_change_proc();
+
+CREATE TRIGGER capture_threads
+    AFTER INSERT OR DELETE OR UPDATE OF title, author, forum, message, slug, created
+    ON threads
+    FOR EACH ROW
+    EXECUTE PROCEDURE capture_change_proc();
+
+CREATE TRIGGER capture_posts
+    AFTER INSERT OR DELETE OR UPDATE OF parent, author, message, is_edited, forum, thread, created
+    ON posts
+    FOR EACH ROW
+    EXECUTE PROCEDURE capture_change_proc();
+
+CREATE TRIGGER capture_votes
+    AFTER INSERT OR UPDATE OR DELETE
+    ON votes
+    FOR EACH ROW
+    EXECUTE PROCEDURE capture_change_proc();
+
+CREATE TRIGGER capture_post_votes
+    AFTER INSERT OR UPDATE OR DELETE
+    ON post_votes
+    FOR EACH ROW
+    EXECUTE PROCEDURE capture_change_proc();
+
 -- INDEXES
 create index if not exists users_nickname_nickname_email on users (nickname, email);
+create index if not exists users_reputation_nickname on users (reputation, nickname);
 
 --create index if not exists user_forum_forum on user_forum (forum);
 create index if not exists user_forum_nickname on user_forum (nickname);
@@ -182,6 +565,7 @@ create index if not exists user_forum_all on user_forum (forum, nickname);
 --create index if not exists threads_slug on threads (forum);
 create index if not exists threads_created on threads (created);
 create index if not exists threads_forum_created on threads (forum, created);
+create index if not exists threads_author_created on threads (author, created, id);
 
 --create index if not exists posts_id_thread on posts (thread, id);
 create index if not exists posts_id_thread on posts (thread, id, parent NULLS FIRST);
@@ -190,5 +574,20 @@ create index if not exists posts_id_path_path1 on posts (path, (path[1]), id);
 create index if not exists posts_id_thread_parent_path1 on posts ((path[1]), thread, id, parent NULLS FIRST);
 --create index if not exists posts_thread on posts (thread);
 create index if not exists posts_thread_past on posts (thread, path);
+create index if not exists posts_thread_votes on posts (thread, votes DESC, id);
+create index if not exists posts_author_created on posts (author, created, id);
 
 create unique index if not exists votes_key on votes (thread, nickname);
+create unique index if not exists post_votes_key on post_votes (post, nickname);
+
+create index if not exists post_reactions_post_reaction on post_reactions (post, reaction);
+
+create index if not exists notifications_nickname_id on notifications (nickname, id);
+
+create index if not exists mentions_nickname_post on mentions (nickname, post);
+
+create index if not exists webhooks_forum on webhooks (forum);
+create index if not exists webhook_outbox_pending on webhook_outbox (next_attempt, id) where status = 'pending';
+create index if not exists webhook_deliveries_webhook_id on webhook_deliveries (webhook, id);
+
+create index if not exists changes_txid_id on changes (txid, id);
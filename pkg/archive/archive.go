@@ -0,0 +1,44 @@
+package archive
+
+import (
+	"Technopark_DB_Project/app/models"
+	"Technopark_DB_Project/pkg/errors"
+	"io"
+)
+
+const (
+	FormatNDJSON = "ndjson"
+	FormatCSV    = "csv"
+	FormatHTML   = "html"
+)
+
+var contentTypes = map[string]string{
+	FormatNDJSON: "application/x-ndjson; charset=utf-8",
+	FormatCSV:    "text/csv; charset=utf-8",
+	FormatHTML:   "text/html; charset=utf-8",
+}
+
+// Writer receives the forum first, then every thread followed by its posts in tree order
+type Writer interface {
+	WriteForum(forum *models.Forum) (err error)
+	WriteThread(thread *models.Thread) (err error)
+	WritePost(post *models.Post, depth int) (err error)
+	Close() (err error)
+}
+
+func NewWriter(format string, writer io.Writer) (archiveWriter Writer, err error) {
+	switch format {
+	case FormatNDJSON:
+		return newNDJSONWriter(writer), nil
+	case FormatCSV:
+		return newCSVWriter(writer), nil
+	case FormatHTML:
+		return newHTMLWriter(writer), nil
+	}
+	return nil, errors.ErrBadRequest
+}
+
+// ContentType returns an empty string for unknown formats
+func ContentType(format string) string {
+	return contentTypes[format]
+}
@@ -0,0 +1,45 @@
+package archive
+
+import (
+	"Technopark_DB_Project/app/models"
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+)
+
+var csvHeader = []string{"type", "id", "forum", "thread", "parent", "depth", "author", "title", "slug", "message",
+	"votes", "isEdited", "created"}
+
+type csvWriter struct {
+	writer *csv.Writer
+}
+
+func newCSVWriter(writer io.Writer) *csvWriter {
+	return &csvWriter{writer: csv.NewWriter(writer)}
+}
+
+func (csvArchive *csvWriter) WriteForum(forum *models.Forum) (err error) {
+	if err = csvArchive.writer.Write(csvHeader); err != nil {
+		return
+	}
+	return csvArchive.writer.Write([]string{models.ImportForum, "", forum.Slug, "", "", "", forum.User, forum.Title,
+		forum.Slug, "", "", "", ""})
+}
+
+func (csvArchive *csvWriter) WriteThread(thread *models.Thread) (err error) {
+	return csvArchive.writer.Write([]string{models.ImportThread, strconv.FormatInt(thread.ID, 10), thread.Forum,
+		strconv.FormatInt(thread.ID, 10), "", "", thread.Author, thread.Title, thread.Slug, thread.Message,
+		strconv.Itoa(int(thread.Votes)), "", thread.Created.Format(time.RFC3339Nano)})
+}
+
+func (csvArchive *csvWriter) WritePost(post *models.Post, depth int) (err error) {
+	return csvArchive.writer.Write([]string{models.ImportPost, strconv.FormatInt(post.ID, 10), post.Forum,
+		strconv.FormatInt(post.Thread, 10), strconv.FormatInt(post.Parent, 10), strconv.Itoa(depth), post.Author,
+		"", "", post.Message, strconv.Itoa(int(post.Votes)), strconv.FormatBool(post.IsEdited), post.Created})
+}
+
+func (csvArchive *csvWriter) Close() (err error) {
+	csvArchive.writer.Flush()
+	return csvArchive.writer.Error()
+}
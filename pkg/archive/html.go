@@ -0,0 +1,92 @@
+package archive
+
+import (
+	"Technopark_DB_Project/app/models"
+	"bufio"
+	"fmt"
+	"html"
+	"io"
+	"time"
+)
+
+const htmlIndent = 24
+
+const htmlHeader = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>%s</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+article { border-top: 1px solid #ccc; padding-top: 1em; }
+.post { border-left: 2px solid #ddd; padding-left: 8px; margin: 8px 0; }
+.meta { color: #666; font-size: 0.85em; }
+.message { white-space: pre-wrap; }
+</style>
+</head>
+<body>
+<h1>%s</h1>
+<p class="meta">/%s by %s</p>
+`
+
+type htmlWriter struct {
+	writer       *bufio.Writer
+	isThreadOpen bool
+}
+
+func newHTMLWriter(writer io.Writer) *htmlWriter {
+	return &htmlWriter{writer: bufio.NewWriter(writer)}
+}
+
+func (htmlArchive *htmlWriter) WriteForum(forum *models.Forum) (err error) {
+	title := html.EscapeString(forum.Title)
+	_, err = fmt.Fprintf(htmlArchive.writer, htmlHeader, title, title, html.EscapeString(forum.Slug),
+		html.EscapeString(forum.User))
+	return
+}
+
+func (htmlArchive *htmlWriter) WriteThread(thread *models.Thread) (err error) {
+	if err = htmlArchive.closeThread(); err != nil {
+		return
+	}
+	htmlArchive.isThreadOpen = true
+	_, err = fmt.Fprintf(htmlArchive.writer, "<article id=\"thread-%d\">\n<h2>%s</h2>\n"+
+		"<p class=\"meta\">%s, %s, votes: %d</p>\n<div class=\"message\">%s</div>\n",
+		thread.ID, html.EscapeString(thread.Title), html.EscapeString(thread.Author),
+		thread.Created.Format(time.RFC3339), thread.Votes, html.EscapeString(thread.Message))
+	return
+}
+
+func (htmlArchive *htmlWriter) WritePost(post *models.Post, depth int) (err error) {
+	_, err = fmt.Fprintf(htmlArchive.writer, "<div class=\"post\" id=\"post-%d\" style=\"margin-left: %dpx\">\n"+
+		"<p class=\"meta\">#%d %s, %s, votes: %d%s</p>\n<div class=\"message\">%s</div>\n</div>\n",
+		post.ID, depth*htmlIndent, post.ID, html.EscapeString(post.Author), html.EscapeString(post.Created),
+		post.Votes, htmlEditedMark(post.IsEdited), html.EscapeString(post.Message))
+	return
+}
+
+func (htmlArchive *htmlWriter) Close() (err error) {
+	if err = htmlArchive.closeThread(); err != nil {
+		return
+	}
+	if _, err = htmlArchive.writer.WriteString("</body>\n</html>\n"); err != nil {
+		return
+	}
+	return htmlArchive.writer.Flush()
+}
+
+func (htmlArchive *htmlWriter) closeThread() (err error) {
+	if !htmlArchive.isThreadOpen {
+		return
+	}
+	htmlArchive.isThreadOpen = false
+	_, err = htmlArchive.writer.WriteString("</article>\n")
+	return
+}
+
+func htmlEditedMark(isEdited bool) string {
+	if isEdited {
+		return ", edited"
+	}
+	return ""
+}
@@ -0,0 +1,49 @@
+package archive
+
+import (
+	"Technopark_DB_Project/app/models"
+	"bufio"
+	"io"
+
+	"github.com/mailru/easyjson"
+)
+
+// ndjsonWriter produces records in the bulk import format, so an archive can be imported back
+type ndjsonWriter struct {
+	writer *bufio.Writer
+}
+
+func newNDJSONWriter(writer io.Writer) *ndjsonWriter {
+	return &ndjsonWriter{writer: bufio.NewWriter(writer)}
+}
+
+func (ndjson *ndjsonWriter) WriteForum(forum *models.Forum) (err error) {
+	return ndjson.write(models.ImportForum, forum)
+}
+
+func (ndjson *ndjsonWriter) WriteThread(thread *models.Thread) (err error) {
+	return ndjson.write(models.ImportThread, thread)
+}
+
+func (ndjson *ndjsonWriter) WritePost(post *models.Post, depth int) (err error) {
+	return ndjson.write(models.ImportPost, post)
+}
+
+func (ndjson *ndjsonWriter) Close() (err error) {
+	return ndjson.writer.Flush()
+}
+
+func (ndjson *ndjsonWriter) write(recordType string, data easyjson.Marshaler) (err error) {
+	dataJSON, err := easyjson.Marshal(data)
+	if err != nil {
+		return
+	}
+	recordJSON, err := models.ImportRecord{Type: recordType, Data: dataJSON}.MarshalJSON()
+	if err != nil {
+		return
+	}
+	if _, err = ndjson.writer.Write(recordJSON); err != nil {
+		return
+	}
+	return ndjson.writer.WriteByte('\n')
+}
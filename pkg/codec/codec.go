@@ -0,0 +1,169 @@
+package codec
+
+import (
+	"Technopark_DB_Project/app/models/pb"
+	"Technopark_DB_Project/pkg/errors"
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mailru/easyjson"
+	"github.com/vmihailenco/msgpack/v5"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	MIMEJSON     = "application/json"
+	MIMEMsgPack  = "application/msgpack"
+	MIMEProtobuf = "application/protobuf"
+)
+
+var mediaTypeAliases = map[string]string{
+	MIMEJSON:                  MIMEJSON,
+	MIMEMsgPack:               MIMEMsgPack,
+	"application/x-msgpack":   MIMEMsgPack,
+	"application/vnd.msgpack": MIMEMsgPack,
+	MIMEProtobuf:              MIMEProtobuf,
+	"application/x-protobuf":  MIMEProtobuf,
+}
+
+var contentTypes = map[string]string{
+	MIMEJSON:     "application/json; charset=utf-8",
+	MIMEMsgPack:  MIMEMsgPack,
+	MIMEProtobuf: MIMEProtobuf,
+}
+
+type acceptRange struct {
+	mediaType string
+	quality   float64
+}
+
+// Negotiate picks the response media type from an Accept header, JSON is used unless a binary encoding is preferred
+func Negotiate(accept string) string {
+	if accept == "" {
+		return MIMEJSON
+	}
+
+	var ranges []acceptRange
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		quality := 1.0
+		if q, isFound := params["q"]; isFound {
+			if quality, err = strconv.ParseFloat(q, 64); err != nil {
+				continue
+			}
+		}
+		if quality <= 0 {
+			continue
+		}
+		if mediaType == "*/*" || mediaType == "application/*" {
+			mediaType = MIMEJSON
+		}
+		if canonical, isFound := mediaTypeAliases[mediaType]; isFound {
+			ranges = append(ranges, acceptRange{mediaType: canonical, quality: quality})
+		}
+	}
+	if len(ranges) == 0 {
+		return MIMEJSON
+	}
+
+	sort.SliceStable(ranges, func(i, j int) bool {
+		return ranges[i].quality > ranges[j].quality
+	})
+	return ranges[0].mediaType
+}
+
+// MediaType maps a request Content-Type to a supported media type, bodies of unknown types are read as JSON
+func MediaType(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return MIMEJSON
+	}
+	if canonical, isFound := mediaTypeAliases[mediaType]; isFound {
+		return canonical
+	}
+	return MIMEJSON
+}
+
+// Marshal encodes value in the given media type. Models without a protobuf schema fall back to JSON,
+// so the returned content type must be used for the response.
+func Marshal(mediaType string, value easyjson.Marshaler) (data []byte, contentType string, err error) {
+	switch mediaType {
+	case MIMEMsgPack:
+		buffer := new(bytes.Buffer)
+		if err = newMsgPackEncoder(buffer).Encode(value); err != nil {
+			return
+		}
+		return buffer.Bytes(), contentTypes[MIMEMsgPack], nil
+	case MIMEProtobuf:
+		if message := pb.ToMessage(value); message != nil {
+			data, err = proto.Marshal(message)
+			return data, contentTypes[MIMEProtobuf], err
+		}
+	}
+	data, err = easyjson.Marshal(value)
+	return data, contentTypes[MIMEJSON], err
+}
+
+// Decode reads a request body of the given media type into value
+func Decode(mediaType string, reader io.Reader, value easyjson.Unmarshaler) (err error) {
+	switch mediaType {
+	case MIMEMsgPack:
+		err = newMsgPackDecoder(reader).Decode(value)
+	case MIMEProtobuf:
+		message := pb.NewMessage(value)
+		if message == nil {
+			return errors.ErrUnsupportedMediaType
+		}
+		var data []byte
+		if data, err = io.ReadAll(reader); err != nil {
+			return errors.ErrBadRequest
+		}
+		if err = proto.Unmarshal(data, message); err == nil {
+			err = pb.FromMessage(message, value)
+		}
+	default:
+		err = easyjson.UnmarshalFromReader(reader, value)
+	}
+	if err != nil {
+		return errors.ErrBadRequest
+	}
+	return
+}
+
+// DecodeValue reads a body of the given media type into generic maps and slices, JSON numbers are kept as json.Number.
+// Protobuf carries no field names, so it can't be read without a model.
+func DecodeValue(mediaType string, data []byte) (value interface{}, err error) {
+	switch mediaType {
+	case MIMEMsgPack:
+		err = newMsgPackDecoder(bytes.NewReader(data)).Decode(&value)
+	case MIMEProtobuf:
+		err = errors.ErrUnsupportedMediaType
+	default:
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+		err = decoder.Decode(&value)
+	}
+	return
+}
+
+// MessagePack uses the json tags, so field names are the same as in JSON responses
+func newMsgPackEncoder(writer io.Writer) *msgpack.Encoder {
+	encoder := msgpack.NewEncoder(writer)
+	encoder.SetCustomStructTag("json")
+	encoder.UseCompactInts(true)
+	return encoder
+}
+
+func newMsgPackDecoder(reader io.Reader) *msgpack.Decoder {
+	decoder := msgpack.NewDecoder(reader)
+	decoder.SetCustomStructTag("json")
+	return decoder
+}
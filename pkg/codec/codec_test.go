@@ -0,0 +1,162 @@
+package codec
+
+import (
+	"Technopark_DB_Project/app/models"
+	"Technopark_DB_Project/pkg/errors"
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		accept string
+		want   string
+	}{
+		{accept: "", want: MIMEJSON},
+		{accept: "application/json", want: MIMEJSON},
+		{accept: "application/msgpack", want: MIMEMsgPack},
+		{accept: "application/x-msgpack", want: MIMEMsgPack},
+		{accept: "application/vnd.msgpack", want: MIMEMsgPack},
+		{accept: "application/x-protobuf", want: MIMEProtobuf},
+		{accept: "*/*", want: MIMEJSON},
+		{accept: "application/*", want: MIMEJSON},
+		{accept: "text/html", want: MIMEJSON},
+		{accept: "text/html, application/msgpack", want: MIMEMsgPack},
+		{accept: "application/json;q=0.5, application/protobuf", want: MIMEProtobuf},
+		{accept: "application/msgpack;q=0.9, application/json", want: MIMEJSON},
+		{accept: "application/protobuf, application/msgpack", want: MIMEProtobuf},
+		{accept: "application/msgpack;q=0, application/protobuf;q=0.1", want: MIMEProtobuf},
+		{accept: "application/msgpack;q=0", want: MIMEJSON},
+		{accept: "application/msgpack;q=high, application/protobuf;q=0.2", want: MIMEProtobuf},
+		{accept: "not a media type", want: MIMEJSON},
+	}
+	for _, test := range tests {
+		if got := Negotiate(test.accept); got != test.want {
+			t.Errorf("Negotiate(%q) = %s, want %s", test.accept, got, test.want)
+		}
+	}
+}
+
+func TestMediaType(t *testing.T) {
+	tests := []struct {
+		contentType string
+		want        string
+	}{
+		{contentType: "", want: MIMEJSON},
+		{contentType: "application/json; charset=utf-8", want: MIMEJSON},
+		{contentType: "application/x-msgpack", want: MIMEMsgPack},
+		{contentType: "application/protobuf", want: MIMEProtobuf},
+		{contentType: "text/plain", want: MIMEJSON},
+		{contentType: ";", want: MIMEJSON},
+	}
+	for _, test := range tests {
+		if got := MediaType(test.contentType); got != test.want {
+			t.Errorf("MediaType(%q) = %s, want %s", test.contentType, got, test.want)
+		}
+	}
+}
+
+func TestMarshalDecode(t *testing.T) {
+	user := &models.User{Nickname: "nickname", Fullname: "Full Name", About: "about", Email: "mail@example.com", Reputation: 3}
+
+	tests := []struct {
+		mediaType       string
+		wantContentType string
+	}{
+		{mediaType: MIMEJSON, wantContentType: "application/json; charset=utf-8"},
+		{mediaType: MIMEMsgPack, wantContentType: MIMEMsgPack},
+		{mediaType: MIMEProtobuf, wantContentType: MIMEProtobuf},
+	}
+	for _, test := range tests {
+		t.Run(test.mediaType, func(t *testing.T) {
+			data, contentType, err := Marshal(test.mediaType, user)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if contentType != test.wantContentType {
+				t.Errorf("content type = %s, want %s", contentType, test.wantContentType)
+			}
+
+			decoded := new(models.User)
+			if err = Decode(test.mediaType, bytes.NewReader(data), decoded); err != nil {
+				t.Fatal(err)
+			}
+			if *decoded != *user {
+				t.Errorf("decoded = %+v, want %+v", *decoded, *user)
+			}
+		})
+	}
+}
+
+func TestMarshalWithoutProtobufSchema(t *testing.T) {
+	reaction := &models.Reaction{Nickname: "nickname", Reaction: "+1"}
+
+	data, contentType, err := Marshal(MIMEProtobuf, reaction)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if contentType != contentTypes[MIMEJSON] || !json.Valid(data) {
+		t.Fatalf("got %s in %s, want a JSON fallback", data, contentType)
+	}
+
+	if err = Decode(MIMEProtobuf, bytes.NewReader(data), new(models.Reaction)); err != errors.ErrUnsupportedMediaType {
+		t.Fatalf("err = %v, want %v", err, errors.ErrUnsupportedMediaType)
+	}
+}
+
+func TestDecodeMalformed(t *testing.T) {
+	for _, mediaType := range []string{MIMEJSON, MIMEMsgPack, MIMEProtobuf} {
+		if err := Decode(mediaType, strings.NewReader("\xc1{"), new(models.User)); err != errors.ErrBadRequest {
+			t.Errorf("Decode(%s) err = %v, want %v", mediaType, err, errors.ErrBadRequest)
+		}
+	}
+}
+
+func TestDecodeValue(t *testing.T) {
+	msgPackData, _, err := Marshal(MIMEMsgPack, &models.User{Nickname: "nickname", Reputation: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		mediaType string
+		data      []byte
+		want      interface{}
+		wantErr   error
+	}{
+		{
+			name:      "json",
+			mediaType: MIMEJSON,
+			data:      []byte(`{"nickname":"nickname","reputation":3}`),
+			want:      map[string]interface{}{"nickname": "nickname", "reputation": json.Number("3")},
+		},
+		{
+			name:      "json array",
+			mediaType: MIMEJSON,
+			data:      []byte(`[1.5]`),
+			want:      []interface{}{json.Number("1.5")},
+		},
+		{
+			name:      "msgpack",
+			mediaType: MIMEMsgPack,
+			data:      msgPackData,
+			want:      map[string]interface{}{"nickname": "nickname", "fullname": "", "about": "", "email": "", "reputation": int8(3)},
+		},
+		{name: "protobuf", mediaType: MIMEProtobuf, wantErr: errors.ErrUnsupportedMediaType},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			value, err := DecodeValue(test.mediaType, test.data)
+			if err != test.wantErr {
+				t.Fatalf("err = %v, want %v", err, test.wantErr)
+			}
+			if !reflect.DeepEqual(value, test.want) {
+				t.Errorf("value = %#v, want %#v", value, test.want)
+			}
+		})
+	}
+}
@@ -22,6 +22,20 @@ var (
 	ErrParentPostNotExist        = errors.New("Can't find user with id ") // TODO
 	ErrParentPostFromOtherThread = errors.New("Can't find user with id ")
 
+	// Vote errors
+	ErrVoteNotFound = errors.New("vote not found")
+
+	// Reaction errors
+	ErrReactionNotAllowed = errors.New("reaction not allowed")
+	ErrReactionNotFound   = errors.New("reaction not found")
+
+	// Subscription errors
+	ErrSubscriptionNotFound = errors.New("subscription not found")
+
+	// Webhook errors
+	ErrWebhookNotFound  = errors.New("webhook not found")
+	ErrWebhookForbidden = errors.New("only forum owner can manage webhooks")
+
 	// User errors
 	ErrUserAlreadyExist = errors.New("user already exist")
 	ErrUserNotFound     = errors.New("Can't find user with id ") // TODO
@@ -31,6 +45,9 @@ var (
 	ErrBadInputData = errors.New("bad input data")
 	ErrBadRequest   = errors.New("bad request")
 
+	// Media type errors
+	ErrUnsupportedMediaType = errors.New("unsupported media type")
+
 	// Internal errors
 	ErrNotImplemented = errors.New("not implemented")
 	ErrInternal       = errors.New("internal error")
@@ -53,6 +70,20 @@ var errorToCodeMap = map[error]int{
 	ErrParentPostNotExist:        http.StatusNotFound,
 	ErrParentPostFromOtherThread: http.StatusConflict,
 
+	// Vote errors
+	ErrVoteNotFound: http.StatusNotFound,
+
+	// Reaction errors
+	ErrReactionNotAllowed: http.StatusBadRequest,
+	ErrReactionNotFound:   http.StatusNotFound,
+
+	// Subscription errors
+	ErrSubscriptionNotFound: http.StatusNotFound,
+
+	// Webhook errors
+	ErrWebhookNotFound:  http.StatusNotFound,
+	ErrWebhookForbidden: http.StatusForbidden,
+
 	// User errors
 	ErrUserAlreadyExist: http.StatusConflict,
 	ErrUserNotFound:     http.StatusNotFound,
@@ -62,12 +93,37 @@ var errorToCodeMap = map[error]int{
 	ErrBadInputData: http.StatusBadRequest,
 	ErrBadRequest:   http.StatusBadRequest,
 
+	// Media type errors
+	ErrUnsupportedMediaType: http.StatusUnsupportedMediaType,
+
 	// Internal errors
 	ErrNotImplemented: http.StatusNotImplemented,
 	ErrInternal:       http.StatusInternalServerError,
 }
 
+type ValidationError struct {
+	Fields []models.FieldError
+}
+
+func (validationError *ValidationError) Error() string {
+	return ErrBadInputData.Error()
+}
+
+func (validationError *ValidationError) Add(field, message string) {
+	validationError.Fields = append(validationError.Fields, models.FieldError{Field: field, Message: message})
+}
+
+func (validationError *ValidationError) OrNil() error {
+	if len(validationError.Fields) == 0 {
+		return nil
+	}
+	return validationError
+}
+
 func ResolveErrorToCode(err error) (code int) {
+	if _, isValidationError := err.(*ValidationError); isValidationError {
+		return http.StatusBadRequest
+	}
 	code, isErrorFound := errorToCodeMap[err]
 	if !isErrorFound {
 		code = http.StatusInternalServerError
@@ -75,13 +131,91 @@ func ResolveErrorToCode(err error) (code int) {
 	return
 }
 
-func PrepareErrorResponse(err error) (statusCode int, contentType string, errorJSON []byte) {
+func PrepareErrorModel(err error) (statusCode int, errorModel *models.Error) {
 	statusCode = ResolveErrorToCode(err)
+	errorModel = &models.Error{Message: err.Error()}
+	if validationError, isValidationError := err.(*ValidationError); isValidationError {
+		errorModel.Fields = validationError.Fields
+	}
+	return
+}
+
+func PrepareErrorResponse(err error) (statusCode int, contentType string, errorJSON []byte) {
+	statusCode, errorModel := PrepareErrorModel(err)
 	contentType = "application/json; charset=utf-8"
-	errorJSON, errMarshal := models.Error{Message: err.Error()}.MarshalJSON()
+	errorJSON, errMarshal := errorModel.MarshalJSON()
 	if errMarshal != nil {
 		statusCode = ResolveErrorToCode(ErrInternal)
 		errorJSON, _ = models.Error{Message: ErrInternal.Error()}.MarshalJSON()
 	}
 	return
 }
+
+type apiError struct {
+	code    string
+	message string
+}
+
+// errorToAPIErrorMap gives every error a stable code and its own message for API v2,
+// v1 keeps the messages above as clients of it may rely on them
+var errorToAPIErrorMap = map[error]apiError{
+	// Forum errors
+	ErrForumNotExist:        {"forum_not_found", "forum not found"},
+	ErrForumOwnerNotFound:   {"user_not_found", "forum owner not found"},
+	ErrForumAlreadyExists:   {"forum_exists", "forum with this slug already exists"},
+	ErrForumOrTheadNotFound: {"forum_or_author_not_found", "forum or thread author not found"},
+
+	// Thread errors
+	ErrThreadAlreadyExists: {"thread_exists", "thread with this slug already exists"},
+	ErrThreadNotFound:      {"thread_not_found", "thread not found"},
+
+	// Post errors
+	ErrPostNotFound:              {"post_not_found", "post not found"},
+	ErrParentPostNotExist:        {"parent_not_found", "parent post not found"},
+	ErrParentPostFromOtherThread: {"parent_in_other_thread", "parent post belongs to another thread"},
+
+	// Vote errors
+	ErrVoteNotFound: {"vote_not_found", ErrVoteNotFound.Error()},
+
+	// Reaction errors
+	ErrReactionNotAllowed: {"reaction_not_allowed", ErrReactionNotAllowed.Error()},
+	ErrReactionNotFound:   {"reaction_not_found", ErrReactionNotFound.Error()},
+
+	// Subscription errors
+	ErrSubscriptionNotFound: {"subscription_not_found", ErrSubscriptionNotFound.Error()},
+
+	// Webhook errors
+	ErrWebhookNotFound:  {"webhook_not_found", ErrWebhookNotFound.Error()},
+	ErrWebhookForbidden: {"forbidden", ErrWebhookForbidden.Error()},
+
+	// User errors
+	ErrUserAlreadyExist: {"user_exists", "user with this nickname or email already exists"},
+	ErrUserNotFound:     {"user_not_found", "user not found"},
+	ErrUserDataConflict: {"user_conflict", "email is taken by another user"},
+
+	// Request errors
+	ErrBadInputData: {"invalid_fields", ErrBadInputData.Error()},
+	ErrBadRequest:   {"bad_request", ErrBadRequest.Error()},
+
+	// Media type errors
+	ErrUnsupportedMediaType: {"unsupported_media_type", ErrUnsupportedMediaType.Error()},
+
+	// Internal errors
+	ErrNotImplemented: {"not_implemented", ErrNotImplemented.Error()},
+	ErrInternal:       {"internal", ErrInternal.Error()},
+}
+
+// PrepareAPIError describes an error for API v2, unknown errors are reported as internal without their text
+func PrepareAPIError(err error) (statusCode int, apiErrorModel *models.APIError) {
+	statusCode = ResolveErrorToCode(err)
+	if validationError, isValidationError := err.(*ValidationError); isValidationError {
+		described := errorToAPIErrorMap[ErrBadInputData]
+		return statusCode, &models.APIError{Code: described.code, Message: described.message, Fields: validationError.Fields}
+	}
+
+	described, isFound := errorToAPIErrorMap[err]
+	if !isFound {
+		described = errorToAPIErrorMap[ErrInternal]
+	}
+	return statusCode, &models.APIError{Code: described.code, Message: described.message}
+}
@@ -0,0 +1,84 @@
+package expand
+
+import (
+	"Technopark_DB_Project/pkg/errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Tree is a parsed expand parameter, every relation maps to the relations expanded under it
+type Tree map[string]Tree
+
+// Parse reads comma separated dot paths like thread.author. Paths missing from allowed are reported
+// in a validation error on field, a path also expands every relation it goes through.
+func Parse(field string, values []string, allowed []string) (tree Tree, err error) {
+	isAllowed := make(map[string]bool, len(allowed))
+	for _, path := range allowed {
+		isAllowed[path] = true
+	}
+
+	tree = Tree{}
+	validationError := new(errors.ValidationError)
+	for _, value := range values {
+		for _, path := range strings.Split(value, ",") {
+			path = strings.TrimSpace(path)
+			if path == "" {
+				continue
+			}
+			if !isAllowed[path] {
+				validationError.Add(field, fmt.Sprintf("unknown value %q, must be one of %s", path, strings.Join(allowed, ", ")))
+				continue
+			}
+
+			node := tree
+			for _, relation := range strings.Split(path, ".") {
+				if node[relation] == nil {
+					node[relation] = Tree{}
+				}
+				node = node[relation]
+			}
+		}
+	}
+	return tree, validationError.OrNil()
+}
+
+// FromAliases maps comma separated short names to the paths they stand for, so an older parameter
+// can be read into the same tree. Unknown names are reported in a validation error on field.
+func FromAliases(field string, values []string, aliases map[string]string) (paths []string, err error) {
+	names := make([]string, 0, len(aliases))
+	for name := range aliases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	validationError := new(errors.ValidationError)
+	for _, value := range values {
+		for _, name := range strings.Split(value, ",") {
+			name = strings.TrimSpace(name)
+			if name == "" {
+				continue
+			}
+			if path, isFound := aliases[name]; isFound {
+				paths = append(paths, path)
+			} else {
+				validationError.Add(field, fmt.Sprintf("unknown value %q, must be one of %s", name, strings.Join(names, ", ")))
+			}
+		}
+	}
+	return paths, validationError.OrNil()
+}
+
+// Has reports whether the relation is expanded, directly or by a longer path
+func (tree Tree) Has(relation string) bool {
+	_, isFound := tree[relation]
+	return isFound
+}
+
+// Sub returns the relations expanded under relation, an empty tree is returned for the ones not expanded
+func (tree Tree) Sub(relation string) Tree {
+	if sub := tree[relation]; sub != nil {
+		return sub
+	}
+	return Tree{}
+}
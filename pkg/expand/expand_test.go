@@ -0,0 +1,143 @@
+package expand
+
+import (
+	"Technopark_DB_Project/app/models"
+	"Technopark_DB_Project/pkg/errors"
+	"reflect"
+	"testing"
+)
+
+var allowedPaths = []string{"author", "thread", "thread.author", "forum", "forum.user"}
+
+func fieldsOf(err error) []models.FieldError {
+	if validationError, isValidationError := err.(*errors.ValidationError); isValidationError {
+		return validationError.Fields
+	}
+	return nil
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name       string
+		values     []string
+		want       Tree
+		wantFields []string
+	}{
+		{name: "nothing", want: Tree{}},
+		{name: "empty value", values: []string{""}, want: Tree{}},
+		{name: "one relation", values: []string{"author"}, want: Tree{"author": Tree{}}},
+		{
+			name:   "comma separated",
+			values: []string{"author, forum"},
+			want:   Tree{"author": Tree{}, "forum": Tree{}},
+		},
+		{
+			name:   "repeated parameter",
+			values: []string{"author", "forum"},
+			want:   Tree{"author": Tree{}, "forum": Tree{}},
+		},
+		{
+			name:   "path expands the relations it goes through",
+			values: []string{"thread.author"},
+			want:   Tree{"thread": Tree{"author": Tree{}}},
+		},
+		{
+			name:   "path and its prefix",
+			values: []string{"thread,thread.author,forum.user"},
+			want:   Tree{"thread": Tree{"author": Tree{}}, "forum": Tree{"user": Tree{}}},
+		},
+		{
+			name:       "unknown relation",
+			values:     []string{"author,parent"},
+			want:       Tree{"author": Tree{}},
+			wantFields: []string{"expand"},
+		},
+		{
+			name:       "unknown nested relation",
+			values:     []string{"author.forum", "thread.parent"},
+			want:       Tree{},
+			wantFields: []string{"expand", "expand"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tree, err := Parse("expand", test.values, allowedPaths)
+			if !reflect.DeepEqual(tree, test.want) {
+				t.Errorf("tree = %v, want %v", tree, test.want)
+			}
+
+			var fields []string
+			for _, field := range fieldsOf(err) {
+				fields = append(fields, field.Field)
+			}
+			if !reflect.DeepEqual(fields, test.wantFields) {
+				t.Errorf("error fields = %v, want %v", fields, test.wantFields)
+			}
+		})
+	}
+}
+
+func TestFromAliases(t *testing.T) {
+	aliases := map[string]string{"user": "author", "thread": "thread", "forum": "forum"}
+
+	tests := []struct {
+		name        string
+		values      []string
+		want        []string
+		wantMessage string
+	}{
+		{name: "nothing"},
+		{name: "aliases", values: []string{"user, thread", "forum"}, want: []string{"author", "thread", "forum"}},
+		{name: "empty names", values: []string{",user,"}, want: []string{"author"}},
+		{
+			name:        "unknown name",
+			values:      []string{"user,author"},
+			want:        []string{"author"},
+			wantMessage: `unknown value "author", must be one of forum, thread, user`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			paths, err := FromAliases("related", test.values, aliases)
+			if !reflect.DeepEqual(paths, test.want) {
+				t.Errorf("paths = %v, want %v", paths, test.want)
+			}
+
+			fields := fieldsOf(err)
+			if test.wantMessage == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", fields)
+				}
+				return
+			}
+			if len(fields) != 1 || fields[0].Field != "related" || fields[0].Message != test.wantMessage {
+				t.Errorf("error fields = %v, want related: %s", fields, test.wantMessage)
+			}
+		})
+	}
+}
+
+func TestTree(t *testing.T) {
+	tree := Tree{"thread": Tree{"author": Tree{}}, "forum": Tree{}}
+
+	tests := []struct {
+		relation string
+		wantHas  bool
+		wantSub  Tree
+	}{
+		{relation: "thread", wantHas: true, wantSub: Tree{"author": Tree{}}},
+		{relation: "forum", wantHas: true, wantSub: Tree{}},
+		{relation: "author", wantHas: false, wantSub: Tree{}},
+	}
+	for _, test := range tests {
+		if got := tree.Has(test.relation); got != test.wantHas {
+			t.Errorf("Has(%q) = %v, want %v", test.relation, got, test.wantHas)
+		}
+		if got := tree.Sub(test.relation); !reflect.DeepEqual(got, test.wantSub) {
+			t.Errorf("Sub(%q) = %v, want %v", test.relation, got, test.wantSub)
+		}
+	}
+	if sub := Tree(nil).Sub("thread"); sub == nil || sub.Has("author") {
+		t.Errorf("Sub of a nil tree = %v, want an empty tree", sub)
+	}
+}
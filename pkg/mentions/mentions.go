@@ -0,0 +1,23 @@
+package mentions
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Mention must not be glued to a preceding word, so e-mail addresses are not taken for mentions
+var regMention = regexp.MustCompile(`(?:^|[^a-zA-Z0-9_.@])@([a-zA-Z0-9_.]+)`)
+
+func Parse(message string) (nicknames []string) {
+	seen := make(map[string]bool)
+	for _, match := range regMention.FindAllStringSubmatch(message, -1) {
+		nickname := strings.TrimRight(match[1], ".")
+		key := strings.ToLower(nickname)
+		if nickname == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		nicknames = append(nicknames, nickname)
+	}
+	return
+}
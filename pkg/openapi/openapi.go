@@ -0,0 +1,141 @@
+package openapi
+
+import (
+	"regexp"
+	"strings"
+)
+
+const Version = "3.0.3"
+
+type Document struct {
+	OpenAPI    string               `json:"openapi"`
+	Info       Info                 `json:"info"`
+	Servers    []Server             `json:"servers,omitempty"`
+	Paths      map[string]*PathItem `json:"paths"`
+	Components Components           `json:"components"`
+
+	modelNames map[interface{}]string
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+type Server struct {
+	URL string `json:"url"`
+}
+
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+// PathItem maps lower case HTTP methods to operations
+type PathItem map[string]*Operation
+
+type Operation struct {
+	OperationID string               `json:"operationId"`
+	Summary     string               `json:"summary,omitempty"`
+	Description string               `json:"description,omitempty"`
+	Tags        []string             `json:"tags,omitempty"`
+	Parameters  []*Parameter         `json:"parameters,omitempty"`
+	RequestBody *RequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*Response `json:"responses"`
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Description string                `json:"description,omitempty"`
+	Required    bool                  `json:"required,omitempty"`
+	Content     map[string]*MediaType `json:"content"`
+}
+
+type Response struct {
+	Description string                `json:"description"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+func NewDocument(info Info, serverURLs ...string) *Document {
+	document := &Document{
+		OpenAPI:    Version,
+		Info:       info,
+		Paths:      make(map[string]*PathItem),
+		Components: Components{Schemas: make(map[string]*Schema)},
+		modelNames: make(map[interface{}]string),
+	}
+	for _, url := range serverURLs {
+		document.Servers = append(document.Servers, Server{URL: url})
+	}
+	return document
+}
+
+// Add registers an operation, path is in the OpenAPI form with {param} placeholders
+func (document *Document) Add(method, path string, operation *Operation) {
+	pathItem, isFound := document.Paths[path]
+	if !isFound {
+		pathItem = &PathItem{}
+		document.Paths[path] = pathItem
+	}
+	(*pathItem)[strings.ToLower(method)] = operation
+}
+
+// Operation looks up the operation registered for method and path
+func (document *Document) Operation(method, path string) (operation *Operation, isFound bool) {
+	pathItem, isFound := document.Paths[path]
+	if !isFound {
+		return nil, false
+	}
+	operation, isFound = (*pathItem)[strings.ToLower(method)]
+	return
+}
+
+// Schema resolves a $ref into the component it points to
+func (document *Document) Schema(schema *Schema) *Schema {
+	for schema != nil && schema.Ref != "" {
+		schema = document.Components.Schemas[strings.TrimPrefix(schema.Ref, componentsPrefix)]
+	}
+	return schema
+}
+
+var ginParam = regexp.MustCompile(`[:*]([^/]+)`)
+
+// PathFromGin turns a gin route like /user/:nickname/profile into /user/{nickname}/profile
+func PathFromGin(path string) string {
+	return ginParam.ReplaceAllString(path, "{$1}")
+}
+
+func PathParam(name, description string, schema *Schema) *Parameter {
+	return &Parameter{Name: name, In: "path", Description: description, Required: true, Schema: schema}
+}
+
+func QueryParam(name, description string, schema *Schema) *Parameter {
+	return &Parameter{Name: name, In: "query", Description: description, Schema: schema}
+}
+
+func HeaderParam(name, description string, schema *Schema) *Parameter {
+	return &Parameter{Name: name, In: "header", Description: description, Schema: schema}
+}
+
+func Body(mediaType string, schema *Schema) *RequestBody {
+	return &RequestBody{Required: true, Content: map[string]*MediaType{mediaType: {Schema: schema}}}
+}
+
+func Content(description, mediaType string, schema *Schema) *Response {
+	return &Response{Description: description, Content: map[string]*MediaType{mediaType: {Schema: schema}}}
+}
+
+func NoContent(description string) *Response {
+	return &Response{Description: description}
+}
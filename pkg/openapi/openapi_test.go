@@ -0,0 +1,134 @@
+package openapi
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/mailru/easyjson"
+)
+
+func TestPathFromGin(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "/service/status", want: "/service/status"},
+		{path: "/user/:nickname/profile", want: "/user/{nickname}/profile"},
+		{path: "/forum/:slug/:kind", want: "/forum/{slug}/{kind}"},
+		{path: "/static/*filepath", want: "/static/{filepath}"},
+	}
+	for _, test := range tests {
+		if got := PathFromGin(test.path); got != test.want {
+			t.Errorf("PathFromGin(%q) = %q, want %q", test.path, got, test.want)
+		}
+	}
+}
+
+func TestOperation(t *testing.T) {
+	document := NewDocument(Info{Title: "test", Version: "1"}, "/api")
+	get := &Operation{OperationID: "get"}
+	post := &Operation{OperationID: "post"}
+	document.Add(http.MethodGet, "/user/{nickname}", get)
+	document.Add(http.MethodPost, "/user/{nickname}", post)
+
+	tests := []struct {
+		method    string
+		path      string
+		want      *Operation
+		wantFound bool
+	}{
+		{method: http.MethodGet, path: "/user/{nickname}", want: get, wantFound: true},
+		{method: "post", path: "/user/{nickname}", want: post, wantFound: true},
+		{method: http.MethodDelete, path: "/user/{nickname}"},
+		{method: http.MethodGet, path: "/user"},
+	}
+	for _, test := range tests {
+		operation, isFound := document.Operation(test.method, test.path)
+		if operation != test.want || isFound != test.wantFound {
+			t.Errorf("Operation(%s, %s) = %v, %v, want %v, %v", test.method, test.path, operation, isFound, test.want, test.wantFound)
+		}
+	}
+	if len(document.Servers) != 1 || document.Servers[0].URL != "/api" {
+		t.Errorf("servers = %v, want /api", document.Servers)
+	}
+}
+
+type testAuthor struct {
+	Nickname string `json:"nickname"`
+}
+
+type testBase struct {
+	ID int64 `json:"id"`
+}
+
+type testPost struct {
+	testBase
+	Author   *testAuthor         `json:"author"`
+	Parent   *testPost           `json:"parent,omitempty"`
+	Tags     []string            `json:"tags"`
+	Counts   map[string]int32    `json:"counts"`
+	Created  time.Time           `json:"created"`
+	Extra    easyjson.RawMessage `json:"extra"`
+	Rating   float64             `json:"rating"`
+	IsEdited bool                `json:"isEdited"`
+	Untagged string
+	Skipped  string `json:"-"`
+	hidden   string
+}
+
+func TestAddModel(t *testing.T) {
+	document := NewDocument(Info{Title: "test", Version: "1"})
+	if ref := document.AddModel("Author", testAuthor{}); ref.Ref != "#/components/schemas/Author" {
+		t.Fatalf("ref = %q", ref.Ref)
+	}
+	document.AddModel("Post", testPost{})
+	document.AddModel("PostAlias", testPost{})
+
+	post := document.Components.Schemas["Post"]
+	tests := []struct {
+		property string
+		want     *Schema
+	}{
+		{property: "id", want: Integer()},
+		{property: "author", want: Ref("Author")},
+		{property: "parent", want: Ref("Post")},
+		{property: "tags", want: ArrayOf(String())},
+		{property: "counts", want: &Schema{Type: "object", AdditionalProperties: &Schema{Type: "integer", Format: "int32"}}},
+		{property: "created", want: String().WithFormat("date-time")},
+		{property: "extra", want: &Schema{}},
+		{property: "rating", want: &Schema{Type: "number"}},
+		{property: "isEdited", want: Boolean()},
+		{property: "Untagged", want: String()},
+	}
+	for _, test := range tests {
+		if got := post.Properties[test.property]; !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s = %+v, want %+v", test.property, got, test.want)
+		}
+	}
+	if len(post.Properties) != len(tests) {
+		t.Errorf("got %d properties, want %d", len(post.Properties), len(tests))
+	}
+	if alias := document.Components.Schemas["PostAlias"]; !reflect.DeepEqual(alias, Ref("Post")) {
+		t.Errorf("PostAlias = %+v, want a reference to Post", alias)
+	}
+	if resolved := document.Schema(Ref("PostAlias")); resolved != post {
+		t.Errorf("PostAlias resolves to %+v, want the Post schema", resolved)
+	}
+}
+
+func TestAddRequestModel(t *testing.T) {
+	document := NewDocument(Info{Title: "test", Version: "1"})
+	document.AddModel("Author", testAuthor{})
+	document.AddModel("Post", testPost{})
+	ref := document.AddRequestModel("PostCreate", testPost{}, []string{"author", "tags"}, "author")
+
+	schema := document.Schema(ref)
+	if len(schema.Properties) != 2 || schema.Properties["author"] == nil || schema.Properties["tags"] == nil {
+		t.Errorf("properties = %v, want author and tags", schema.Properties)
+	}
+	if !reflect.DeepEqual(schema.Required, []string{"author"}) {
+		t.Errorf("required = %v, want author", schema.Required)
+	}
+}
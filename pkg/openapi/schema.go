@@ -0,0 +1,168 @@
+package openapi
+
+import (
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/mailru/easyjson"
+)
+
+const componentsPrefix = "#/components/schemas/"
+
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	Default              interface{}        `json:"default,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+}
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	rawMessageType = reflect.TypeOf(easyjson.RawMessage{})
+)
+
+func String() *Schema {
+	return &Schema{Type: "string"}
+}
+
+func Integer() *Schema {
+	return &Schema{Type: "integer", Format: "int64"}
+}
+
+func Boolean() *Schema {
+	return &Schema{Type: "boolean"}
+}
+
+func ArrayOf(items *Schema) *Schema {
+	return &Schema{Type: "array", Items: items}
+}
+
+func Ref(name string) *Schema {
+	return &Schema{Ref: componentsPrefix + name}
+}
+
+// WithEnum restricts the schema to the listed values
+func (schema *Schema) WithEnum(values ...interface{}) *Schema {
+	schema.Enum = values
+	return schema
+}
+
+func (schema *Schema) WithFormat(format string) *Schema {
+	schema.Format = format
+	return schema
+}
+
+func (schema *Schema) WithDefault(value interface{}) *Schema {
+	schema.Default = value
+	return schema
+}
+
+func (schema *Schema) WithMinimum(minimum float64) *Schema {
+	schema.Minimum = &minimum
+	return schema
+}
+
+// AsNullable lets null stand for the value
+func (schema *Schema) AsNullable() *Schema {
+	schema.Nullable = true
+	return schema
+}
+
+// AddModel registers the schema of a model under name, described by its json tags.
+// Later models refer to it by $ref, so nested models have to be added before the ones containing them.
+// The name is known before the model is described, so a model may refer to itself.
+func (document *Document) AddModel(name string, model interface{}) *Schema {
+	modelType := reflect.TypeOf(model)
+	if existingName, isFound := document.modelNames[modelType]; isFound {
+		document.Components.Schemas[name] = Ref(existingName)
+		return Ref(name)
+	}
+	document.modelNames[modelType] = name
+	document.Components.Schemas[name] = document.schemaOf(modelType, true)
+	return Ref(name)
+}
+
+// AddRequestModel registers a variant of a model used as a request body, only the listed fields
+// may be sent and the required ones must be
+func (document *Document) AddRequestModel(name string, model interface{}, fields []string, required ...string) *Schema {
+	schema := document.schemaOf(reflect.TypeOf(model), true)
+	properties := make(map[string]*Schema, len(fields))
+	for _, field := range fields {
+		properties[field] = schema.Properties[field]
+	}
+	schema.Properties = properties
+	schema.Required = required
+	document.Components.Schemas[name] = schema
+	return Ref(name)
+}
+
+// schemaOf describes a type, registered models are referenced unless inline is set for the top level
+func (document *Document) schemaOf(valueType reflect.Type, inline bool) *Schema {
+	valueType = indirect(valueType)
+	if name, isFound := document.modelNames[valueType]; isFound && !inline {
+		return Ref(name)
+	}
+
+	switch valueType {
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case rawMessageType:
+		return &Schema{}
+	}
+
+	switch valueType.Kind() {
+	case reflect.String:
+		return String()
+	case reflect.Bool:
+		return Boolean()
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return Integer()
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		return ArrayOf(document.schemaOf(valueType.Elem(), false))
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: document.schemaOf(valueType.Elem(), false)}
+	case reflect.Struct:
+		schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+		for i := 0; i < valueType.NumField(); i++ {
+			field := valueType.Field(i)
+			name := strings.Split(field.Tag.Get("json"), ",")[0]
+			isEmbeddedStruct := field.Anonymous && name == "" && indirect(field.Type).Kind() == reflect.Struct
+			if name == "-" || (field.PkgPath != "" && !isEmbeddedStruct) {
+				continue
+			}
+			// Embedded structs are inlined the same way JSON encoding does it, unexported ones included
+			if isEmbeddedStruct {
+				for embeddedName, embeddedSchema := range document.schemaOf(field.Type, true).Properties {
+					schema.Properties[embeddedName] = embeddedSchema
+				}
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+			schema.Properties[name] = document.schemaOf(field.Type, false)
+		}
+		return schema
+	}
+	return &Schema{}
+}
+
+func indirect(valueType reflect.Type) reflect.Type {
+	if valueType.Kind() == reflect.Ptr {
+		return valueType.Elem()
+	}
+	return valueType
+}
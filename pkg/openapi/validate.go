@@ -0,0 +1,189 @@
+package openapi
+
+import (
+	"Technopark_DB_Project/pkg/errors"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Validate checks a decoded body against schema, problems are added to validationError under paths
+// like [0].author the same way handlers name fields. Unknown object fields are errors only in strict mode.
+// Numbers may be json.Number, float64 or any integer type, so bodies decoded from MessagePack fit as well.
+func (document *Document) Validate(schema *Schema, value interface{}, strict bool, field string, validationError *errors.ValidationError) {
+	schema = document.Schema(schema)
+	if schema == nil || schema.Type == "" || (value == nil && schema.Nullable) {
+		return
+	}
+
+	switch schema.Type {
+	case "object":
+		object, isObject := value.(map[string]interface{})
+		if !isObject {
+			validationError.Add(fieldName(field), "must be an object")
+			return
+		}
+		for _, required := range schema.Required {
+			if _, isFound := object[required]; !isFound {
+				validationError.Add(join(field, required), "is required")
+			}
+		}
+		keys := make([]string, 0, len(object))
+		for key := range object {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			property, isKnown := schema.Properties[key]
+			switch {
+			case isKnown:
+				document.Validate(property, object[key], strict, join(field, key), validationError)
+			case schema.AdditionalProperties != nil:
+				document.Validate(schema.AdditionalProperties, object[key], strict, join(field, key), validationError)
+			case strict:
+				validationError.Add(join(field, key), "is not allowed")
+			}
+		}
+	case "array":
+		array, isArray := value.([]interface{})
+		if !isArray {
+			validationError.Add(fieldName(field), "must be an array")
+			return
+		}
+		for i, item := range array {
+			document.Validate(schema.Items, item, strict, fmt.Sprintf("%s[%d]", field, i), validationError)
+		}
+	case "string":
+		text, isString := value.(string)
+		if !isString {
+			validationError.Add(fieldName(field), "must be a string")
+			return
+		}
+		validateString(schema, text, field, validationError)
+	case "integer", "number":
+		number, isNumber := toNumber(value)
+		if !isNumber {
+			validationError.Add(fieldName(field), numberMessage(schema))
+			return
+		}
+		validateNumber(schema, number, field, validationError)
+	case "boolean":
+		if _, isBool := value.(bool); !isBool {
+			validationError.Add(fieldName(field), "must be a boolean")
+			return
+		}
+		validateEnum(schema, value, field, validationError)
+	}
+}
+
+// ValidateParameter checks a raw query or header value against the schema of the parameter
+func (document *Document) ValidateParameter(parameter *Parameter, raw string, validationError *errors.ValidationError) {
+	schema := document.Schema(parameter.Schema)
+	switch schema.Type {
+	case "integer", "number":
+		number, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			validationError.Add(parameter.Name, numberMessage(schema))
+			return
+		}
+		validateNumber(schema, number, parameter.Name, validationError)
+	case "boolean":
+		isSet, err := strconv.ParseBool(raw)
+		if err != nil {
+			validationError.Add(parameter.Name, "must be a boolean")
+			return
+		}
+		validateEnum(schema, isSet, parameter.Name, validationError)
+	case "string":
+		validateString(schema, raw, parameter.Name, validationError)
+	}
+}
+
+func validateString(schema *Schema, text, field string, validationError *errors.ValidationError) {
+	if schema.Format == "date-time" {
+		if _, err := time.Parse(time.RFC3339Nano, text); err != nil {
+			validationError.Add(fieldName(field), "must be an RFC 3339 timestamp")
+			return
+		}
+	}
+	validateEnum(schema, text, field, validationError)
+}
+
+func validateNumber(schema *Schema, number float64, field string, validationError *errors.ValidationError) {
+	if schema.Type == "integer" {
+		if number != math.Trunc(number) {
+			validationError.Add(fieldName(field), "must be an integer")
+			return
+		}
+		if schema.Format == "int32" && (number < math.MinInt32 || number > math.MaxInt32) {
+			validationError.Add(fieldName(field), "must be a 32-bit integer")
+			return
+		}
+	}
+	if schema.Minimum != nil && number < *schema.Minimum {
+		validationError.Add(fieldName(field), fmt.Sprintf("must be at least %v", *schema.Minimum))
+		return
+	}
+	validateEnum(schema, number, field, validationError)
+}
+
+func numberMessage(schema *Schema) string {
+	if schema.Type == "integer" {
+		return "must be an integer"
+	}
+	return "must be a number"
+}
+
+func validateEnum(schema *Schema, value interface{}, field string, validationError *errors.ValidationError) {
+	if len(schema.Enum) == 0 {
+		return
+	}
+	for _, allowed := range schema.Enum {
+		if isEqual(allowed, value) {
+			return
+		}
+	}
+	validationError.Add(fieldName(field), fmt.Sprintf("must be one of %v", schema.Enum))
+}
+
+func isEqual(allowed, value interface{}) bool {
+	if allowedNumber, isNumber := toNumber(allowed); isNumber {
+		number, isValueNumber := toNumber(value)
+		return isValueNumber && number == allowedNumber
+	}
+	return allowed == value
+}
+
+func toNumber(value interface{}) (number float64, isNumber bool) {
+	switch value := value.(type) {
+	case json.Number:
+		number, err := value.Float64()
+		return number, err == nil
+	case float32, float64:
+		return reflect.ValueOf(value).Float(), true
+	case int, int8, int16, int32, int64:
+		return float64(reflect.ValueOf(value).Int()), true
+	case uint, uint8, uint16, uint32, uint64:
+		return float64(reflect.ValueOf(value).Uint()), true
+	}
+	return 0, false
+}
+
+func join(field, key string) string {
+	if field == "" {
+		return key
+	}
+	return field + "." + key
+}
+
+// fieldName names the whole body when the problem is at its top level
+func fieldName(field string) string {
+	if field == "" {
+		return "body"
+	}
+	return field
+}
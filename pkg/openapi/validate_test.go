@@ -0,0 +1,153 @@
+package openapi
+
+import (
+	"Technopark_DB_Project/app/models"
+	"Technopark_DB_Project/pkg/errors"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func newValidateDocument() (document *Document, schema *Schema) {
+	document = NewDocument(Info{Title: "test", Version: "1"})
+	document.Components.Schemas["Vote"] = &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"nickname": String(),
+			"voice":    {Type: "integer", Format: "int32", Enum: []interface{}{-1, 1}},
+			"created":  String().WithFormat("date-time"),
+			"rating":   {Type: "number"},
+			"limit":    Integer().WithMinimum(1),
+			"isFinal":  Boolean(),
+			"tags":     {Type: "object", AdditionalProperties: String()},
+			"comment":  String().AsNullable(),
+		},
+		Required: []string{"nickname", "voice"},
+	}
+	return document, ArrayOf(Ref("Vote"))
+}
+
+func TestValidate(t *testing.T) {
+	document, schema := newValidateDocument()
+
+	tests := []struct {
+		name   string
+		body   string
+		strict bool
+		want   []models.FieldError
+	}{
+		{name: "valid", body: `[{"nickname":"a","voice":1,"created":"2022-01-02T03:04:05.123+03:00","rating":1.5,"limit":3,"isFinal":true,"tags":{"x":"y"}}]`},
+		{name: "not an array", body: `{}`, want: []models.FieldError{{Field: "body", Message: "must be an array"}}},
+		{name: "not an object", body: `[1]`, want: []models.FieldError{{Field: "[0]", Message: "must be an object"}}},
+		{
+			name: "required",
+			body: `[{"nickname":"a","voice":1},{}]`,
+			want: []models.FieldError{{Field: "[1].nickname", Message: "is required"}, {Field: "[1].voice", Message: "is required"}},
+		},
+		{
+			name: "types",
+			body: `[{"nickname":1,"voice":"1","rating":"high","isFinal":"yes","tags":{"x":1}}]`,
+			want: []models.FieldError{
+				{Field: "[0].isFinal", Message: "must be a boolean"},
+				{Field: "[0].nickname", Message: "must be a string"},
+				{Field: "[0].rating", Message: "must be a number"},
+				{Field: "[0].tags.x", Message: "must be a string"},
+				{Field: "[0].voice", Message: "must be an integer"},
+			},
+		},
+		{
+			name: "values",
+			body: `[{"nickname":"a","voice":2,"created":"yesterday","limit":0}]`,
+			want: []models.FieldError{
+				{Field: "[0].created", Message: "must be an RFC 3339 timestamp"},
+				{Field: "[0].limit", Message: "must be at least 1"},
+				{Field: "[0].voice", Message: "must be one of [-1 1]"},
+			},
+		},
+		{
+			name: "integers",
+			body: `[{"nickname":"a","voice":1.5},{"nickname":"a","voice":4294967296}]`,
+			want: []models.FieldError{{Field: "[0].voice", Message: "must be an integer"}, {Field: "[1].voice", Message: "must be a 32-bit integer"}},
+		},
+		{name: "null for a nullable field", body: `[{"nickname":"a","voice":1,"comment":null}]`},
+		{name: "null for a field", body: `[{"nickname":null,"voice":1}]`, want: []models.FieldError{{Field: "[0].nickname", Message: "must be a string"}}},
+		{name: "unknown field", body: `[{"nickname":"a","voice":1,"extra":true}]`},
+		{
+			name:   "unknown field in strict mode",
+			body:   `[{"nickname":"a","voice":1,"extra":true}]`,
+			strict: true,
+			want:   []models.FieldError{{Field: "[0].extra", Message: "is not allowed"}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var body interface{}
+			decoder := json.NewDecoder(strings.NewReader(test.body))
+			decoder.UseNumber()
+			if err := decoder.Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+
+			validationError := new(errors.ValidationError)
+			document.Validate(schema, body, test.strict, "", validationError)
+			if !reflect.DeepEqual(validationError.Fields, test.want) {
+				t.Errorf("fields = %v, want %v", validationError.Fields, test.want)
+			}
+		})
+	}
+}
+
+func TestValidateMsgPackNumbers(t *testing.T) {
+	document, schema := newValidateDocument()
+
+	// MessagePack decodes numbers into sized integers and floats instead of json.Number
+	body := []interface{}{
+		map[string]interface{}{"nickname": "a", "voice": int8(-1), "rating": float32(2), "limit": uint16(5)},
+		map[string]interface{}{"nickname": "a", "voice": uint64(1), "limit": int64(0)},
+	}
+	validationError := new(errors.ValidationError)
+	document.Validate(schema, body, true, "", validationError)
+
+	want := []models.FieldError{{Field: "[1].limit", Message: "must be at least 1"}}
+	if !reflect.DeepEqual(validationError.Fields, want) {
+		t.Errorf("fields = %v, want %v", validationError.Fields, want)
+	}
+}
+
+func TestValidateParameter(t *testing.T) {
+	document := NewDocument(Info{Title: "test", Version: "1"})
+
+	tests := []struct {
+		name      string
+		parameter *Parameter
+		raw       string
+		want      string
+	}{
+		{name: "integer", parameter: QueryParam("limit", "", Integer().WithMinimum(1)), raw: "10"},
+		{name: "not an integer", parameter: QueryParam("limit", "", Integer()), raw: "ten", want: "must be an integer"},
+		{name: "fraction", parameter: QueryParam("limit", "", Integer()), raw: "1.5", want: "must be an integer"},
+		{name: "below minimum", parameter: QueryParam("limit", "", Integer().WithMinimum(1)), raw: "0", want: "must be at least 1"},
+		{name: "number", parameter: QueryParam("rating", "", &Schema{Type: "number"}), raw: "x", want: "must be a number"},
+		{name: "boolean", parameter: QueryParam("desc", "", Boolean()), raw: "true"},
+		{name: "not a boolean", parameter: QueryParam("desc", "", Boolean()), raw: "yes", want: "must be a boolean"},
+		{name: "date-time", parameter: QueryParam("since", "", String().WithFormat("date-time")), raw: "2022-01-02T03:04:05Z"},
+		{name: "not a date-time", parameter: QueryParam("since", "", String().WithFormat("date-time")), raw: "2022-01-02", want: "must be an RFC 3339 timestamp"},
+		{name: "enum", parameter: QueryParam("sort", "", String().WithEnum("flat", "tree")), raw: "tree"},
+		{name: "not in enum", parameter: QueryParam("sort", "", String().WithEnum("flat", "tree")), raw: "random", want: "must be one of [flat tree]"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			validationError := new(errors.ValidationError)
+			document.ValidateParameter(test.parameter, test.raw, validationError)
+
+			var want []models.FieldError
+			if test.want != "" {
+				want = []models.FieldError{{Field: test.parameter.Name, Message: test.want}}
+			}
+			if !reflect.DeepEqual(validationError.Fields, want) {
+				t.Errorf("fields = %v, want %v", validationError.Fields, want)
+			}
+		})
+	}
+}
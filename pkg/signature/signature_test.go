@@ -0,0 +1,48 @@
+package signature
+
+import "testing"
+
+func TestSign(t *testing.T) {
+	tests := []struct {
+		secret string
+		body   string
+		want   string
+	}{
+		// Known answers from RFC 4231 test case 2 and an empty key and body
+		{secret: "Jefe", body: "what do ya want for nothing?", want: "sha256=5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843"},
+		{secret: "", body: "", want: "sha256=b613679a0814d9ec772f95d778c35fc5ff1697c493715653c6c712144292c5ad"},
+	}
+	for _, test := range tests {
+		if got := Sign(test.secret, []byte(test.body)); got != test.want {
+			t.Errorf("Sign(%q, %q) = %s, want %s", test.secret, test.body, got, test.want)
+		}
+	}
+}
+
+func TestVerify(t *testing.T) {
+	body := []byte(`{"event":"post.created"}`)
+	valid := Sign("secret", body)
+
+	tests := []struct {
+		name      string
+		secret    string
+		body      []byte
+		signature string
+		want      bool
+	}{
+		{name: "valid", secret: "secret", body: body, signature: valid, want: true},
+		{name: "other secret", secret: "other", body: body, signature: valid},
+		{name: "changed body", secret: "secret", body: []byte(`{"event":"post.updated"}`), signature: valid},
+		{name: "no prefix", secret: "secret", body: body, signature: valid[len(prefix):]},
+		{name: "other algorithm", secret: "secret", body: body, signature: "sha1=" + valid[len(prefix):]},
+		{name: "truncated", secret: "secret", body: body, signature: valid[:len(valid)-1]},
+		{name: "empty", secret: "secret", body: body},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := Verify(test.secret, test.body, test.signature); got != test.want {
+				t.Errorf("Verify(%q) = %v, want %v", test.signature, got, test.want)
+			}
+		})
+	}
+}
@@ -2,23 +2,280 @@ package validator
 
 import (
 	"Technopark_DB_Project/app/models"
+	"Technopark_DB_Project/pkg/errors"
+	"fmt"
+	"net/mail"
+	"net/url"
 	"regexp"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	maxNicknameLength = 64
+	maxFullnameLength = 256
+	maxAboutLength    = 4096
+	maxEmailLength    = 254
+	maxSlugLength     = 128
+	maxTitleLength    = 256
+	maxMessageLength  = 65536
+	maxURLLength      = 2048
 )
 
 var (
-	regLatinNumUnderline = regexp.MustCompile("[a-zA-Z0-9_]*")
-	regEmail             = regexp.MustCompile(".+@.+")
+	regNickname = regexp.MustCompile(`^[a-zA-Z0-9_.]+$`)
+	// Slug must contain at least one non-digit, otherwise it can't be told apart from thread id
+	regSlug = regexp.MustCompile(`^[a-zA-Z0-9_-]*[a-zA-Z_-][a-zA-Z0-9_-]*$`)
 )
 
-func ValidateUserData(user *models.User, isUpdate bool) (isValid bool) {
-	if !isUpdate && (len(user.Fullname) == 0 || len(user.Email) == 0) {
-		return false
+func ValidateUserData(user *models.User, isUpdate bool) (err error) {
+	validationError := new(errors.ValidationError)
+
+	validateNickname(validationError, "nickname", user.Nickname)
+	if !isUpdate || user.Fullname != "" {
+		validateRequiredText(validationError, "fullname", user.Fullname, maxFullnameLength)
+	}
+	validateMaxLength(validationError, "about", user.About, maxAboutLength)
+	if !isUpdate || user.Email != "" {
+		validateEmail(validationError, "email", user.Email)
+	}
+
+	return validationError.OrNil()
+}
+
+func ValidateForumData(forum *models.Forum) (err error) {
+	validationError := new(errors.ValidationError)
+
+	validateRequiredText(validationError, "title", forum.Title, maxTitleLength)
+	validateNickname(validationError, "user", forum.User)
+	validateSlug(validationError, "slug", forum.Slug, true)
+
+	return validationError.OrNil()
+}
+
+func ValidateThreadData(thread *models.Thread, isUpdate bool) (err error) {
+	validationError := new(errors.ValidationError)
+	validateThread(validationError, thread, isUpdate)
+	return validationError.OrNil()
+}
+
+func ValidatePostData(post *models.Post, isUpdate bool) (err error) {
+	validationError := new(errors.ValidationError)
+	validatePost(validationError, "", post, isUpdate)
+	return validationError.OrNil()
+}
+
+func ValidatePostsData(posts *models.Posts) (err error) {
+	validationError := new(errors.ValidationError)
+	for i := range *posts {
+		validatePost(validationError, fmt.Sprintf("[%d].", i), &(*posts)[i], false)
+	}
+	return validationError.OrNil()
+}
+
+func ValidateImportedThreadData(thread *models.Thread) (err error) {
+	validationError := new(errors.ValidationError)
+
+	validateThread(validationError, thread, false)
+	validateSlug(validationError, "forum", thread.Forum, true)
+	if thread.ID < 0 {
+		validationError.Add("id", "must not be negative")
+	}
+
+	return validationError.OrNil()
+}
+
+func ValidateImportedPostData(post *models.Post) (err error) {
+	validationError := new(errors.ValidationError)
+
+	validatePost(validationError, "", post, false)
+	if post.ID < 0 {
+		validationError.Add("id", "must not be negative")
+	}
+	if post.Thread <= 0 {
+		validationError.Add("thread", "must be positive")
+	}
+	if post.Created != "" {
+		if _, errParse := time.Parse(time.RFC3339Nano, post.Created); errParse != nil {
+			validationError.Add("created", "must be an RFC 3339 timestamp")
+		}
+	}
+
+	return validationError.OrNil()
+}
+
+func ValidateVoteData(vote *models.Vote) (err error) {
+	validationError := new(errors.ValidationError)
+
+	validateNickname(validationError, "nickname", vote.Nickname)
+	if vote.Voice != models.Like && vote.Voice != models.Dislike {
+		validationError.Add("voice", fmt.Sprintf("must be %d or %d", models.Dislike, models.Like))
+	}
+
+	return validationError.OrNil()
+}
+
+func ValidateReactionData(reaction *models.Reaction) (err error) {
+	validationError := new(errors.ValidationError)
+
+	validateNickname(validationError, "nickname", reaction.Nickname)
+	if reaction.Reaction == "" {
+		validationError.Add("reaction", "must not be empty")
+	}
+
+	return validationError.OrNil()
+}
+
+func ValidateSubscriptionData(subscription *models.Subscription) (err error) {
+	validationError := new(errors.ValidationError)
+	validateNickname(validationError, "nickname", subscription.Nickname)
+	return validationError.OrNil()
+}
+
+func ValidateWebhookData(webhook *models.Webhook) (err error) {
+	validationError := new(errors.ValidationError)
+
+	validateNickname(validationError, "nickname", webhook.Nickname)
+	validateURL(validationError, "url", webhook.URL)
+	if len(webhook.Events) == 0 {
+		validationError.Add("events", "must not be empty")
 	}
-	if !regLatinNumUnderline.MatchString(user.Fullname) {
-		return false
+	for i, event := range webhook.Events {
+		switch event {
+		case models.EventThreadCreated, models.EventPostCreated, models.EventPostUpdated, models.EventVoteCast:
+		default:
+			validationError.Add(fmt.Sprintf("events[%d]", i), fmt.Sprintf("must be one of %s, %s, %s, %s",
+				models.EventThreadCreated, models.EventPostCreated, models.EventPostUpdated, models.EventVoteCast))
+		}
 	}
-	if !regEmail.MatchString(user.Email) {
-		return false
+
+	return validationError.OrNil()
+}
+
+// ValidateBatchNicknames checks a batch lookup request, maxSize bounds the number of nicknames
+func ValidateBatchNicknames(batch *models.BatchNicknames, maxSize int) (err error) {
+	validationError := new(errors.ValidationError)
+
+	validateBatchSize(validationError, "nicknames", len(batch.Nicknames), maxSize)
+	for i, nickname := range batch.Nicknames {
+		validateNickname(validationError, fmt.Sprintf("nicknames[%d]", i), nickname)
+	}
+
+	return validationError.OrNil()
+}
+
+// ValidateBatchIDs checks a batch lookup request, maxSize bounds the number of ids
+func ValidateBatchIDs(batch *models.BatchIDs, maxSize int) (err error) {
+	validationError := new(errors.ValidationError)
+
+	validateBatchSize(validationError, "ids", len(batch.IDs), maxSize)
+	for i, id := range batch.IDs {
+		if id <= 0 {
+			validationError.Add(fmt.Sprintf("ids[%d]", i), "must be positive")
+		}
+	}
+
+	return validationError.OrNil()
+}
+
+func validateThread(validationError *errors.ValidationError, thread *models.Thread, isUpdate bool) {
+	if !isUpdate || thread.Title != "" {
+		validateRequiredText(validationError, "title", thread.Title, maxTitleLength)
+	}
+	if !isUpdate || thread.Message != "" {
+		validateRequiredText(validationError, "message", thread.Message, maxMessageLength)
+	}
+	if !isUpdate {
+		validateNickname(validationError, "author", thread.Author)
+		validateSlug(validationError, "slug", thread.Slug, false)
+	}
+}
+
+func validatePost(validationError *errors.ValidationError, prefix string, post *models.Post, isUpdate bool) {
+	if !isUpdate || post.Message != "" {
+		validateRequiredText(validationError, prefix+"message", post.Message, maxMessageLength)
+	}
+	if !isUpdate {
+		validateNickname(validationError, prefix+"author", post.Author)
+		if post.Parent < 0 {
+			validationError.Add(prefix+"parent", "must not be negative")
+		}
+	}
+}
+
+func validateNickname(validationError *errors.ValidationError, field, nickname string) {
+	switch {
+	case nickname == "":
+		validationError.Add(field, "must not be empty")
+	case utf8.RuneCountInString(nickname) > maxNicknameLength:
+		validationError.Add(field, fmt.Sprintf("must be at most %d characters long", maxNicknameLength))
+	case !regNickname.MatchString(nickname):
+		validationError.Add(field, "may contain only latin letters, digits, '_' and '.'")
+	}
+}
+
+func validateSlug(validationError *errors.ValidationError, field, slug string, isRequired bool) {
+	switch {
+	case slug == "":
+		if isRequired {
+			validationError.Add(field, "must not be empty")
+		}
+	case utf8.RuneCountInString(slug) > maxSlugLength:
+		validationError.Add(field, fmt.Sprintf("must be at most %d characters long", maxSlugLength))
+	case !regSlug.MatchString(slug):
+		validationError.Add(field, "may contain only latin letters, digits, '_' and '-' and must not be a number")
+	}
+}
+
+func validateEmail(validationError *errors.ValidationError, field, email string) {
+	if email == "" {
+		validationError.Add(field, "must not be empty")
+		return
+	}
+	if len(email) > maxEmailLength {
+		validationError.Add(field, fmt.Sprintf("must be at most %d characters long", maxEmailLength))
+		return
+	}
+	address, err := mail.ParseAddress(email)
+	if err != nil || address.Address != email {
+		validationError.Add(field, "must be a valid email address")
+	}
+}
+
+func validateURL(validationError *errors.ValidationError, field, rawURL string) {
+	if rawURL == "" {
+		validationError.Add(field, "must not be empty")
+		return
+	}
+	if len(rawURL) > maxURLLength {
+		validationError.Add(field, fmt.Sprintf("must be at most %d characters long", maxURLLength))
+		return
+	}
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || parsedURL.Host == "" {
+		validationError.Add(field, "must be an absolute http or https URL")
+	}
+}
+
+func validateBatchSize(validationError *errors.ValidationError, field string, size, maxSize int) {
+	switch {
+	case size == 0:
+		validationError.Add(field, "must not be empty")
+	case size > maxSize:
+		validationError.Add(field, fmt.Sprintf("must contain at most %d items", maxSize))
+	}
+}
+
+func validateRequiredText(validationError *errors.ValidationError, field, text string, maxLength int) {
+	if text == "" {
+		validationError.Add(field, "must not be empty")
+		return
+	}
+	validateMaxLength(validationError, field, text, maxLength)
+}
+
+func validateMaxLength(validationError *errors.ValidationError, field, text string, maxLength int) {
+	if utf8.RuneCountInString(text) > maxLength {
+		validationError.Add(field, fmt.Sprintf("must be at most %d characters long", maxLength))
 	}
-	return true
 }
@@ -0,0 +1,249 @@
+package validator
+
+import (
+	"Technopark_DB_Project/app/models"
+	"Technopark_DB_Project/pkg/errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// fieldsOf lists the fields reported by a validation error, nil stands for no error
+func fieldsOf(t *testing.T, err error) []string {
+	t.Helper()
+	if err == nil {
+		return nil
+	}
+	validationError, isValidationError := err.(*errors.ValidationError)
+	if !isValidationError {
+		t.Fatalf("err = %v, want a validation error", err)
+	}
+	var fields []string
+	for _, field := range validationError.Fields {
+		fields = append(fields, field.Field)
+	}
+	return fields
+}
+
+func TestValidateUserData(t *testing.T) {
+	valid := models.User{Nickname: "j.doe_1", Fullname: "John Doe", About: "about", Email: "j.doe@mail.ru"}
+
+	tests := []struct {
+		name     string
+		user     models.User
+		isUpdate bool
+		want     []string
+	}{
+		{name: "valid", user: valid},
+		{name: "empty", user: models.User{}, want: []string{"nickname", "fullname", "email"}},
+		{name: "update keeps empty fields", user: models.User{Nickname: "j.doe"}, isUpdate: true},
+		{name: "nickname with a space", user: models.User{Nickname: "j doe", Fullname: "John", Email: "j@mail.ru"}, want: []string{"nickname"}},
+		{name: "nickname in cyrillic", user: models.User{Nickname: "вася", Fullname: "John", Email: "j@mail.ru"}, want: []string{"nickname"}},
+		{name: "long nickname", user: models.User{Nickname: strings.Repeat("a", maxNicknameLength+1), Fullname: "John", Email: "j@mail.ru"}, want: []string{"nickname"}},
+		{name: "long about", user: models.User{Nickname: "j", Fullname: "John", Email: "j@mail.ru", About: strings.Repeat("я", maxAboutLength+1)}, want: []string{"about"}},
+		{name: "about at the limit in runes", user: models.User{Nickname: "j", Fullname: "John", Email: "j@mail.ru", About: strings.Repeat("я", maxAboutLength)}},
+		{name: "email without domain", user: models.User{Nickname: "j", Fullname: "John", Email: "j.doe"}, want: []string{"email"}},
+		{name: "email with a name", user: models.User{Nickname: "j", Fullname: "John", Email: "John <j@mail.ru>"}, want: []string{"email"}},
+		{name: "invalid email on update", user: models.User{Nickname: "j", Email: "j.doe"}, isUpdate: true, want: []string{"email"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := fieldsOf(t, ValidateUserData(&test.user, test.isUpdate)); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("fields = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestValidateForumData(t *testing.T) {
+	tests := []struct {
+		name  string
+		forum models.Forum
+		want  []string
+	}{
+		{name: "valid", forum: models.Forum{Title: "Forum", User: "j.doe", Slug: "forum-1_a"}},
+		{name: "empty", forum: models.Forum{}, want: []string{"title", "user", "slug"}},
+		{name: "numeric slug", forum: models.Forum{Title: "Forum", User: "j.doe", Slug: "42"}, want: []string{"slug"}},
+		{name: "slug with a dot", forum: models.Forum{Title: "Forum", User: "j.doe", Slug: "forum.1"}, want: []string{"slug"}},
+		{name: "long slug", forum: models.Forum{Title: "Forum", User: "j.doe", Slug: strings.Repeat("s", maxSlugLength+1)}, want: []string{"slug"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := fieldsOf(t, ValidateForumData(&test.forum)); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("fields = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestValidateThreadData(t *testing.T) {
+	tests := []struct {
+		name     string
+		thread   models.Thread
+		isUpdate bool
+		want     []string
+	}{
+		{name: "valid", thread: models.Thread{Title: "Thread", Author: "j.doe", Message: "message"}},
+		{name: "valid with slug", thread: models.Thread{Title: "Thread", Author: "j.doe", Message: "message", Slug: "thread"}},
+		{name: "empty", thread: models.Thread{}, want: []string{"title", "message", "author"}},
+		{name: "numeric slug", thread: models.Thread{Title: "Thread", Author: "j.doe", Message: "message", Slug: "7"}, want: []string{"slug"}},
+		{name: "empty update", thread: models.Thread{}, isUpdate: true},
+		{name: "update ignores author", thread: models.Thread{Title: "Thread", Author: "j doe"}, isUpdate: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := fieldsOf(t, ValidateThreadData(&test.thread, test.isUpdate)); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("fields = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestValidatePostsData(t *testing.T) {
+	tests := []struct {
+		name  string
+		posts models.Posts
+		want  []string
+	}{
+		{name: "no posts", posts: models.Posts{}},
+		{name: "valid", posts: models.Posts{{Author: "j.doe", Message: "first"}, {Author: "j.doe", Message: "reply", Parent: 1}}},
+		{
+			name:  "fields are prefixed with the index",
+			posts: models.Posts{{Author: "j.doe", Message: "first"}, {Author: "", Message: "", Parent: -1}},
+			want:  []string{"[1].message", "[1].author", "[1].parent"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := fieldsOf(t, ValidatePostsData(&test.posts)); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("fields = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestValidateImportedData(t *testing.T) {
+	threadTests := []struct {
+		name   string
+		thread models.Thread
+		want   []string
+	}{
+		{name: "valid", thread: models.Thread{ID: 1, Title: "Thread", Author: "j.doe", Forum: "forum", Message: "message"}},
+		{name: "no forum", thread: models.Thread{Title: "Thread", Author: "j.doe", Message: "message"}, want: []string{"forum"}},
+		{name: "negative id", thread: models.Thread{ID: -1, Title: "Thread", Author: "j.doe", Forum: "forum", Message: "message"}, want: []string{"id"}},
+	}
+	for _, test := range threadTests {
+		t.Run("thread "+test.name, func(t *testing.T) {
+			if got := fieldsOf(t, ValidateImportedThreadData(&test.thread)); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("fields = %v, want %v", got, test.want)
+			}
+		})
+	}
+
+	postTests := []struct {
+		name string
+		post models.Post
+		want []string
+	}{
+		{name: "valid", post: models.Post{ID: 1, Author: "j.doe", Message: "message", Thread: 1, Created: "2022-01-02T03:04:05.000Z"}},
+		{name: "no thread", post: models.Post{Author: "j.doe", Message: "message"}, want: []string{"thread"}},
+		{name: "negative id", post: models.Post{ID: -1, Author: "j.doe", Message: "message", Thread: 1}, want: []string{"id"}},
+		{name: "created not a timestamp", post: models.Post{Author: "j.doe", Message: "message", Thread: 1, Created: "today"}, want: []string{"created"}},
+	}
+	for _, test := range postTests {
+		t.Run("post "+test.name, func(t *testing.T) {
+			if got := fieldsOf(t, ValidateImportedPostData(&test.post)); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("fields = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestValidateVoteData(t *testing.T) {
+	tests := []struct {
+		name string
+		vote models.Vote
+		want []string
+	}{
+		{name: "like", vote: models.Vote{Nickname: "j.doe", Voice: models.Like}},
+		{name: "dislike", vote: models.Vote{Nickname: "j.doe", Voice: models.Dislike}},
+		{name: "zero voice", vote: models.Vote{Nickname: "j.doe"}, want: []string{"voice"}},
+		{name: "double like", vote: models.Vote{Nickname: "j.doe", Voice: 2}, want: []string{"voice"}},
+		{name: "no nickname", vote: models.Vote{Voice: models.Like}, want: []string{"nickname"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := fieldsOf(t, ValidateVoteData(&test.vote)); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("fields = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestValidateWebhookData(t *testing.T) {
+	events := []string{models.EventPostCreated, models.EventVoteCast}
+
+	tests := []struct {
+		name    string
+		webhook models.Webhook
+		want    []string
+	}{
+		{name: "valid", webhook: models.Webhook{Nickname: "j.doe", URL: "https://example.com/hook", Events: events}},
+		{name: "empty", webhook: models.Webhook{}, want: []string{"nickname", "url", "events"}},
+		{name: "relative url", webhook: models.Webhook{Nickname: "j.doe", URL: "/hook", Events: events}, want: []string{"url"}},
+		{name: "not http", webhook: models.Webhook{Nickname: "j.doe", URL: "ftp://example.com/hook", Events: events}, want: []string{"url"}},
+		{name: "long url", webhook: models.Webhook{Nickname: "j.doe", URL: "https://example.com/" + strings.Repeat("a", maxURLLength), Events: events}, want: []string{"url"}},
+		{
+			name:    "unknown event",
+			webhook: models.Webhook{Nickname: "j.doe", URL: "https://example.com/hook", Events: []string{models.EventPostCreated, "post.deleted"}},
+			want:    []string{"events[1]"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := fieldsOf(t, ValidateWebhookData(&test.webhook)); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("fields = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestValidateBatch(t *testing.T) {
+	nicknameTests := []struct {
+		name      string
+		nicknames []string
+		want      []string
+	}{
+		{name: "valid", nicknames: []string{"a", "b.c"}},
+		{name: "empty", want: []string{"nicknames"}},
+		{name: "too many", nicknames: []string{"a", "b", "c"}, want: []string{"nicknames"}},
+		{name: "invalid nickname", nicknames: []string{"a", "b c"}, want: []string{"nicknames[1]"}},
+	}
+	for _, test := range nicknameTests {
+		t.Run("nicknames "+test.name, func(t *testing.T) {
+			batch := &models.BatchNicknames{Nicknames: test.nicknames}
+			if got := fieldsOf(t, ValidateBatchNicknames(batch, 2)); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("fields = %v, want %v", got, test.want)
+			}
+		})
+	}
+
+	idTests := []struct {
+		name string
+		ids  []int64
+		want []string
+	}{
+		{name: "valid", ids: []int64{1, 2}},
+		{name: "empty", want: []string{"ids"}},
+		{name: "too many", ids: []int64{1, 2, 3}, want: []string{"ids"}},
+		{name: "not positive", ids: []int64{0, -1}, want: []string{"ids[0]", "ids[1]"}},
+	}
+	for _, test := range idTests {
+		t.Run("ids "+test.name, func(t *testing.T) {
+			batch := &models.BatchIDs{IDs: test.ids}
+			if got := fieldsOf(t, ValidateBatchIDs(batch, 2)); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("fields = %v, want %v", got, test.want)
+			}
+		})
+	}
+}